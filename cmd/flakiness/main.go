@@ -0,0 +1,116 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command flakiness ranks the retry.UntilSuccess call sites by how close they came to failing
+// across one or more test runs and suggests better MaxAttempts and DelayBetweenAttempts values.
+//
+// Usage:
+//
+//	go run ./cmd/flakiness [-top N] [-all] [file or dir...]
+//
+// Directories (tests/ by default) are searched recursively for retry-stats.jsonl files.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+)
+
+const statsFileName = "retry-stats.jsonl"
+
+func main() {
+	top := flag.Int("top", 20, "number of call sites to show")
+	all := flag.Bool("all", false, "also show call sites that never needed more than one attempt")
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"tests"}
+	}
+	files, err := findStatsFiles(paths)
+	if err != nil {
+		fail(err)
+	}
+	if len(files) == 0 {
+		fail(fmt.Errorf("no %s files found in %s", statsFileName, strings.Join(paths, ", ")))
+	}
+	stats, err := retry.LoadStats(files...)
+	if err != nil {
+		fail(err)
+	}
+
+	summaries := retry.Summarize(stats)
+	fmt.Printf("Analyzed %d retry loops from %d file(s)\n\n", len(stats), len(files))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FLAKINESS\tLOOPS\tFAILED\tNEARLY FAILED\tRETRIED\tMOST ATTEMPTS\tLONGEST WAIT\tCURRENT\tSUGGESTED\tCALL SITE")
+	shown := 0
+	for _, s := range summaries {
+		if shown == *top {
+			break
+		}
+		if !*all && s.Retried == 0 {
+			continue
+		}
+		shown++
+		suggested := "-"
+		if s.HasSuggestion() {
+			suggested = fmt.Sprintf("%d x %v", s.SuggestedMaxAttempts, s.SuggestedDelay)
+		}
+		fmt.Fprintf(w, "%.0f%%\t%d\t%d\t%d\t%d\t%d\t%v\t%d x %v\t%s\t%s\n",
+			100*s.Flakiness(), s.Loops, s.Exhausted, s.NearlyExhausted, s.Retried,
+			s.MaxAttemptsUsed, s.MaxSuccessfulWait.Round(100*time.Millisecond), s.MaxAttempts, s.Delay, suggested, location(s))
+	}
+	w.Flush()
+}
+
+// location returns the call site and, if it isn't in a test file, the test code that led to it
+func location(s retry.CallSiteSummary) string {
+	if s.TestSite == "" || s.TestSite == s.CallSite {
+		return s.CallSite
+	}
+	return fmt.Sprintf("%s (via %s)", s.CallSite, s.TestSite)
+}
+
+func findStatsFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if p == path && !d.IsDir() || !d.IsDir() && d.Name() == statsFileName {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,309 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command mtt lists the tests of the test suite and shows which of them would be executed,
+// without compiling or running them.
+//
+// Usage:
+//
+//	go run ./cmd/mtt list [-json] [-group G] [-id ID] [-run REGEXP] [-subtests] [dir...]
+//	go run ./cmd/mtt dry-run [-json] [-ocp-version V] [-capabilities C1,C2] [-run REGEXP] [dir...]
+//	go run ./cmd/mtt groups
+//
+// The dry-run uses the same environment variables as the test suite (TEST_GROUP, SMCP_VERSION,
+// OPERATOR_VERSION and OCP_ARCH). Requirements on the OCP version and capabilities depend on the
+// cluster, so they are only evaluated if -ocp-version and -capabilities are specified.
+// Directories default to pkg/tests and must be relative to the repository root.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/env"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+	"github.com/maistra/maistra-test-tool/pkg/util/test/discovery"
+	"github.com/maistra/maistra-test-tool/pkg/util/version"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	args := os.Args[2:]
+	switch os.Args[1] {
+	case "list":
+		list(args)
+	case "dry-run":
+		dryRun(args)
+	case "groups":
+		groups()
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: mtt list|dry-run|groups [flags] [dir...]")
+	os.Exit(2)
+}
+
+func list(args []string) {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	jsonOutput := flags.Bool("json", false, "print the tests as JSON")
+	group := flags.String("group", "", "only list tests in this group (e.g. smoke or Smoke)")
+	id := flags.String("id", "", "only list the test with this ID")
+	run := flags.String("run", "", "only list tests whose name matches this regular expression")
+	subtests := flags.Bool("subtests", false, "also list the subtests of each test")
+	_ = flags.Parse(args)
+
+	tests := discover(flags.Args(), *run)
+	var filtered []test.Metadata
+	for _, t := range tests {
+		if *group != "" && !t.IsPartOfGroup(parseGroup(*group)) {
+			continue
+		}
+		if *id != "" && t.Id != *id {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+
+	if *jsonOutput {
+		printJSON(filtered)
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PACKAGE\tTEST\tID\tGROUPS\tSMCP VERSIONS\tOTHER REQUIREMENTS\tSUBTESTS")
+	for _, t := range filtered {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n", t.Package, t.Name, orDash(t.Id), orDash(joinGroups(t.Groups)),
+			smcpVersions(t), orDash(strings.Join(otherRequirements(t), ", ")), len(t.Subtests))
+		if *subtests {
+			for _, s := range t.Subtests {
+				fmt.Fprintf(w, "\t\t\t\t\t\t- %s\n", s)
+			}
+		}
+	}
+	_ = w.Flush()
+	fmt.Printf("\n%d tests\n", len(filtered))
+}
+
+// DryRunResult is the outcome of a test in the dry-run
+type DryRunResult struct {
+	test.Metadata
+	// Status is run, skip or depends-on-cluster
+	Status     string   `json:"status"`
+	SkipReason string   `json:"skipReason,omitempty"`
+	DependsOn  []string `json:"dependsOn,omitempty"`
+}
+
+func dryRun(args []string) {
+	flags := flag.NewFlagSet("dry-run", flag.ExitOnError)
+	jsonOutput := flags.Bool("json", false, "print the results as JSON")
+	ocpVersion := flags.String("ocp-version", "", "evaluate MinOCPVersion against this OCP version")
+	capabilities := flags.String("capabilities", "", "evaluate capability requirements assuming that only these comma-separated capabilities are available")
+	run := flags.String("run", "", "only consider tests whose name matches this regular expression")
+	_ = flags.Parse(args)
+
+	environment := test.Environment{
+		TestGroup:       test.CurrentTestGroup(),
+		SMCPVersion:     env.GetSMCPVersion(),
+		OperatorVersion: env.GetOperatorVersion(),
+	}
+	if *ocpVersion != "" {
+		v, err := version.Parse(*ocpVersion)
+		if err != nil {
+			fail(err)
+		}
+		environment.OCPVersion = func() (version.Version, error) {
+			return v, nil
+		}
+	}
+	if flagSet(flags, "capabilities") {
+		available := map[test.Capability]bool{}
+		for _, c := range strings.Split(*capabilities, ",") {
+			available[test.Capability(strings.TrimSpace(c))] = true
+		}
+		environment.Capability = func(c test.Capability) test.CapabilityStatus {
+			if available[c] {
+				return test.CapabilityStatus{Available: true}
+			}
+			return test.CapabilityStatus{Reason: "not listed in -capabilities"}
+		}
+	}
+
+	var results []DryRunResult
+	counts := map[string]int{}
+	for _, t := range discover(flags.Args(), *run) {
+		result := DryRunResult{Metadata: t, Status: "run"}
+		reason, err := t.SkipReason(environment)
+		if err != nil {
+			fail(err)
+		}
+		if reason != "" {
+			result.Status = "skip"
+			result.SkipReason = strings.TrimPrefix(reason, "This test is being skipped because ")
+		} else if result.DependsOn = t.Unevaluated(environment); len(result.DependsOn) > 0 {
+			result.Status = "depends-on-cluster"
+		}
+		counts[result.Status]++
+		results = append(results, result)
+	}
+
+	if *jsonOutput {
+		printJSON(results)
+		return
+	}
+	fmt.Printf("Test group: %s, SMCP version: %s, operator version: %s, arch: %s\n\n",
+		environment.TestGroup, environment.SMCPVersion, environment.OperatorVersion, env.GetArch())
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tPACKAGE\tTEST\tREASON")
+	sort.SliceStable(results, func(i, j int) bool {
+		return statusOrder(results[i].Status) < statusOrder(results[j].Status)
+	})
+	for _, r := range results {
+		reason := r.SkipReason
+		if len(r.DependsOn) > 0 {
+			reason = "requires " + strings.Join(r.DependsOn, ", ")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", strings.ToUpper(r.Status), r.Package, r.Name, reason)
+	}
+	_ = w.Flush()
+	fmt.Printf("\n%d tests would run, %d depend on the cluster, %d would be skipped\n", counts["run"], counts["depends-on-cluster"], counts["skip"])
+}
+
+func groups() {
+	var names []string
+	for name := range test.TestGroupsByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTEST_GROUP")
+	for _, name := range names {
+		fmt.Fprintf(w, "%s\t%s\n", name, test.TestGroupsByName[name])
+	}
+	_ = w.Flush()
+}
+
+func discover(dirs []string, run string) []test.Metadata {
+	if len(dirs) == 0 {
+		dirs = []string{"pkg/tests"}
+	}
+	var runRegexp *regexp.Regexp
+	if run != "" {
+		var err error
+		if runRegexp, err = regexp.Compile(run); err != nil {
+			fail(err)
+		}
+	}
+	var tests []test.Metadata
+	for _, dir := range dirs {
+		found, err := discovery.Discover(".", dir)
+		if err != nil {
+			fail(err)
+		}
+		for _, t := range found {
+			if runRegexp == nil || runRegexp.MatchString(t.Name) {
+				tests = append(tests, t)
+			}
+		}
+	}
+	return tests
+}
+
+// parseGroup accepts both the name of the TestGroup constant and its value
+func parseGroup(str string) test.TestGroup {
+	for name, group := range test.TestGroupsByName {
+		if strings.EqualFold(name, str) || strings.EqualFold(string(group), str) {
+			return group
+		}
+	}
+	fail(fmt.Errorf("unknown test group %q (see mtt groups)", str))
+	return ""
+}
+
+func smcpVersions(t test.Metadata) string {
+	switch {
+	case t.MinVersion != nil && t.MaxVersion != nil:
+		return fmt.Sprintf(">=%s <=%s", t.MinVersion, t.MaxVersion)
+	case t.MinVersion != nil:
+		return fmt.Sprintf(">=%s", t.MinVersion)
+	case t.MaxVersion != nil:
+		return fmt.Sprintf("<=%s", t.MaxVersion)
+	}
+	return "all"
+}
+
+func otherRequirements(t test.Metadata) []string {
+	var requirements []string
+	if t.MinOperatorVersion != nil {
+		requirements = append(requirements, fmt.Sprintf("operator >=%s", t.MinOperatorVersion))
+	}
+	if t.MinOCPVersion != nil {
+		requirements = append(requirements, fmt.Sprintf("OCP >=%s", t.MinOCPVersion))
+	}
+	for _, c := range t.Capabilities {
+		requirements = append(requirements, string(c))
+	}
+	return requirements
+}
+
+func joinGroups(groups []test.TestGroup) string {
+	var str []string
+	for _, g := range groups {
+		str = append(str, string(g))
+	}
+	return strings.Join(str, ",")
+}
+
+func statusOrder(status string) int {
+	return map[string]int{"run": 0, "depends-on-cluster": 1, "skip": 2}[status]
+}
+
+func flagSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+func orDash(str string) string {
+	if str == "" {
+		return "-"
+	}
+	return str
+}
+
+func printJSON(v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		fail(err)
+	}
+	fmt.Println(string(data))
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
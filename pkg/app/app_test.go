@@ -0,0 +1,97 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+func TestInstallAndUninstall(t *testing.T) {
+	th := test.NewTestHelper(t)
+	cluster := oc.NewFakeCluster()
+	cluster.UseAsDefault(th)
+
+	apps := []App{Sleep("foo"), Httpbin("foo")}
+	InstallAndWaitReady(th, apps...)
+
+	for _, name := range []string{"sleep", "httpbin"} {
+		if cluster.Get(th, "foo", "deployment", name) == nil {
+			t.Fatalf("expected deployment %s to be created", name)
+		}
+		if cluster.Get(th, "foo", "service", name) == nil {
+			t.Fatalf("expected service %s to be created", name)
+		}
+	}
+
+	Uninstall(th, apps...)
+
+	for _, name := range []string{"sleep", "httpbin"} {
+		if cluster.Get(th, "foo", "deployment", name) != nil {
+			t.Fatalf("expected deployment %s to be deleted", name)
+		}
+	}
+}
+
+func TestSleepInjectsSidecar(t *testing.T) {
+	th := test.NewTestHelper(t)
+	cluster := oc.NewFakeCluster()
+	cluster.UseAsDefault(th)
+
+	Install(th, Sleep("foo"), SleepNoSidecar("bar"))
+
+	assertSidecarInjection(t, cluster.Get(th, "foo", "deployment", "sleep").Object, "true")
+	assertSidecarInjection(t, cluster.Get(th, "bar", "deployment", "sleep").Object, "false")
+}
+
+func TestExecInSleepPod(t *testing.T) {
+	th := test.NewTestHelper(t)
+	_, cluster := oc.NewFakeOC(th, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: sleep-1
+  namespace: foo
+  labels:
+    app: sleep
+spec:
+  containers:
+  - name: sleep
+    image: curl
+`)
+	cluster.UseAsDefault(th)
+
+	fake := shell.NewFakeExecutor()
+	fake.OnPattern(`^kubectl exec -n foo sleep-1 -c sleep -- curl .* http://httpbin:8000/ip`).Return("200")
+	defer shell.SetExecutor(fake)()
+
+	AssertSleepPodRequestSuccess(th, "foo", "http://httpbin:8000/ip")
+
+	if len(fake.Calls()) != 1 {
+		t.Fatalf("expected a single curl command, but got %v", fake.Commands())
+	}
+}
+
+func assertSidecarInjection(t *testing.T, deployment map[string]interface{}, expected string) {
+	t.Helper()
+	template := deployment["spec"].(map[string]interface{})["template"].(map[string]interface{})
+	annotations, _ := template["metadata"].(map[string]interface{})["annotations"].(map[string]interface{})
+	if annotations["sidecar.istio.io/inject"] != expected {
+		t.Fatalf("expected sidecar.istio.io/inject=%s, but got annotations %v", expected, annotations)
+	}
+}
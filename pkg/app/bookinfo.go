@@ -102,37 +102,6 @@ func BookinfoProductPageURL(t test.TestHelper, meshNamespace string) string {
 	return fmt.Sprintf("http://%s/productpage", istio.GetIngressGatewayHost(t, meshNamespace))
 }
 
-func FindBookinfoProductPageResponseFile(body []byte) string {
-	for _, file := range ProductPageResponseFiles {
-		if matchesFile(body, file) {
-			return file
-		}
-	}
-	return ""
-}
-
-func matchesFile(body []byte, file string) bool {
-	err := util.CompareHTTPResponse(body, file)
-	return err == nil
-}
-
-var ProductPageResponseFiles = []string{
-	"productpage-normal-user-mongo.html",
-	"productpage-normal-user-rating-one-star.html",
-	"productpage-normal-user-rating-unavailable.html",
-	"productpage-normal-user-v1.html",
-	"productpage-normal-user-v2.html",
-	"productpage-normal-user-v3.html",
-	"productpage-quota-exhausted.html",
-	"productpage-rbac-details-reviews-error.html",
-	"productpage-rbac-rating-error.html",
-	"productpage-review-timeout.html",
-	"productpage-test-user-v1.html",
-	"productpage-test-user-v2.html",
-	"productpage-test-user-v2-rating-unavailable.html",
-	"productpage-test-user-v2-review-timeout.html",
-}
-
 var (
 	//go:embed "yaml/bookinfo.yaml"
 	BookinfoTemplate string
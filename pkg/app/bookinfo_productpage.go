@@ -0,0 +1,200 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/curl"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// Error banners that the productpage shows when it can't reach the details or reviews service
+const (
+	ErrorFetchingProductDetails = "Error fetching product details!"
+	ErrorFetchingProductReviews = "Error fetching product reviews!"
+)
+
+// Star colours of the ratings, which tell reviews-v2 (black) and reviews-v3 (red) apart
+const (
+	StarColorBlack = "black"
+	StarColorRed   = "red"
+)
+
+var (
+	productPageTitleRegexp   = regexp.MustCompile(`<title>\s*Simple Bookstore App\s*</title>`)
+	reviewsPodRegexp         = regexp.MustCompile(`Reviews served by:\s*</dt>\s*<u>\s*([^<\s]+)\s*</u>`)
+	reviewsVersionRegexp     = regexp.MustCompile(`^reviews-(v\d+)-`)
+	starColorRegexp          = regexp.MustCompile(`<font color="([^"]+)">\s*(?:<!--[^>]*-->\s*)*<span class="glyphicon glyphicon-star`)
+	errorBannerRegexp        = regexp.MustCompile(`<h4[^>]*>\s*(Error [^<]*?)\s*</h4>`)
+	signedInUserRegexp       = regexp.MustCompile(`Signed in as\s+([^<\s]+)`)
+	ratingsUnavailableRegexp = regexp.MustCompile(`Ratings service is currently unavailable`)
+)
+
+// ProductPage is what the bookinfo productpage shows, as far as the tests are concerned. Parsing the page
+// instead of comparing it to a file keeps tests independent of the pod names and of cosmetic changes to the HTML.
+type ProductPage struct {
+	// User is the signed-in user, or "" if no user is signed in
+	User string
+	// ReviewsPod is the reviews pod that served the reviews, or "" if the reviews couldn't be fetched
+	ReviewsPod string
+	// ReviewsVersion is the version of the reviews service (e.g. "v1"), derived from ReviewsPod
+	ReviewsVersion string
+	// StarColor is the colour of the rating stars (StarColorBlack or StarColorRed), or "" if the page shows no stars,
+	// e.g. because reviews-v1 doesn't call the ratings service
+	StarColor string
+	// RatingsUnavailable is set if the reviews service couldn't fetch the ratings
+	RatingsUnavailable bool
+	// Errors are the error banners on the page (e.g. ErrorFetchingProductReviews)
+	Errors []string
+}
+
+// ParseProductPage parses the body of a bookinfo productpage response. It returns an error if the body isn't a
+// productpage, e.g. when a proxy denied the request with "RBAC: access denied".
+func ParseProductPage(body []byte) (ProductPage, error) {
+	if !productPageTitleRegexp.Match(body) {
+		return ProductPage{}, errors.New("the response is not a bookinfo productpage")
+	}
+	page := ProductPage{
+		User:               submatch(signedInUserRegexp, body),
+		ReviewsPod:         submatch(reviewsPodRegexp, body),
+		StarColor:          submatch(starColorRegexp, body),
+		RatingsUnavailable: ratingsUnavailableRegexp.Match(body),
+	}
+	if match := reviewsVersionRegexp.FindStringSubmatch(page.ReviewsPod); match != nil {
+		page.ReviewsVersion = match[1]
+	}
+	for _, match := range errorBannerRegexp.FindAllSubmatch(body, -1) {
+		page.Errors = append(page.Errors, string(match[1]))
+	}
+	return page, nil
+}
+
+func submatch(re *regexp.Regexp, body []byte) string {
+	if match := re.FindSubmatch(body); match != nil {
+		return string(match[1])
+	}
+	return ""
+}
+
+// HasError returns whether the page shows the error banner
+func (p ProductPage) HasError(banner string) bool {
+	for _, e := range p.Errors {
+		if e == banner {
+			return true
+		}
+	}
+	return false
+}
+
+func (p ProductPage) String() string {
+	var s []string
+	if p.User != "" {
+		s = append(s, "user "+p.User)
+	}
+	if p.ReviewsPod != "" {
+		s = append(s, fmt.Sprintf("reviews %s (%s)", p.ReviewsVersion, p.ReviewsPod))
+	}
+	if p.StarColor != "" {
+		s = append(s, p.StarColor+" stars")
+	}
+	if p.RatingsUnavailable {
+		s = append(s, "ratings unavailable")
+	}
+	if len(p.Errors) > 0 {
+		s = append(s, fmt.Sprintf("errors %q", p.Errors))
+	}
+	if len(s) == 0 {
+		return "productpage without reviews"
+	}
+	return "productpage with " + strings.Join(s, ", ")
+}
+
+// ResponseIsReviewsVersion checks that the productpage shows the reviews served by the version of the reviews service
+// (e.g. "v3") and no error banners
+func ResponseIsReviewsVersion(version string) curl.HTTPResponseCheckFunc {
+	return checkProductPage(fmt.Sprintf("productpage shows reviews-%s", version), func(p ProductPage) bool {
+		return p.ReviewsVersion == version && len(p.Errors) == 0
+	})
+}
+
+// ResponseHasStarColor checks that the productpage shows ratings with stars of the colour (e.g. StarColorRed)
+func ResponseHasStarColor(color string) curl.HTTPResponseCheckFunc {
+	return checkProductPage(fmt.Sprintf("productpage shows %s stars", color), func(p ProductPage) bool {
+		return p.StarColor == color
+	})
+}
+
+// ResponseHasRatingsUnavailable checks that the productpage shows the reviews, but says that the ratings service is
+// currently unavailable
+func ResponseHasRatingsUnavailable() curl.HTTPResponseCheckFunc {
+	return checkProductPage("productpage shows the ratings service as unavailable", func(p ProductPage) bool {
+		return p.ReviewsPod != "" && p.RatingsUnavailable
+	})
+}
+
+// ResponseHasErrors checks that the productpage shows each of the error banners (e.g. ErrorFetchingProductReviews)
+func ResponseHasErrors(banners ...string) curl.HTTPResponseCheckFunc {
+	return checkProductPage(fmt.Sprintf("productpage shows %q", banners), func(p ProductPage) bool {
+		for _, banner := range banners {
+			if !p.HasError(banner) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// ResponseHasNoErrors checks that the productpage shows the details and reviews without error banners and, if
+// the reviews version calls the ratings service, with the ratings
+func ResponseHasNoErrors() curl.HTTPResponseCheckFunc {
+	return checkProductPage("productpage shows no errors", func(p ProductPage) bool {
+		return len(p.Errors) == 0 && !p.RatingsUnavailable
+	})
+}
+
+func checkProductPage(expected string, matches func(p ProductPage) bool) curl.HTTPResponseCheckFunc {
+	return func(t test.TestHelper, resp *http.Response, responseBody []byte, responseErr error, duration time.Duration) {
+		t.T().Helper()
+		if resp == nil {
+			t.Errorf("expected: %s; but the request failed: %v", expected, responseErr)
+			return
+		}
+		page, err := ParseProductPage(responseBody)
+		switch {
+		case resp.StatusCode != http.StatusOK:
+			t.Errorf("expected: %s; but got status %s: %s", expected, resp.Status, excerpt(responseBody))
+		case err != nil:
+			t.Errorf("expected: %s; but %v: %s", expected, err, excerpt(responseBody))
+		case !matches(page):
+			t.Errorf("expected: %s; but got %s", expected, page)
+		default:
+			t.LogSuccessf("%s (%s)", expected, page)
+		}
+	}
+}
+
+func excerpt(body []byte) string {
+	const maxLength = 500
+	if len(body) > maxLength {
+		return string(body[:maxLength]) + "..."
+	}
+	return string(body)
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/curl"
+	"github.com/maistra/maistra-test-tool/pkg/util/env"
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// readProductPage reads a productpage from testdata/resources/html and replaces the pod name placeholders
+// (e.g. {{.ReviewV2Podname}}) with the name of a reviews pod of that version
+func readProductPage(t *testing.T, file string) []byte {
+	body, err := os.ReadFile(filepath.Join(env.GetRootDir(), "testdata/resources/html", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return regexp.MustCompile(`\{\{\.ReviewV(\d)Podname\}\}`).ReplaceAll(body, []byte("reviews-v$1-5b8b9f6c4d-x2x7q"))
+}
+
+func TestParseProductPage(t *testing.T) {
+	testCases := []struct {
+		file     string
+		expected ProductPage
+	}{
+		{
+			file:     "productpage-normal-user-v1.html",
+			expected: ProductPage{ReviewsPod: "reviews-v1-5b8b9f6c4d-x2x7q", ReviewsVersion: "v1"},
+		},
+		{
+			file:     "productpage-normal-user-v2.html",
+			expected: ProductPage{ReviewsPod: "reviews-v2-5b8b9f6c4d-x2x7q", ReviewsVersion: "v2", StarColor: StarColorBlack},
+		},
+		{
+			file:     "productpage-normal-user-v3.html",
+			expected: ProductPage{ReviewsPod: "reviews-v3-5b8b9f6c4d-x2x7q", ReviewsVersion: "v3", StarColor: StarColorRed},
+		},
+		{
+			file:     "productpage-test-user-v2.html",
+			expected: ProductPage{User: "jason", ReviewsPod: "reviews-v2-5b8b9f6c4d-x2x7q", ReviewsVersion: "v2", StarColor: StarColorBlack},
+		},
+		{
+			file: "productpage-test-user-v2-rating-unavailable.html",
+			expected: ProductPage{User: "jason", ReviewsPod: "reviews-v2-5b8b9f6c4d-x2x7q", ReviewsVersion: "v2",
+				RatingsUnavailable: true},
+		},
+		{
+			file:     "productpage-review-timeout.html",
+			expected: ProductPage{Errors: []string{ErrorFetchingProductReviews}},
+		},
+		{
+			file:     "productpage-test-user-v2-review-timeout.html",
+			expected: ProductPage{User: "jason", Errors: []string{ErrorFetchingProductReviews}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.file, func(t *testing.T) {
+			page, err := ParseProductPage(readProductPage(t, tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(page, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, page)
+			}
+		})
+	}
+
+	t.Run("not a productpage", func(t *testing.T) {
+		if _, err := ParseProductPage(readProductPage(t, "productpage-quota-exhausted.html")); err == nil {
+			t.Error("expected an error for a quota denial")
+		}
+		if _, err := ParseProductPage([]byte("RBAC: access denied")); err == nil {
+			t.Error("expected an error for an RBAC denial")
+		}
+	})
+}
+
+func TestProductPageChecks(t *testing.T) {
+	v2 := readProductPage(t, "productpage-normal-user-v2.html")
+	ratingsUnavailable := readProductPage(t, "productpage-test-user-v2-rating-unavailable.html")
+	reviewTimeout := readProductPage(t, "productpage-review-timeout.html")
+
+	assertCheckPasses(t, ResponseIsReviewsVersion("v2"), http.StatusOK, v2)
+	assertCheckPasses(t, ResponseHasStarColor(StarColorBlack), http.StatusOK, v2)
+	assertCheckPasses(t, ResponseHasRatingsUnavailable(), http.StatusOK, ratingsUnavailable)
+	assertCheckPasses(t, ResponseHasErrors(ErrorFetchingProductReviews), http.StatusOK, reviewTimeout)
+	assertCheckPasses(t, ResponseHasNoErrors(), http.StatusOK, v2)
+
+	assertCheckFails(t, ResponseIsReviewsVersion("v3"), http.StatusOK, v2)
+	assertCheckFails(t, ResponseIsReviewsVersion("v1"), http.StatusOK, reviewTimeout)
+	assertCheckFails(t, ResponseHasStarColor(StarColorRed), http.StatusOK, v2)
+	assertCheckFails(t, ResponseHasRatingsUnavailable(), http.StatusOK, v2)
+	assertCheckFails(t, ResponseHasErrors(ErrorFetchingProductDetails, ErrorFetchingProductReviews), http.StatusOK, reviewTimeout)
+	assertCheckFails(t, ResponseHasNoErrors(), http.StatusOK, ratingsUnavailable)
+	assertCheckFails(t, ResponseHasNoErrors(), http.StatusServiceUnavailable, v2)
+	assertCheckFails(t, ResponseHasNoErrors(), http.StatusOK, []byte("RBAC: access denied"))
+}
+
+func assertCheckPasses(t *testing.T, check curl.HTTPResponseCheckFunc, status int, body []byte) {
+	t.Helper()
+	if checkFails(t, check, status, body) {
+		t.Errorf("expected the check to pass for the response with status %d, but it failed", status)
+	}
+}
+
+func assertCheckFails(t *testing.T, check curl.HTTPResponseCheckFunc, status int, body []byte) {
+	t.Helper()
+	if !checkFails(t, check, status, body) {
+		t.Errorf("expected the check to fail for the response with status %d, but it passed", status)
+	}
+}
+
+func checkFails(t *testing.T, check curl.HTTPResponseCheckFunc, status int, body []byte) bool {
+	resp := &http.Response{StatusCode: status, Status: http.StatusText(status)}
+	return retry.Attempt(test.NewTestHelper(t), func(t test.TestHelper) {
+		check(t, resp, body, nil, 0)
+	}).Failed()
+}
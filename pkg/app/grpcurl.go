@@ -64,7 +64,6 @@ kind: Job
 metadata:
   name: grpcurl
 spec:
-  replicas: 1
   template:
     metadata:
       labels:
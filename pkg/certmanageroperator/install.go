@@ -57,9 +57,9 @@ func install(t test.TestHelper) {
 	operator.CreateOperatorViaOlm(t, certManagerOperatorNs, certManagerCSVName, certManagerSubscriptionYaml, certManagerOperatorSelector, nil)
 
 	t.LogStep("Wait for cert manager control plane")
-	oc.WaitPodReadyWithOptions(t, retry.Options().MaxAttempts(70).DelayBetweenAttempts(5*time.Second), pod.MatchingSelector("app=cert-manager", certManagerNs))
-	oc.WaitPodReadyWithOptions(t, retry.Options().MaxAttempts(70).DelayBetweenAttempts(5*time.Second), pod.MatchingSelector("app=cainjector", certManagerNs))
-	oc.WaitPodReadyWithOptions(t, retry.Options().MaxAttempts(70).DelayBetweenAttempts(5*time.Second), pod.MatchingSelector("app=webhook", certManagerNs))
+	oc.WaitPodReadyWithOptions(t, retry.Options().Timeout(6*time.Minute).DelayBetweenAttempts(5*time.Second), pod.MatchingSelector("app=cert-manager", certManagerNs))
+	oc.WaitPodReadyWithOptions(t, retry.Options().Timeout(6*time.Minute).DelayBetweenAttempts(5*time.Second), pod.MatchingSelector("app=cainjector", certManagerNs))
+	oc.WaitPodReadyWithOptions(t, retry.Options().Timeout(6*time.Minute).DelayBetweenAttempts(5*time.Second), pod.MatchingSelector("app=webhook", certManagerNs))
 
 	t.LogStep("Wait for cert-manager-webhook service available")
 	retry.UntilSuccess(t, func(t test.TestHelper) {
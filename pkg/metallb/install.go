@@ -75,7 +75,7 @@ func installOperator(t test.TestHelper, oc oc.OC) {
 	t.Log("Install MetalLB operator")
 	//nolint:typecheck
 	ocpVersion := version.ParseVersion(oc.GetOCPVersion(t))
-	metallbVersion := metallbVersions[ocpVersion.String()]
+	metallbVersion := metallbVersions[ocpVersion.MajorMinor().String()]
 	t.Log(fmt.Sprintf("MetalLB version: %s", metallbVersion))
 	oc.ApplyTemplateString(t, ns.MetalLB, metallbOperator, map[string]string{"Version": metallbVersion})
 	retry.UntilSuccess(t, func(t test.TestHelper) {
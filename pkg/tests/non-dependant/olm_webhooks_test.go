@@ -28,12 +28,9 @@ import (
 )
 
 func TestOlmWebhookCreation(t *testing.T) {
-	NewTest(t).Groups(Full, ARM, Disconnected).Run(func(t TestHelper) {
+	NewTest(t).Groups(Full, ARM, Disconnected).MinOperatorVersion(version.OPERATOR_2_6_0).Run(func(t TestHelper) {
 		t.Log("This test verifies that OLM creates all validating/mutating webhooks")
 		t.Log("See https://issues.redhat.com/browse/OSSM-6762")
-		if env.GetOperatorVersion().LessThan(version.OPERATOR_2_6_0) {
-			t.Skip("Skipping until 2.6 operator")
-		}
 
 		t.Cleanup(func() {
 			t.LogStepf("Delete namespace %s", meshNamespace)
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/maistra/maistra-test-tool/pkg/util/check/assert"
+	"github.com/maistra/maistra-test-tool/pkg/util/cluster"
 	"github.com/maistra/maistra-test-tool/pkg/util/env"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
 	"github.com/maistra/maistra-test-tool/pkg/util/retry"
@@ -84,8 +85,8 @@ func (ft federationTest) run(t TestHelper) {
 		peer2Address = "west-mesh-ingress.east-mesh-system.svc.cluster.local"
 	} else {
 		t.Log("Using LoadBalancer service for ingress")
-		peer1Address = getLoadBalancerIngressAddress(t, ft.west, "east-mesh-ingress")
-		peer2Address = getLoadBalancerIngressAddress(t, ft.east, "west-mesh-ingress")
+		peer1Address = ft.west.cluster().LoadBalancerAddress(t, ft.west.smcpNamespace, "east-mesh-ingress")
+		peer2Address = ft.east.cluster().LoadBalancerAddress(t, ft.east.smcpNamespace, "west-mesh-ingress")
 	}
 
 	westMeshInfo := PeerInfo{Address: peer1Address, DiscoveryPort: "8188", ServicePort: "15443", Region: ft.west.region, Zone: ft.west.zone}
@@ -94,8 +95,8 @@ func (ft federationTest) run(t TestHelper) {
 	t.Logf("east-mesh: address: %s; discovery port: %v, service port: %v", eastMeshInfo.Address, eastMeshInfo.DiscoveryPort, eastMeshInfo.ServicePort)
 
 	t.LogStep("Retrieve root certificates")
-	westMeshInfo.CARootCert = getRootCertificate(t, ft.west)
-	eastMeshInfo.CARootCert = getRootCertificate(t, ft.east)
+	westMeshInfo.CARootCert = ft.west.cluster().RootCert(t)
+	eastMeshInfo.CARootCert = ft.east.cluster().RootCert(t)
 
 	t.LogStep("Install ServiceMeshPeer and ExportedServiceSet in west-mesh")
 	ocWest.ApplyTemplateFile(t, ft.west.smcpNamespace, ft.testdataPath+"/west-mesh/configmap.yaml", eastMeshInfo)
@@ -130,34 +131,9 @@ func (ft federationTest) run(t TestHelper) {
 	ft.checker(t, ft)
 }
 
-func getLoadBalancerIngressAddress(t TestHelper, c config, serviceName string) string {
-	var address string
-	retryFor10Minutes := retry.Options().MaxAttempts(6 * 10).DelayBetweenAttempts(10 * time.Second)
-	retry.UntilSuccessWithOptions(t, retryFor10Minutes, func(t TestHelper) {
-		// try to get the load balancer ip
-		address = c.oc.Invokef(t, `oc -n %s get svc %s -o jsonpath="{.status.loadBalancer.ingress[].ip}"`, c.smcpNamespace, serviceName)
-		if address != "" {
-			return
-		}
-
-		// try to get the load balancer hostname
-		address = c.oc.Invokef(t, `oc -n %s get svc %s -o jsonpath="{.status.loadBalancer.ingress[].hostname}"`, c.smcpNamespace, serviceName)
-		if address != "" {
-			return
-		}
-
-		t.Fatalf("could not get ingress address from LoadBalancer service %s/%s", c.smcpNamespace, serviceName)
-	})
-	return address
-}
-
-func getRootCertificate(t TestHelper, c config) string {
-	configMap := "istio-ca-root-cert"
-	key := "root-cert.pem"
-
-	t.Logf("Get key %s from ConfigMap %s", key, configMap)
-	data := c.oc.GetConfigMapData(t, c.smcpNamespace, configMap)
-	return data[key]
+// cluster returns the cluster of the mesh, whose mesh namespace is the SMCP namespace
+func (c config) cluster() *cluster.Cluster {
+	return &cluster.Cluster{Name: c.smcpName, OC: c.oc, MeshNamespace: c.smcpNamespace}
 }
 
 func installSMCPandSMMR(t TestHelper, c config, smcpFile, smmrFile string, ingressServiceType string) {
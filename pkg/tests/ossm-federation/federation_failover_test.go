@@ -22,9 +22,10 @@ import (
 	"testing"
 
 	"github.com/maistra/maistra-test-tool/pkg/app"
+	"github.com/maistra/maistra-test-tool/pkg/util/capability"
 	"github.com/maistra/maistra-test-tool/pkg/util/check/assert"
+	"github.com/maistra/maistra-test-tool/pkg/util/cluster"
 	"github.com/maistra/maistra-test-tool/pkg/util/curl"
-	"github.com/maistra/maistra-test-tool/pkg/util/env"
 	"github.com/maistra/maistra-test-tool/pkg/util/istio"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
 	"github.com/maistra/maistra-test-tool/pkg/util/pod"
@@ -35,19 +36,16 @@ import (
 // use the following command to monitor the test: watch "kubecolor get smcp -n west-mesh-system;echo;kubecolor get pods -n west-mesh-system;echo;kubecolor get pods -n west-mesh-bookinfo; echo; echo '========================================================================='; echo; kubecolor get smcp -n east-mesh-system;echo;kubecolor get pods -n east-mesh-system;echo;kubecolor get pods -n east-mesh-bookinfo"
 
 func TestMultiClusterFederationFailover(t *testing.T) {
-	NewTest(t).Groups(Full).Run(func(t TestHelper) {
-		kubeconfig2 := env.GetKubeconfig2()
-		if kubeconfig2 == "" {
-			t.Skip("this test only runs when the KUBECONFIG2 environment variable points to the kubeconfig of the second cluster")
-		}
-
-		ocWest := oc.DefaultOC
-		ocEast := oc.WithKubeconfig(kubeconfig2)
+	NewTest(t).Groups(Full).RequiresCapability(capability.TwoClusters).Run(func(t TestHelper) {
+		topology := cluster.LoadTopology(t)
+		topology.Require(t, 2)
+		ocWest := topology.Clusters[0].OC
+		ocEast := topology.Clusters[1].OC
 
 		westRegion, westZone := getRegionAndZone(t, ocWest)
 		eastRegion, eastZone := getRegionAndZone(t, ocEast)
 		if eastRegion == westRegion {
-			t.Fatalf("KUBECONFIG and KUBECONFIG2 must point to clusters in different regions, but they are both in %s", westRegion)
+			t.Fatalf("clusters %v must be in different regions, but they are both in %s", topology.Names()[:2], westRegion)
 		}
 
 		federationTest{
@@ -22,6 +22,7 @@ import (
 
 	"github.com/maistra/maistra-test-tool/pkg/app"
 	"github.com/maistra/maistra-test-tool/pkg/util/check/assert"
+	"github.com/maistra/maistra-test-tool/pkg/util/cluster"
 	"github.com/maistra/maistra-test-tool/pkg/util/curl"
 	"github.com/maistra/maistra-test-tool/pkg/util/env"
 	"github.com/maistra/maistra-test-tool/pkg/util/istio"
@@ -36,7 +37,7 @@ func TestFederation(t *testing.T) {
 		//This test will be executed in multicluster only if two kubeconfigs are provided and those cluster are not in ROSA
 		//To ROSA test of federation we have this testcase: TestMultiClusterFederationFailover
 		t.Log("Both OC clusters are set respectively to cluster west and east if they are provided with two kubeconfig files, if not, both are set from the same file")
-		ocWest, ocEast := setKubeconfig()
+		ocWest, ocEast := setKubeconfig(t)
 
 		federationTest{
 			testdataPath: "testdata/traffic-splitting",
@@ -65,7 +66,7 @@ func TestFederation(t *testing.T) {
 func TestFederationDifferentCerts(t *testing.T) {
 	NewTest(t).Id("T32").Groups(Full).Run(func(t TestHelper) {
 
-		ocWest, ocEast := setKubeconfig()
+		ocWest, ocEast := setKubeconfig(t)
 		federationTest{
 			testdataPath: "testdata/traffic-splitting",
 			west: config{
@@ -97,12 +98,12 @@ func TestFederationDifferentCerts(t *testing.T) {
 	})
 }
 
-func setKubeconfig() (*oc.OC, *oc.OC) {
-	ocWest := oc.DefaultOC
-	ocEast := oc.DefaultOC
-	kubeconfig2 := env.GetKubeconfig2()
-	if kubeconfig2 != "" && !env.IsRosa() {
-		ocEast = oc.WithKubeconfig(kubeconfig2)
+func setKubeconfig(t TestHelper) (*oc.OC, *oc.OC) {
+	topology := cluster.LoadTopology(t)
+	ocWest := topology.Primary().OC
+	ocEast := ocWest
+	if len(topology.Clusters) > 1 && !env.IsRosa() {
+		ocEast = topology.Clusters[1].OC
 	}
 	return ocWest, ocEast
 }
@@ -138,7 +138,7 @@ spec:
 			gatewayapi.InstallSupportedVersion(t, env.GetSMCPVersion())
 			t.Cleanup(func() {
 				// OCP 4.19+ has gateway api crds build in, do not uninstall them
-				if version.ParseVersion(oc.GetOCPVersion(t)).LessThan(version.OCP_4_19) {
+				if version.ParseVersion(oc.GetOCPVersion(t)).Core().LessThan(version.OCP_4_19) {
 					gatewayapi.UninstallSupportedVersion(t, env.GetSMCPVersion())
 				}
 			})
@@ -19,7 +19,6 @@ import (
 
 	"github.com/maistra/maistra-test-tool/pkg/app"
 	"github.com/maistra/maistra-test-tool/pkg/util/check/assert"
-	"github.com/maistra/maistra-test-tool/pkg/util/env"
 	"github.com/maistra/maistra-test-tool/pkg/util/ns"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
 	"github.com/maistra/maistra-test-tool/pkg/util/pod"
@@ -30,10 +29,7 @@ import (
 )
 
 func TestInjectionInPrivelegedPods(t *testing.T) {
-	NewTest(t).Groups(Full, Disconnected, ARM).Run(func(t TestHelper) {
-		if env.GetOperatorVersion().LessThan(version.OPERATOR_2_6_2) {
-			t.Skip("This test requires the operator version to be at least 2.6.2")
-		}
+	NewTest(t).Groups(Full, Disconnected, ARM).MinOperatorVersion(version.OPERATOR_2_6_2).Run(func(t TestHelper) {
 		t.Log("Reference: https://issues.redhat.com/browse/OSSM-8001")
 
 		t.Cleanup(func() {
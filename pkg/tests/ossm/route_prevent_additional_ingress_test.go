@@ -32,7 +32,7 @@ func TestRoutePreventAdditionalIngress(t *testing.T) {
 	NewTest(t).Id("T48").Groups(Full, ARM, Disconnected, Persistent).MaxVersion(version.SMCP_2_5).Run(func(t TestHelper) {
 
 		tracingType := "None"
-		if env.GetSMCPVersion().LessThanOrEqual(version.SMCP_2_5) && version.ParseVersion(oc.GetOCPVersion(t)).LessThanOrEqual(version.OCP_4_18) {
+		if env.GetSMCPVersion().LessThanOrEqual(version.SMCP_2_5) && version.ParseVersion(oc.GetOCPVersion(t)).Core().LessThan(version.OCP_4_19) {
 			tracingType = "Jaeger"
 		}
 		meshValues := map[string]interface{}{
@@ -22,19 +22,12 @@ import (
 	"github.com/maistra/maistra-test-tool/pkg/util/env"
 	"github.com/maistra/maistra-test-tool/pkg/util/ns"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/ossm3"
 	"github.com/maistra/maistra-test-tool/pkg/util/template"
 	"github.com/maistra/maistra-test-tool/pkg/util/test"
 	"github.com/maistra/maistra-test-tool/pkg/util/version"
 )
 
-type Istio struct {
-	Name      string
-	Namespace string
-
-	// Template will override the default template.
-	Template string
-}
-
 type SMCP struct {
 	Name          string
 	Namespace     string
@@ -76,6 +69,9 @@ var (
 	//go:embed yaml/subscription-ossm.yaml
 	ossmSubscription string
 
+	// smmrMembers are the members of the default SMMR (yaml/smmr.yaml)
+	smmrMembers = []string{ns.Bookinfo, ns.Foo, ns.Bar, ns.Legacy}
+
 	smcpName      = env.GetDefaultSMCPName()
 	meshNamespace = env.GetDefaultMeshNamespace()
 	rootDir       = env.GetRootDir()
@@ -104,13 +100,6 @@ func DefaultClusterWideSMCP(t test.TestHelper) SMCP {
 	}
 }
 
-func DefaultIstio() Istio {
-	return Istio{
-		Name:      env.GetIstioName(),
-		Namespace: env.GetIstioNamespace(),
-	}
-}
-
 // Install nightly build operators from quay.io. This is used in Jenkins daily build pipeline.
 func installNightlyOperators(t test.TestHelper) {
 	ns := env.GetOperatorNamespace()
@@ -129,8 +118,13 @@ func BasicSetup(t test.TestHelper) {
 	oc.CreateNamespace(t, meshNamespace, ns.Bookinfo, ns.Foo, ns.Bar, ns.Legacy, ns.MeshExternal)
 }
 
+// DeployControlPlane deploys the default SMCP and SMMR or, if CONTROL_PLANE=ossm3, an OSSM 3 control plane instead
+// (see deployOSSM3ControlPlane)
 func DeployControlPlane(t test.TestHelper) SMCP {
 	t.T().Helper()
+	if env.IsOSSM3ControlPlane() {
+		return deployOSSM3ControlPlane(t)
+	}
 	t.LogStep("Apply default SMCP and SMMR manifests")
 	smcpValues := DefaultSMCP(t)
 	clusterWideProxy := oc.GetProxy(t)
@@ -147,6 +141,22 @@ func DeployControlPlane(t test.TestHelper) SMCP {
 	return smcpValues
 }
 
+// deployOSSM3ControlPlane deploys an Istio and IstioCNI in the mesh namespace and enrolls the members of the default
+// SMMR, so that tests that don't manipulate the SMCP or SMMR directly run unchanged against OSSM 3. The returned
+// SMCP only carries the name and namespace of the Istio resource.
+func deployOSSM3ControlPlane(t test.TestHelper) SMCP {
+	t.T().Helper()
+	t.LogStep("Apply default Istio and IstioCNI manifests")
+	istio := ossm3.DefaultIstio().WithNamespace(meshNamespace)
+	ossm3.DeployControlPlane(t, istio, smmrMembers...)
+	return SMCP{
+		Name:      istio.Name,
+		Namespace: istio.Namespace,
+		Version:   env.GetSMCPVersion(),
+		Rosa:      env.IsRosa(),
+	}
+}
+
 func DeployClusterWideControlPlane(t test.TestHelper) {
 	t.T().Helper()
 	t.LogStep("Apply ClusterWide SMCP")
@@ -196,7 +206,7 @@ func GetProfileFile() string {
 
 func getDefaultTracingType(t test.TestHelper) string {
 	// jaeger is not available on SMCP 2.6 or OCP 4.19+, so use Jaeger tracing only for SMCP 2.5 and lower and OCP 4.18 and lower
-	if env.GetSMCPVersion().LessThanOrEqual(version.SMCP_2_5) && version.ParseVersion(oc.GetOCPVersion(t)).LessThanOrEqual(version.OCP_4_18) {
+	if env.GetSMCPVersion().LessThanOrEqual(version.SMCP_2_5) && version.ParseVersion(oc.GetOCPVersion(t)).Core().LessThan(version.OCP_4_19) {
 		return "Jaeger"
 	} else {
 		return "None"
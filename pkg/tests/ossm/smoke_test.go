@@ -284,7 +284,7 @@ func assertRoutesExist(t TestHelper) {
 	})
 
 	// jaeger is not available on SMCP 2.6 or OCP 4.19+, so use Jaeger tracing only for SMCP 2.5 and lower and OCP 4.18 and lower
-	if env.GetSMCPVersion().LessThanOrEqual(version.SMCP_2_5) && version.ParseVersion(oc.GetOCPVersion(t)).LessThanOrEqual(version.OCP_4_18) {
+	if env.GetSMCPVersion().LessThanOrEqual(version.SMCP_2_5) && version.ParseVersion(oc.GetOCPVersion(t)).Core().LessThan(version.OCP_4_19) {
 		retry.UntilSuccess(t, func(t TestHelper) {
 			oc.Get(t,
 				meshNamespace,
@@ -32,11 +32,8 @@ import (
 )
 
 func TestNativeSidecars(t *testing.T) {
-	NewTest(t).Groups(Full, InterOp, ARM, Disconnected, Persistent).Run(func(t TestHelper) {
-		if version.ParseVersion(oc.GetOCPVersion(t)).LessThan(version.OCP_4_16) || env.GetSMCPVersion().LessThan(version.SMCP_2_6) {
-			t.Skip("Native sidecars are only supported in OpenShift 4.16+ and OSSM 2.6+")
-		}
-
+	// Native sidecars are only supported in OpenShift 4.16+ and OSSM 2.6+
+	NewTest(t).Groups(Full, InterOp, ARM, Disconnected, Persistent).MinVersion(version.SMCP_2_6).MinOCPVersion(version.OCP_4_16).Run(func(t TestHelper) {
 		meshValues := map[string]interface{}{
 			"Member":                ns.Foo,
 			"NativeSidecarsEnabled": true,
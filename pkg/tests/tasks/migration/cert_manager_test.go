@@ -28,6 +28,7 @@ import (
 	"github.com/maistra/maistra-test-tool/pkg/util/helm"
 	"github.com/maistra/maistra-test-tool/pkg/util/ns"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/ossm3"
 	"github.com/maistra/maistra-test-tool/pkg/util/pod"
 	"github.com/maistra/maistra-test-tool/pkg/util/retry"
 	"github.com/maistra/maistra-test-tool/pkg/util/template"
@@ -57,9 +58,14 @@ func TestCertManagerMigration(t *testing.T) {
 
 		smcp := ossm.DefaultClusterWideSMCP(t)
 		smcp.Namespace = meshNamespace
-		istio := ossm.DefaultIstio()
-		istio.Template = istioWithCertManager
-		istio.Namespace = meshNamespace
+		istio := ossm3.DefaultIstio().WithNamespace(meshNamespace).WithValues(map[string]any{
+			"global": map[string]any{
+				"caAddress": fmt.Sprintf("cert-manager-istio-csr.%s.svc:443", meshNamespace),
+			},
+			"pilot": map[string]any{
+				"env": map[string]any{"ENABLE_CA_SERVER": "false"},
+			},
+		})
 
 		istioCSRValues := map[string]any{
 			"Namespace": meshNamespace,
@@ -131,7 +137,7 @@ func TestCertManagerMigration(t *testing.T) {
 
 		t.LogStep("Migrate bookinfo to 3.y controlplane")
 		t.Log("Getting Istio active Rev name")
-		ossm3RevName := oc.GetJson(t, "", "Istio", istio.Name, "{.status.activeRevisionName}")
+		ossm3RevName := istio.ActiveRevision(t)
 		t.Log("Relabeling bookinfo namespace")
 		oc.Label(t, "", "Namespace", ns.Bookinfo, maistraIgnoreLabel+" istio-injection- istio.io/rev="+ossm3RevName)
 		// Wait for book info to be removed.
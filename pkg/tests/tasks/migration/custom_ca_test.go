@@ -25,6 +25,7 @@ import (
 	"github.com/maistra/maistra-test-tool/pkg/util/curl"
 	"github.com/maistra/maistra-test-tool/pkg/util/ns"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/ossm3"
 	"github.com/maistra/maistra-test-tool/pkg/util/pod"
 	"github.com/maistra/maistra-test-tool/pkg/util/retry"
 	"github.com/maistra/maistra-test-tool/pkg/util/test"
@@ -45,17 +46,11 @@ func TestCustomCAMigration(t *testing.T) {
 		ossm.BasicSetup(t)
 
 		t.LogStep("Create cacerts secret for SMCP")
-		oc.CreateGenericSecretFromFiles(t, meshNamespace, "cacerts",
-			"ca-cert.pem="+cert.SampleCACert,
-			"ca-key.pem="+cert.SampleCAKey,
-			"root-cert.pem="+cert.SampleCARoot,
-			"cert-chain.pem="+cert.SampleCAChain)
+		oc.CreateGenericSecretFromFiles(t, meshNamespace, "cacerts", cert.NewCAChain(1, cert.RSA).WriteCACerts(t.T().TempDir())...)
 
 		smcp := ossm.DefaultClusterWideSMCP(t)
 		smcp.Namespace = meshNamespace
-		istio := ossm.DefaultIstio()
-		istio.Template = istioCustomCATmpl
-		istio.Namespace = meshNamespace
+		istio := ossm3.DefaultIstio().WithNamespace(meshNamespace)
 
 		t.LogStep("Deploy SMCP " + smcp.Version.String() + " with custom CA and SMMR")
 		oc.ApplyTemplate(t, meshNamespace, serviceMeshCustomCATmpl, smcp)
@@ -123,7 +118,7 @@ func TestCustomCAMigration(t *testing.T) {
 
 		t.LogStep("Migrate bookinfo to 3.y controlplane")
 		t.Log("Getting Istio active Rev name")
-		ossm3RevName := oc.GetJson(t, "", "Istio", istio.Name, "{.status.activeRevisionName}")
+		ossm3RevName := istio.ActiveRevision(t)
 		t.Log("Relabeling bookinfo namespace")
 		oc.Label(t, "", "Namespace", ns.Bookinfo, maistraIgnoreLabel+" istio-injection- istio.io/rev="+ossm3RevName)
 		retry.UntilSuccess(t, func(t test.TestHelper) {
@@ -21,10 +21,12 @@ import (
 	"time"
 
 	"github.com/maistra/maistra-test-tool/pkg/app"
+	"github.com/maistra/maistra-test-tool/pkg/util/capability"
 	"github.com/maistra/maistra-test-tool/pkg/util/check/assert"
 	"github.com/maistra/maistra-test-tool/pkg/util/check/require"
 	"github.com/maistra/maistra-test-tool/pkg/util/env"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/ossm3"
 	"github.com/maistra/maistra-test-tool/pkg/util/pod"
 	"github.com/maistra/maistra-test-tool/pkg/util/retry"
 	"github.com/maistra/maistra-test-tool/pkg/util/test"
@@ -109,11 +111,8 @@ type peerInfo struct {
 // This test follows the migration guide from:
 // https://github.com/openshift-service-mesh/sail-operator/tree/main/docs/ossm/ossm2-migration/federation
 func TestFederationMigration(t *testing.T) {
-	test.NewTest(t).MinVersion(version.SMCP_2_6).Groups(test.Migration).Run(func(t test.TestHelper) {
+	test.NewTest(t).MinVersion(version.SMCP_2_6).Groups(test.Migration).RequiresCapability(capability.TwoClusters).Run(func(t test.TestHelper) {
 		kubeconfig2 := env.GetKubeconfig2()
-		if kubeconfig2 == "" {
-			t.Skip("This test requires KUBECONFIG2 environment variable pointing to the second cluster")
-		}
 
 		ocEast := oc.DefaultOC
 		ocWest := oc.WithKubeconfig(kubeconfig2)
@@ -395,21 +394,14 @@ func deployOSSM3ControlPlanes(t test.TestHelper, ocEast, ocWest *oc.OC) {
 	westRootCert := getRootCertFromConfigMap(t, ocWest, westMeshNamespace)
 	eastRootCert := getRootCertFromConfigMap(t, ocEast, eastMeshNamespace)
 
-	istioCNI := `apiVersion: sailoperator.io/v1
-kind: IstioCNI
-metadata:
-  name: default
-spec:
-  version: v1.24-latest
-  namespace: istio-cni`
-
-	ocEast.CreateNamespace(t, "istio-cni")
-	ocWest.CreateNamespace(t, "istio-cni")
-	ocEast.ApplyString(t, "", istioCNI)
-	ocWest.ApplyString(t, "", istioCNI)
-
-	ocEast.WaitFor(t, "", "IstioCNI", "default", "condition=Ready")
-	ocWest.WaitFor(t, "", "IstioCNI", "default", "condition=Ready")
+	istioCNI := ossm3.DefaultIstioCNI()
+	ocEast.CreateNamespace(t, istioCNI.Namespace)
+	ocWest.CreateNamespace(t, istioCNI.Namespace)
+	ocEast.ApplyString(t, "", istioCNI.Manifest())
+	ocWest.ApplyString(t, "", istioCNI.Manifest())
+
+	ocEast.WaitFor(t, "", "IstioCNI", istioCNI.Name, "condition=Ready")
+	ocWest.WaitFor(t, "", "IstioCNI", istioCNI.Name, "condition=Ready")
 
 	ocEast.ApplyTemplateString(t, "", eastIstioTmpl, map[string]string{
 		"WestRootCert": westRootCert,
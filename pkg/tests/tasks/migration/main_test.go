@@ -28,6 +28,7 @@ import (
 	"github.com/maistra/maistra-test-tool/pkg/util/env"
 	"github.com/maistra/maistra-test-tool/pkg/util/ns"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/ossm3"
 	"github.com/maistra/maistra-test-tool/pkg/util/test"
 )
 
@@ -51,14 +52,8 @@ var (
 	//go:embed yaml/istio-csr.yaml
 	istioCSRTmpl string
 
-	//go:embed yaml/istio-cert-manager.yaml
-	istioWithCertManager string
-
 	//go:embed yaml/mesh-custom-ca.yaml
 	serviceMeshCustomCATmpl string
-
-	//go:embed yaml/istio-custom-ca.yaml
-	istioCustomCATmpl string
 )
 
 func TestMain(m *testing.M) {
@@ -142,41 +137,20 @@ func continuallyRequest(t test.TestHelper, url string) {
 	}(ctx)
 }
 
-func setupIstio(t test.TestHelper, istios ...ossm.Istio) {
+func setupIstio(t test.TestHelper, istios ...ossm3.Istio) {
 	t.T().Helper()
+	cni := ossm3.DefaultIstioCNI()
 	t.Cleanup(func() {
-		t.Log("Cleaning up IstioCNI")
-		oc.DeleteResource(t, "", "IstioCNI", "default")
+		cni.Delete(t)
 	})
 	for _, istio := range istios {
 		istio := istio
 		t.Cleanup(func() {
-			t.Logf("Cleaning up Istio %s", istio.Name)
-			oc.DeleteResource(t, "", "Istio", istio.Name)
+			istio.Delete(t)
 		})
-		if istio.Template == "" {
-			istio.Template = `apiVersion: sailoperator.io/v1
-kind: Istio
-metadata:
-  name: {{ .Name }}
-spec:
-  namespace: {{ .Namespace }}
-  version: v1.24-latest`
-		}
-		oc.ApplyTemplate(t, "", istio.Template, istio)
-		oc.Label(t, "", "Istio", istio.Name, oc.MaistraTestLabel+`=""`)
-		oc.DefaultOC.WaitFor(t, "", "Istio", istio.Name, "condition=Ready")
+		istio.Install(t)
 	}
-	oc.CreateNamespace(t, "istio-cni")
-	istioCNI := `apiVersion: sailoperator.io/v1
-kind: IstioCNI
-metadata:
-  name: default
-spec:
-  namespace: istio-cni
-  version: v1.24-latest`
-	oc.ApplyString(t, "", istioCNI)
-	oc.DefaultOC.WaitFor(t, "", "IstioCNI", "default", "condition=Ready")
+	cni.Install(t)
 }
 
 // Returns either the ip address or the hostname of the LoadBalancer from the Service status.
@@ -22,14 +22,14 @@ import (
 
 	"github.com/maistra/maistra-test-tool/pkg/app"
 	"github.com/maistra/maistra-test-tool/pkg/tests/ossm"
+	"github.com/maistra/maistra-test-tool/pkg/util/capability"
 	"github.com/maistra/maistra-test-tool/pkg/util/check/assert"
 	"github.com/maistra/maistra-test-tool/pkg/util/check/common"
 	"github.com/maistra/maistra-test-tool/pkg/util/check/require"
-	"github.com/maistra/maistra-test-tool/pkg/util/cluster"
 	"github.com/maistra/maistra-test-tool/pkg/util/curl"
-	"github.com/maistra/maistra-test-tool/pkg/util/env"
 	"github.com/maistra/maistra-test-tool/pkg/util/ns"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/ossm3"
 	"github.com/maistra/maistra-test-tool/pkg/util/pod"
 	"github.com/maistra/maistra-test-tool/pkg/util/retry"
 	"github.com/maistra/maistra-test-tool/pkg/util/test"
@@ -52,9 +52,8 @@ func TestMigrationSimpleClusterWide(t *testing.T) {
 
 		t.LogStep("Install SMCP 2.6 in clusterwide mode")
 		smcp := ossm.DefaultClusterWideSMCP(t)
-		istio := ossm.DefaultIstio()
 		// These are defaulted to the same but better to be explicit.
-		istio.Namespace = smcp.Namespace
+		istio := ossm3.DefaultIstio().WithNamespace(smcp.Namespace)
 		ossm.BasicSetup(t)
 		templ := `apiVersion: maistra.io/v2
 kind: ServiceMeshControlPlane
@@ -106,7 +105,7 @@ spec:
 		setupIstio(t, istio)
 
 		t.LogStep("Migrate bookinfo to 3.y controlplane")
-		ossm3RevName := oc.GetJson(t, "", "Istio", istio.Name, "{.status.activeRevisionName}")
+		ossm3RevName := istio.ActiveRevision(t)
 		oc.Label(t, "", "Namespace", ns.Bookinfo, maistraIgnoreLabel+" istio-injection- istio.io/rev="+ossm3RevName)
 		// Wait for book info to be removed.
 		retry.UntilSuccess(t, func(t test.TestHelper) {
@@ -170,13 +169,8 @@ func TestMigrationSimpleMultiTenant(t *testing.T) {
 		smcpB.Namespace = "tenant-b"
 		oc.CreateNamespace(t, smcpB.Namespace)
 
-		istioA := ossm.DefaultIstio()
-		istioA.Namespace = smcpA.Namespace
-		istioA.Name = smcpA.Namespace
-
-		istioB := ossm.DefaultIstio()
-		istioB.Namespace = smcpB.Namespace
-		istioB.Name = smcpB.Namespace
+		istioA := ossm3.DefaultIstio().WithName(smcpA.Namespace).WithNamespace(smcpA.Namespace).WithValues(tenantValues("tenant-a"))
+		istioB := ossm3.DefaultIstio().WithName(smcpB.Namespace).WithNamespace(smcpB.Namespace).WithValues(tenantValues("tenant-b"))
 
 		const (
 			bookinfoA = "bookinfo-a"
@@ -186,34 +180,6 @@ func TestMigrationSimpleMultiTenant(t *testing.T) {
 		installSMCPWithBookinfo(t, smcpB, bookinfoB)
 
 		t.LogStep("Create 3.y controlplane and IstioCNI")
-		istioA.Template = `apiVersion: sailoperator.io/v1
-kind: Istio
-metadata:
-  name: {{ .Name }}
-spec:
-  namespace: {{ .Namespace }}
-  version: v1.24-latest
-  values:
-    meshConfig:
-      outboundTrafficPolicy:
-        mode: REGISTRY_ONLY
-      discoverySelectors:
-        - matchLabels:
-            tenant: tenant-a`
-		istioB.Template = `apiVersion: sailoperator.io/v1
-kind: Istio
-metadata:
-  name: {{ .Name }}
-spec:
-  namespace: {{ .Namespace }}
-  version: v1.24-latest
-  values:
-    meshConfig:
-      outboundTrafficPolicy:
-        mode: REGISTRY_ONLY
-      discoverySelectors:
-        - matchLabels:
-            tenant: tenant-b`
 		setupIstio(t, istioA, istioB)
 
 		t.LogStep("Migrate bookinfo A to 3.y controlplane")
@@ -224,8 +190,20 @@ spec:
 	})
 }
 
-func migrateBookinfo(t test.TestHelper, istio ossm.Istio, bookinfoNamespace string) {
-	ossm3RevName := oc.GetJson(t, "", "Istio", istio.Name, "{.status.activeRevisionName}")
+// tenantValues restricts the control plane to the namespaces of the tenant and to the services in its registry
+func tenantValues(tenant string) map[string]any {
+	return map[string]any{
+		"meshConfig": map[string]any{
+			"outboundTrafficPolicy": map[string]any{"mode": "REGISTRY_ONLY"},
+			"discoverySelectors": []any{
+				map[string]any{"matchLabels": map[string]any{"tenant": tenant}},
+			},
+		},
+	}
+}
+
+func migrateBookinfo(t test.TestHelper, istio ossm3.Istio, bookinfoNamespace string) {
+	ossm3RevName := istio.ActiveRevision(t)
 	oc.Label(t, "", "Namespace", bookinfoNamespace, fmt.Sprintf("%s istio.io/rev=%s tenant=%s", maistraIgnoreLabel, ossm3RevName, ossm3RevName))
 
 	workloads := []workload{
@@ -333,13 +311,7 @@ spec:
 }
 
 func TestMigrationSimpleClusterWideLoadBalancer(t *testing.T) {
-	test.NewTest(t).MinVersion(version.SMCP_2_6).Groups(test.Migration).Run(func(t test.TestHelper) {
-		if arch := env.GetArch(); arch == "p" || arch == "z" {
-			t.Skipf("External LoadBalancer test not supported on arch: %s", arch)
-		}
-		if cluster.SupportsIPv6(t) {
-			t.Skip("External LoadBalancer test not supported on IPv6 cluster")
-		}
+	test.NewTest(t).MinVersion(version.SMCP_2_6).Groups(test.Migration).RequiresCapability(capability.LoadBalancer).Run(func(t test.TestHelper) {
 
 		// delete mesh namespace from previous tests
 		t.LogStep("Cleanup any lingering namespaces from previous runs")
@@ -355,9 +327,8 @@ func TestMigrationSimpleClusterWideLoadBalancer(t *testing.T) {
 
 		t.LogStep("Install SMCP 2.6 in clusterwide mode")
 		smcp := ossm.DefaultClusterWideSMCP(t)
-		istio := ossm.DefaultIstio()
 		// These are defaulted to the same but better to be explicit.
-		istio.Namespace = smcp.Namespace
+		istio := ossm3.DefaultIstio().WithNamespace(smcp.Namespace)
 		ossm.BasicSetup(t)
 		templ := `apiVersion: maistra.io/v2
 kind: ServiceMeshControlPlane
@@ -427,7 +398,7 @@ spec:
 
 		t.LogStep("Migrate bookinfo to 3.y controlplane")
 		t.Log("Getting Istio active Rev name")
-		ossm3RevName := oc.GetJson(t, "", "Istio", istio.Name, "{.status.activeRevisionName}")
+		ossm3RevName := istio.ActiveRevision(t)
 		t.Log("Relabeling bookinfo namespace")
 		oc.Label(t, "", "Namespace", ns.Bookinfo, maistraIgnoreLabel+" istio-injection- istio.io/rev="+ossm3RevName)
 		// Wait for book info to be removed.
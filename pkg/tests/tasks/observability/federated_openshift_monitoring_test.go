@@ -17,7 +17,6 @@ package observability
 import (
 	"fmt"
 	"net/http"
-	"strings"
 	"testing"
 
 	"github.com/maistra/maistra-test-tool/pkg/app"
@@ -68,12 +67,7 @@ func TestFederatedOpenShiftMonitoring(t *testing.T) {
 		oc.ApplyString(t, meshNamespace, federatedMonitor)
 
 		t.LogStep("Wait until istio targets appear in the Prometheus")
-		retry.UntilSuccess(t, func(t TestHelper) {
-			resp := prometheus.ThanosTargets(t, monitoringNs)
-			if !strings.Contains(resp, "serviceMonitor/istio-system/istio-federation") {
-				t.Error("Istio Prometheus target serviceMonitor/istio-system/istio-federation are not ready")
-			}
-		})
+		prometheus.DefaultThanos.WaitForTargetUp(t, monitoringNs, "serviceMonitor/istio-system/istio-federation")
 
 		t.LogStep("Generate some ingress traffic")
 		oc.ApplyFile(t, ns.Foo, "https://raw.githubusercontent.com/maistra/istio/maistra-2.6/samples/httpbin/httpbin-gateway.yaml")
@@ -83,19 +77,9 @@ func TestFederatedOpenShiftMonitoring(t *testing.T) {
 		})
 
 		t.LogStep("Check istiod metrics")
-		retry.UntilSuccess(t, func(t TestHelper) {
-			resp := prometheus.ThanosQuery(t, monitoringNs, `pilot_info{mesh_id="unique-mesh-id"}`)
-			if len(resp.Data.Result) == 0 {
-				t.Errorf("No data points received from Prometheus API. Response status: %s", resp.Status)
-			}
-		})
+		prometheus.DefaultThanos.WaitForMetric(t, monitoringNs, `pilot_info{mesh_id="unique-mesh-id"}`, prometheus.HasSeries())
 
 		t.LogStep("Check httpbin metrics")
-		retry.UntilSuccess(t, func(t TestHelper) {
-			resp := prometheus.ThanosQuery(t, monitoringNs, `istio_requests_total{mesh_id="unique-mesh-id"}`)
-			if len(resp.Data.Result) == 0 {
-				t.Errorf("No data points received from Prometheus API. Response status: %s", resp.Status)
-			}
-		})
+		prometheus.DefaultThanos.WaitForMetric(t, monitoringNs, `istio_requests_total{mesh_id="unique-mesh-id"}`, prometheus.HasSeries())
 	})
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/maistra/maistra-test-tool/pkg/util/ns"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
 	"github.com/maistra/maistra-test-tool/pkg/util/pod"
+	"github.com/maistra/maistra-test-tool/pkg/util/prometheus"
 	"github.com/maistra/maistra-test-tool/pkg/util/retry"
 	"github.com/maistra/maistra-test-tool/pkg/util/shell"
 	"github.com/maistra/maistra-test-tool/pkg/util/version"
@@ -216,17 +217,7 @@ func generateTrafficAndcheckMetrics(t TestHelper, thanosToken string) {
 }
 
 func checkMetricExists(t TestHelper, ns, metricName, token string) {
-	retry.UntilSuccess(t, func(t TestHelper) {
-		oc.Exec(t,
-			pod.MatchingSelectorFirst("app.kubernetes.io/instance=thanos-querier", monitoringNs),
-			"thanos-query",
-			prometheusQuery(ns, metricName, token),
-			assert.OutputContains(
-				fmt.Sprintf(`"result":[{"metric":{"__name__":"%s"`, metricName),
-				fmt.Sprintf("Successfully fetched %s metrics", metricName),
-				fmt.Sprintf("Did not find %s metric", metricName)),
-		)
-	})
+	thanos(token).WaitForMetric(t, monitoringNs, fmt.Sprintf(`%s{namespace="%s"}`, metricName, ns), prometheus.HasSeries())
 }
 
 func waitUntilAllPrometheusTargetReady(t TestHelper, token string) {
@@ -235,28 +226,12 @@ func waitUntilAllPrometheusTargetReady(t TestHelper, token string) {
 }
 
 func waitUntilPrometheusTargetReady(t TestHelper, monitorType string, ns string, targetName string, token string) {
-	retry.UntilSuccess(t, func(t TestHelper) {
-		oc.Exec(t,
-			pod.MatchingSelectorFirst("app.kubernetes.io/instance=thanos-querier", monitoringNs),
-			"thanos-query",
-			prometheusActiveTargetQuery(token),
-			assert.OutputContains(
-				fmt.Sprintf(`"scrapePool":"%s/%s/%s`, monitorType, ns, targetName),
-				fmt.Sprintf("The %s %s prometheus target is ready in namespace %s", monitorType, targetName, ns),
-				fmt.Sprintf("The %s %s prometheus target is not ready yet in namespace %s", monitorType, targetName, ns)),
-		)
-	})
-}
-
-func prometheusQuery(ns, metricName, token string) string {
-	return fmt.Sprintf(
-		`curl -X GET -kG "https://localhost:9091/api/v1/query?namespace=%s&query=%s" --data-urlencode "query=up" -H "Authorization: Bearer %s"`,
-		ns, metricName, token)
+	thanos(token).WaitForTargetUp(t, monitoringNs, fmt.Sprintf("%s/%s/%s", monitorType, ns, targetName))
 }
 
-func prometheusActiveTargetQuery(token string) string {
-	return fmt.Sprintf(
-		`curl -X GET -kG "https://localhost:9091/api/v1/targets?state=active" --data-urlencode "query=up" -H "Authorization: Bearer %s"`, token)
+// thanos returns the Thanos querier API behind the authenticating proxy, which accepts the token of the Kiali service account
+func thanos(token string) prometheus.Prometheus {
+	return prometheus.DefaultThanos.WithBaseURL("https://localhost:9091").WithToken(token)
 }
 
 func fetchKialiToken(t TestHelper) string {
@@ -21,6 +21,7 @@ import (
 	"github.com/maistra/maistra-test-tool/pkg/tests/ossm"
 	"github.com/maistra/maistra-test-tool/pkg/util/check/assert"
 	"github.com/maistra/maistra-test-tool/pkg/util/curl"
+	"github.com/maistra/maistra-test-tool/pkg/util/istio/config"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
 	"github.com/maistra/maistra-test-tool/pkg/util/retry"
 
@@ -88,8 +89,7 @@ func TestAuthorizationHTTPTraffic(t *testing.T) {
 				curl.Request(t,
 					productPageURL,
 					nil,
-					assert.ResponseContains("Error fetching product details"),
-					assert.ResponseContains("Error fetching product reviews"),
+					app.ResponseHasErrors(app.ErrorFetchingProductDetails, app.ErrorFetchingProductReviews),
 				)
 			})
 		})
@@ -114,101 +114,29 @@ func TestAuthorizationHTTPTraffic(t *testing.T) {
 				curl.Request(t,
 					productPageURL,
 					nil,
-					assert.ResponseDoesNotContain("RBAC: access denied"),
-					assert.ResponseDoesNotContain("Error fetching product details"),
-					assert.ResponseDoesNotContain("Error fetching product reviews"),
-					assert.ResponseDoesNotContain("Ratings service currently unavailable"),
+					app.ResponseHasNoErrors(),
 				)
 			})
 		})
 	})
 }
 
-const (
-	DenyAllPolicy = `
-apiVersion: security.istio.io/v1beta1
-kind: AuthorizationPolicy
-metadata:
-  name: allow-nothing
-  namespace: bookinfo
-spec:
-  {}
-`
-
-	ProductpageGETPolicy = `
-apiVersion: "security.istio.io/v1beta1"
-kind: "AuthorizationPolicy"
-metadata:
-  name: "productpage-viewer"
-  namespace: bookinfo
-spec:
-  selector:
-    matchLabels:
-      app: productpage
-  action: ALLOW
-  rules:
-  - to:
-    - operation:
-        methods: ["GET"]
-`
-
-	DetailsGETPolicy = `
-apiVersion: "security.istio.io/v1beta1"
-kind: "AuthorizationPolicy"
-metadata:
-  name: "details-viewer"
-  namespace: bookinfo
-spec:
-  selector:
-    matchLabels:
-      app: details
-  action: ALLOW
-  rules:
-  - from:
-    - source:
-        principals: ["cluster.local/ns/bookinfo/sa/bookinfo-productpage"]
-    to:
-    - operation:
-        methods: ["GET"]
-`
-
-	ReviewsGETPolicy = `
-apiVersion: "security.istio.io/v1beta1"
-kind: "AuthorizationPolicy"
-metadata:
-  name: "reviews-viewer"
-  namespace: bookinfo
-spec:
-  selector:
-    matchLabels:
-      app: reviews
-  action: ALLOW
-  rules:
-  - from:
-    - source:
-        principals: ["cluster.local/ns/bookinfo/sa/bookinfo-productpage"]
-    to:
-    - operation:
-        methods: ["GET"]
-`
-
-	RatingsGETPolicy = `
-apiVersion: "security.istio.io/v1beta1"
-kind: "AuthorizationPolicy"
-metadata:
-  name: "ratings-viewer"
-  namespace: bookinfo
-spec:
-  selector:
-    matchLabels:
-      app: ratings
-  action: ALLOW
-  rules:
-  - from:
-    - source:
-        principals: ["cluster.local/ns/bookinfo/sa/bookinfo-reviews"]
-    to:
-    - operation:
-        methods: ["GET"]
-`
+var (
+	DenyAllPolicy = config.AuthorizationPolicy("allow-nothing").InNamespace("bookinfo").Manifest()
+
+	ProductpageGETPolicy = config.AuthorizationPolicy("productpage-viewer").InNamespace("bookinfo").WithSelector("app=productpage").
+				WithAction(config.ActionAllow).WithRule(config.Rule{}.ToMethods("GET")).Manifest()
+
+	DetailsGETPolicy = bookinfoViewerPolicy("details", "bookinfo-productpage")
+	ReviewsGETPolicy = bookinfoViewerPolicy("reviews", "bookinfo-productpage")
+	RatingsGETPolicy = bookinfoViewerPolicy("ratings", "bookinfo-reviews")
 )
+
+// bookinfoViewerPolicy allows GET requests to the bookinfo service from the workloads with the service account
+func bookinfoViewerPolicy(service, serviceAccount string) string {
+	return config.AuthorizationPolicy(service + "-viewer").InNamespace("bookinfo").
+		WithSelector("app=" + service).
+		WithAction(config.ActionAllow).
+		WithRule(config.Rule{}.FromPrincipals("cluster.local/ns/bookinfo/sa/" + serviceAccount).ToMethods("GET")).
+		Manifest()
+}
@@ -16,8 +16,6 @@ package certificate
 
 import (
 	"crypto/x509"
-	"encoding/pem"
-	"os"
 	"testing"
 
 	"github.com/maistra/maistra-test-tool/pkg/app"
@@ -25,6 +23,7 @@ import (
 	"github.com/maistra/maistra-test-tool/pkg/util/check/assert"
 	"github.com/maistra/maistra-test-tool/pkg/util/curl"
 	"github.com/maistra/maistra-test-tool/pkg/util/env"
+	"github.com/maistra/maistra-test-tool/pkg/util/istio"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
 	"github.com/maistra/maistra-test-tool/pkg/util/pod"
 	"github.com/maistra/maistra-test-tool/pkg/util/retry"
@@ -50,14 +49,11 @@ func TestExternalCertificate(t *testing.T) {
 		t.LogStep("Uninstall existing SMCP")
 		oc.RecreateNamespace(t, meshNamespace)
 
-		t.LogStep("Create cacerts secret")
-		oc.CreateGenericSecretFromFiles(t, meshNamespace, "cacerts",
-			"ca-cert.pem="+cert.SampleCACert,
-			"ca-key.pem="+cert.SampleCAKey,
-			"root-cert.pem="+cert.SampleCARoot,
-			"cert-chain.pem="+cert.SampleCAChain)
-		rootCert := readPemCertificatesFromFile(t, cert.SampleCARoot)[0]
-		chainCerts := readPemCertificatesFromFile(t, cert.SampleCAChain)
+		t.LogStep("Create cacerts secret with a new intermediate CA")
+		ca := cert.NewCAChain(1, cert.RSA)
+		oc.CreateGenericSecretFromFiles(t, meshNamespace, "cacerts", ca.WriteCACerts(t.T().TempDir())...)
+		rootCert := ca.Root()
+		chainCerts := ca.Chain()
 
 		t.LogStep("Apply SMCP to configure certificate authority to use cacerts secret")
 		oc.ApplyTemplate(t, meshNamespace, SMCPWithCustomCA, meshValues)
@@ -77,21 +73,16 @@ func TestExternalCertificate(t *testing.T) {
 
 		var returnedCerts []*x509.Certificate
 		retry.UntilSuccess(t, func(t TestHelper) {
-			opensslOutput := oc.Exec(t,
-				pod.MatchingSelector("app=productpage", ns), "istio-proxy",
-				`openssl s_client -showcerts -connect details:9080 || true`) // the "|| true" is needed until oc.Exec can ignore return codes
-			returnedCerts = readPemCertificatesFromText(t, opensslOutput)
+			returnedCerts = istio.ServedCertChain(t, oc.DefaultOC, pod.MatchingSelector("app=productpage", ns), "istio-proxy", "details:9080")
 		})
-		certToVerify := returnedCerts[0]
-		otherCerts := returnedCerts[1:]
 
-		verifyContainsCerts(t, otherCerts, chainCerts,
+		verifyContainsCerts(t, returnedCerts[1:], chainCerts,
 			"The cert-chain certificates are present in the certificates sent by the tested service",
 			"The cert-chain certificates were not found in the certificates sent by the tested service")
 
-		verifyCertificate(t, certToVerify, []*x509.Certificate{rootCert}, otherCerts,
-			"Certificate trust chain successfully verified",
-			"Unable to verify certificate trust chain")
+		istio.CheckCertChain(t, returnedCerts,
+			istio.IssuedBy(rootCert),
+			istio.HasSPIFFEID(cert.SPIFFEID("cluster.local", ns, "bookinfo-details")))
 	})
 }
 
@@ -120,76 +111,6 @@ func verifyContainsCerts(t TestHelper, actualCerts, expectedCerts []*x509.Certif
 	t.Error(failureMsg)
 }
 
-func verifyCertificate(t TestHelper, cert *x509.Certificate, rootCerts, intermediateCerts []*x509.Certificate, successMsg, failureMsg string) {
-	t.T().Helper()
-	rootCertPool := x509.NewCertPool()
-	for _, c := range rootCerts {
-		rootCertPool.AddCert(c)
-	}
-
-	intermediateCertPool := x509.NewCertPool()
-	for _, c := range intermediateCerts {
-		intermediateCertPool.AddCert(c)
-	}
-
-	chains, err := cert.Verify(x509.VerifyOptions{
-		Roots:         rootCertPool,
-		Intermediates: intermediateCertPool})
-
-	if err != nil {
-		// x509.UnknownAuthorityError will be generated during a non-exceptional failure and can be ignored here
-		_, isUnkAuthErr := err.(x509.UnknownAuthorityError)
-		if !isUnkAuthErr {
-			t.Fatalf(`Error while verifying certificate: %v`, err)
-		}
-	}
-
-	if len(chains) > 0 {
-		t.LogSuccess(successMsg)
-	} else {
-		t.Error(failureMsg)
-	}
-}
-
-func readPemCertificatesFromFile(t TestHelper, path string) []*x509.Certificate {
-	t.T().Helper()
-	bytes, err := os.ReadFile(path)
-	if err != nil {
-		t.Fatalf(`Error reading certificates: Unable to open "%s" for reading: %v`, path, err)
-	}
-	return readPemCertificates(t, bytes)
-}
-
-func readPemCertificatesFromText(t TestHelper, text string) []*x509.Certificate {
-	t.T().Helper()
-
-	return readPemCertificates(t, []byte(text))
-}
-
-func readPemCertificates(t TestHelper, pemData []byte) []*x509.Certificate {
-	t.T().Helper()
-	var certificates []*x509.Certificate
-	for {
-		block, rest := pem.Decode(pemData)
-		if block == nil {
-			break
-		}
-		if block.Type != "CERTIFICATE" {
-			continue
-		}
-		cert, err := x509.ParseCertificate(block.Bytes)
-		if err != nil {
-			t.Fatalf("failed to parse certificate: %w", err)
-		}
-		certificates = append(certificates, cert)
-		pemData = rest
-	}
-	if len(certificates) == 0 {
-		t.Fatal("Failed to read certificates: No certificates found in text.")
-	}
-	return certificates
-}
-
 const (
 	SMCPWithCustomCA = `
 apiVersion: maistra.io/v2
@@ -36,17 +36,9 @@ import (
 )
 
 func TestIstioCsr(t *testing.T) {
-	test.NewTest(t).Id("T38").Groups(test.Full, test.ARM).Run(func(t test.TestHelper) {
-		// Validate OCP version, this test setup can't be executed in OCP versions less than 4.12
-		// More information in: https://57747--docspreview.netlify.app/openshift-enterprise/latest/service_mesh/v2x/ossm-security.html#ossm-cert-manager-integration-istio_ossm-security
+	test.NewTest(t).Id("T38").Groups(test.Full, test.ARM).MinVersion(version.SMCP_2_4).MinOCPVersion(version.OCP_4_12).Run(func(t test.TestHelper) {
+		// More information about the supported versions in: https://57747--docspreview.netlify.app/openshift-enterprise/latest/service_mesh/v2x/ossm-security.html#ossm-cert-manager-integration-istio_ossm-security
 		smcpVer := env.GetSMCPVersion()
-		if smcpVer.LessThan(version.SMCP_2_4) {
-			t.Skip("istio-csr is not supported in SMCP older than v2.4")
-		}
-		ocpVersion := version.ParseVersion(oc.GetOCPVersion(t))
-		if ocpVersion.LessThan(version.OCP_4_12) {
-			t.Skip("istio-csr is not supported in OCP older than v4.12")
-		}
 
 		meshValues := map[string]string{
 			"Name":    smcpName,
@@ -17,7 +17,6 @@ package traffic
 import (
 	"bufio"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -25,6 +24,8 @@ import (
 	"github.com/maistra/maistra-test-tool/pkg/app"
 	"github.com/maistra/maistra-test-tool/pkg/tests/ossm"
 	"github.com/maistra/maistra-test-tool/pkg/util/check/assert"
+	"github.com/maistra/maistra-test-tool/pkg/util/istio/config"
+	"github.com/maistra/maistra-test-tool/pkg/util/load"
 	"github.com/maistra/maistra-test-tool/pkg/util/ns"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
 	"github.com/maistra/maistra-test-tool/pkg/util/pod"
@@ -61,26 +62,14 @@ func TestCircuitBreaking(t *testing.T) {
 					"Expected 200 OK from httpbin, but got an unexpected response"))
 		})
 
-		connection := 2
-		reqCount := 50
 		t.LogStep("Trip the circuit breaker by sending 50 requests to httpbin with 2 connections")
-		t.Log("We expect request with response code 503")
+		t.Log("We expect at least 5% of the requests to fail with response code 503")
 		retry.UntilSuccess(t, func(t TestHelper) {
 			httpbinIP := oc.GetServiceClusterIP(t, ns.Bookinfo, "httpbin")
-			msg := oc.Exec(t,
-				pod.MatchingSelector("app=fortio", ns.Bookinfo),
-				"fortio",
-				fmt.Sprintf("/usr/bin/fortio load -c %d -qps 0 -n %d -loglevel Warning -resolve %s http://httpbin:8000/get", connection, reqCount, httpbinIP))
-
-			c200 := getNumberOfResponses(t, msg, `Code 200.*`)
-			c503 := getNumberOfResponses(t, msg, `Code 503.*`)
-			successRate200 := 100 * c200 / reqCount
-			failureRate503 := 100 * c503 / reqCount
-			t.Log(fmt.Sprintf("Success rate 200: %d%%", successRate200))
-			t.Log(fmt.Sprintf("Success rate 503: %d%%", failureRate503))
-			if failureRate503 < 5 {
-				t.Fatalf("Failed to trip the circuit breaker")
-			}
+			load.Run(t,
+				load.FromFortio(pod.MatchingSelector("app=fortio", ns.Bookinfo), "fortio", "http://httpbin:8000/get", "-resolve", httpbinIP),
+				load.Options().Requests(50).Connections(2),
+				load.CodeRatioAtLeast(503, 0.05))
 
 			t.LogStep("Validate the circuit breaker is tripped by checking the istio-proxy log")
 			t.Log("Verify istio-proxy pilot-agent stats, expected upstream_rq_pending_overflow value to be more than zero")
@@ -93,19 +82,6 @@ func TestCircuitBreaking(t *testing.T) {
 	})
 }
 
-func getNumberOfResponses(t TestHelper, msg string, codeText string) int {
-	re := regexp.MustCompile(codeText)
-	line := re.FindStringSubmatch(msg)[0]
-	re = regexp.MustCompile(`: [\d]+`)
-	word := re.FindStringSubmatch(line)[0]
-	count, err := strconv.Atoi(strings.TrimLeft(word, ": "))
-	if err != nil {
-		t.Fatalf("Failed to parse %s count: %v", codeText, err)
-	}
-
-	return count
-}
-
 func assertProxyContainsUpstreamRqPendingOverflow(t TestHelper, output string) {
 	var v int
 	scanner := bufio.NewScanner(strings.NewReader(output))
@@ -129,18 +105,4 @@ func assertProxyContainsUpstreamRqPendingOverflow(t TestHelper, output string) {
 	}
 }
 
-var httpbinCircuitBreaker = `
-apiVersion: networking.istio.io/v1alpha3
-kind: DestinationRule
-metadata:
-  name: httpbin
-spec:
-  host: httpbin
-  trafficPolicy:
-    connectionPool:
-      tcp:
-        maxConnections: 1
-      http:
-        http1MaxPendingRequests: 1
-        maxRequestsPerConnection: 1
-`
+var httpbinCircuitBreaker = config.DestinationRule("httpbin").WithCircuitBreaker(1, 1, 1).Manifest()
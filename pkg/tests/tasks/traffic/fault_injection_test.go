@@ -15,8 +15,6 @@
 package traffic
 
 import (
-	_ "embed"
-	"os"
 	"testing"
 	"time"
 
@@ -24,7 +22,7 @@ import (
 	"github.com/maistra/maistra-test-tool/pkg/tests/ossm"
 	"github.com/maistra/maistra-test-tool/pkg/util/check/assert"
 	"github.com/maistra/maistra-test-tool/pkg/util/curl"
-	"github.com/maistra/maistra-test-tool/pkg/util/env"
+	"github.com/maistra/maistra-test-tool/pkg/util/istio/config"
 	"github.com/maistra/maistra-test-tool/pkg/util/ns"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
 	"github.com/maistra/maistra-test-tool/pkg/util/retry"
@@ -32,19 +30,24 @@ import (
 )
 
 var (
-	//go:embed yaml/virtualservice-ratings-fixed-delay.yaml
-	ratingsVirtualServiceWithFixedDelay string
-
-	//go:embed yaml/virtualservice-ratings-abort500.yaml
-	ratingsVirtualServiceWithHttpStatus500 string
+	ratingsVirtualServiceWithFixedDelay    = ratingsVirtualServiceForJason(config.HTTPRoute{}.WithDelay(100, 7*time.Second))
+	ratingsVirtualServiceWithHttpStatus500 = ratingsVirtualServiceForJason(config.HTTPRoute{}.WithAbort(100, 500))
 )
 
+// ratingsVirtualServiceForJason applies the fault of the route to the requests of the user jason and sends all
+// requests to ratings v1
+func ratingsVirtualServiceForJason(fault config.HTTPRoute) string {
+	return config.VirtualService("ratings").
+		WithHTTPRoute(fault.MatchHeader("end-user", "jason").To("ratings", "v1", 0)).
+		Route("v1", 0).
+		Manifest()
+}
+
 func TestFaultInjection(t *testing.T) {
 	NewTest(t).Id("T2").Groups(Full, InterOp, ARM, Disconnected, Persistent).Run(func(t TestHelper) {
 
 		t.Cleanup(func() {
 			oc.RecreateNamespace(t, ns.Bookinfo)
-			os.Remove(env.GetRootDir() + `/testdata/resources/html/modified-productpage-test-user-v2-rating-unavailable.html`)
 		})
 
 		ossm.DeployControlPlane(t)
@@ -66,29 +69,20 @@ func TestFaultInjection(t *testing.T) {
 					app.BookinfoProductPageURL(t, meshNamespace),
 					curl.WithCookieJar(testUserCookieJar),
 					assert.DurationInRange(4*time.Second, 14*time.Second),
-					assert.ResponseMatchesFile(
-						"productpage-test-user-v2-review-timeout.html",
-						"productpage shows 'error fetching product reviews', which is expected",
-						"expected productpage to show 'error fetching product reviews', but got a different response",
-						app.ProductPageResponseFiles...))
+					app.ResponseHasErrors(app.ErrorFetchingProductReviews))
 			})
 		})
 
 		t.NewSubTest("ratings-fault-abort").Run(func(t TestHelper) {
 			oc.ApplyString(t, ns.Bookinfo, ratingsVirtualServiceWithHttpStatus500)
 
-			expectedResponseFile := TestreviewV2(t, "productpage-test-user-v2-rating-unavailable.html")
-
 			t.LogStep("check if productpage shows ratings service as unavailable due to abort injection")
 			retry.UntilSuccess(t, func(t TestHelper) {
 				curl.Request(t,
 					app.BookinfoProductPageURL(t, meshNamespace),
 					curl.WithCookieJar(testUserCookieJar),
-					assert.ResponseMatchesFile(
-						expectedResponseFile,
-						"productpage shows 'ratings service is currently unavailable' as expected",
-						"expected productpage to show ratings service as unavailable, but got a different response",
-						app.ProductPageResponseFiles...))
+					app.ResponseIsReviewsVersion("v2"),
+					app.ResponseHasRatingsUnavailable())
 			})
 		})
 	})
@@ -21,6 +21,7 @@ import (
 
 	"github.com/maistra/maistra-test-tool/pkg/app"
 	"github.com/maistra/maistra-test-tool/pkg/tests/ossm"
+	"github.com/maistra/maistra-test-tool/pkg/util/capability"
 	"github.com/maistra/maistra-test-tool/pkg/util/check/assert"
 	"github.com/maistra/maistra-test-tool/pkg/util/env"
 	"github.com/maistra/maistra-test-tool/pkg/util/gatewayapi"
@@ -39,11 +40,7 @@ type EnvVar struct {
 }
 
 func TestGatewayApi(t *testing.T) {
-	NewTest(t).Id("T41").Groups(Full, InterOp, ARM, Disconnected).Run(func(t TestHelper) {
-		if env.GetSMCPVersion().LessThan(version.SMCP_2_3) {
-			t.Skip("TestGatewayApi was added in v2.3")
-		}
-
+	NewTest(t).Id("T41").Groups(Full, InterOp, ARM, Disconnected).MinVersion(version.SMCP_2_3).RequiresCapability(capability.GatewayAPI).Run(func(t TestHelper) {
 		smcpName := env.GetDefaultSMCPName()
 		istiodDeployment := fmt.Sprintf("istiod-%s", smcpName)
 
@@ -15,31 +15,31 @@
 package traffic
 
 import (
-	_ "embed"
-	"os"
 	"testing"
+	"time"
 
 	"github.com/maistra/maistra-test-tool/pkg/app"
 	"github.com/maistra/maistra-test-tool/pkg/tests/ossm"
-	"github.com/maistra/maistra-test-tool/pkg/util/check/assert"
-	"github.com/maistra/maistra-test-tool/pkg/util/check/require"
 	"github.com/maistra/maistra-test-tool/pkg/util/curl"
-	"github.com/maistra/maistra-test-tool/pkg/util/env"
+	"github.com/maistra/maistra-test-tool/pkg/util/istio/config"
 	"github.com/maistra/maistra-test-tool/pkg/util/ns"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
 	"github.com/maistra/maistra-test-tool/pkg/util/retry"
 	. "github.com/maistra/maistra-test-tool/pkg/util/test"
 )
 
-//go:embed yaml/virtualservice-reviews-ratings-timeout.yaml
-var reviewTimeout string
+// reviewTimeout sends all requests to reviews v2, which calls ratings, and delays the ratings requests
+// beyond the timeout of the reviews requests
+var reviewTimeout = config.YAML(
+	config.VirtualService("reviews").WithHTTPRoute(config.HTTPRoute{}.To("reviews", "v2", 0).WithTimeout(500*time.Millisecond)),
+	config.VirtualService("ratings").WithHTTPRoute(config.HTTPRoute{}.WithDelay(100, 2*time.Second).To("ratings", "v1", 0)),
+)
 
 func TestRequestTimeouts(t *testing.T) {
 	NewTest(t).Id("T5").Groups(Full, InterOp, ARM, Disconnected, Persistent).Run(func(t TestHelper) {
 
 		t.Cleanup(func() {
 			oc.RecreateNamespace(t, ns.Bookinfo)
-			os.Remove(env.GetRootDir() + `/testdata/resources/html/modified-productpage-normal-user-v1.html`)
 		})
 
 		ossm.DeployControlPlane(t)
@@ -53,16 +53,8 @@ func TestRequestTimeouts(t *testing.T) {
 
 		t.LogStep("make sure there is no timeout before applying delay and timeout in VirtualServices")
 
-		expectedResponseFile := TestreviewV1(t, "productpage-normal-user-v1.html")
-
 		retry.UntilSuccess(t, func(t TestHelper) {
-			curl.Request(t,
-				productpageURL, nil,
-				assert.ResponseMatchesFile(
-					expectedResponseFile,
-					"received normal productpage response",
-					"unexpected response",
-					app.ProductPageResponseFiles...))
+			curl.Request(t, productpageURL, nil, app.ResponseIsReviewsVersion("v1"))
 		})
 
 		t.LogStep("apply delay and timeout in VirtualServices")
@@ -71,13 +63,7 @@ func TestRequestTimeouts(t *testing.T) {
 		t.LogStep("check if productpage shows 'error fetching product reviews' due to delay and timeout injection")
 		retry.UntilSuccess(t, func(t TestHelper) {
 			for i := 0; i <= 5; i++ {
-				curl.Request(t,
-					productpageURL, nil,
-					require.ResponseMatchesFile(
-						"productpage-review-timeout.html",
-						"productpage shows 'error fetching product reviews', which is expected",
-						"expected productpage to show 'error fetching product reviews', but got a different response",
-						app.ProductPageResponseFiles...))
+				curl.Request(t, productpageURL, nil, app.ResponseHasErrors(app.ErrorFetchingProductReviews))
 			}
 		})
 	})
@@ -21,6 +21,7 @@ import (
 	"github.com/maistra/maistra-test-tool/pkg/tests/ossm"
 	. "github.com/maistra/maistra-test-tool/pkg/util"
 	"github.com/maistra/maistra-test-tool/pkg/util/check/assert"
+	"github.com/maistra/maistra-test-tool/pkg/util/lease"
 	"github.com/maistra/maistra-test-tool/pkg/util/ns"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
 	"github.com/maistra/maistra-test-tool/pkg/util/pod"
@@ -30,33 +31,32 @@ import (
 
 func TestMirroring(t *testing.T) {
 	NewTest(t).Id("T7").Groups(Full, InterOp, ARM, Disconnected, Persistent).Run(func(t TestHelper) {
-
-		t.Cleanup(func() {
-			oc.RecreateNamespace(t, ns.Bookinfo)
-		})
+		t.Parallel()
+		lease.Shared(t, lease.ControlPlane)
 
 		ossm.DeployControlPlane(t)
+		bookinfo := ns.Unique(t, ns.Bookinfo)
 
 		t.LogStep("Install httpbin-v1, httpbin-v2, and sleep")
 		app.InstallAndWaitReady(t,
-			app.HttpbinV1(ns.Bookinfo),
-			app.HttpbinV2(ns.Bookinfo),
-			app.Sleep(ns.Bookinfo))
+			app.HttpbinV1(bookinfo),
+			app.HttpbinV2(bookinfo),
+			app.Sleep(bookinfo))
 
 		t.NewSubTest("no mirroring").Run(func(t TestHelper) {
-			oc.ApplyString(t, ns.Bookinfo, httpbinAllv1)
+			oc.ApplyString(t, bookinfo, httpbinAllv1)
 
 			t.LogStep("sending HTTP request from sleep to httpbin-v1, not expecting mirroring to v2")
 			retry.UntilSuccess(t, func(t TestHelper) {
 				nonce := NewNonce()
 
 				oc.Exec(t,
-					pod.MatchingSelector("app=sleep", ns.Bookinfo),
+					pod.MatchingSelector("app=sleep", bookinfo),
 					"sleep",
 					"curl -sS http://httpbin:8000/headers?nonce="+nonce)
 
 				oc.Logs(t,
-					pod.MatchingSelector("app=httpbin,version=v1", ns.Bookinfo),
+					pod.MatchingSelector("app=httpbin,version=v1", bookinfo),
 					"httpbin",
 					assert.OutputContains(
 						"GET /headers?nonce="+nonce,
@@ -64,7 +64,7 @@ func TestMirroring(t *testing.T) {
 						"request not received by httpbin-v1"))
 
 				oc.Logs(t,
-					pod.MatchingSelector("app=httpbin,version=v2", ns.Bookinfo),
+					pod.MatchingSelector("app=httpbin,version=v2", bookinfo),
 					"httpbin",
 					assert.OutputDoesNotContain(
 						"GET /headers?nonce="+nonce,
@@ -74,19 +74,19 @@ func TestMirroring(t *testing.T) {
 		})
 
 		t.NewSubTest("mirroring to httpbin-v2").Run(func(t TestHelper) {
-			oc.ApplyString(t, ns.Bookinfo, httpbinMirrorv2)
+			oc.ApplyString(t, bookinfo, httpbinMirrorv2)
 
 			t.LogStep("sending HTTP request from sleep to httpbin-v1, expecting mirroring to v2")
 			retry.UntilSuccess(t, func(t TestHelper) {
 				nonce := NewNonce()
 
 				oc.Exec(t,
-					pod.MatchingSelector("app=sleep", ns.Bookinfo),
+					pod.MatchingSelector("app=sleep", bookinfo),
 					"sleep",
 					"curl -sS http://httpbin:8000/headers?nonce="+nonce)
 
 				oc.Logs(t,
-					pod.MatchingSelector("app=httpbin,version=v1", ns.Bookinfo),
+					pod.MatchingSelector("app=httpbin,version=v1", bookinfo),
 					"httpbin",
 					assert.OutputContains(
 						"GET /headers?nonce="+nonce,
@@ -94,7 +94,7 @@ func TestMirroring(t *testing.T) {
 						"request not received by httpbin-v1"))
 
 				oc.Logs(t,
-					pod.MatchingSelector("app=httpbin,version=v2", ns.Bookinfo),
+					pod.MatchingSelector("app=httpbin,version=v2", bookinfo),
 					"httpbin",
 					assert.OutputContains(
 						"GET /headers?nonce="+nonce,
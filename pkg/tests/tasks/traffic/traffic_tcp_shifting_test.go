@@ -15,19 +15,16 @@
 package traffic
 
 import (
-	"bufio"
-	"fmt"
-	"strings"
 	"testing"
 
 	"github.com/maistra/maistra-test-tool/pkg/app"
 	"github.com/maistra/maistra-test-tool/pkg/tests/ossm"
-	"github.com/maistra/maistra-test-tool/pkg/util"
+	"github.com/maistra/maistra-test-tool/pkg/util/istio/config"
 	"github.com/maistra/maistra-test-tool/pkg/util/ns"
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
 	"github.com/maistra/maistra-test-tool/pkg/util/pod"
-	"github.com/maistra/maistra-test-tool/pkg/util/retry"
 	"github.com/maistra/maistra-test-tool/pkg/util/test"
+	"github.com/maistra/maistra-test-tool/pkg/util/traffic"
 )
 
 // TestTcpTrafficShifting validates TCP traffic shifting feature.
@@ -45,6 +42,9 @@ func TestTcpTrafficShifting(t *testing.T) {
 
 		t.LogStep("Install sleep, echoV1 and echoV2")
 		app.InstallAndWaitReady(t, app.Sleep(ns.Foo), app.EchoV1(ns.Foo), app.EchoV2(ns.Foo))
+		tcpEcho := traffic.TCPFromPod(pod.MatchingSelector("app=sleep", ns.Foo), "sleep", "tcp-echo", "9000")
+		// tcp-echo v1 prefixes the echoed line with "one" and v2 with "two"
+		echoVersion := traffic.ByBodyRegexp(`^(one|two) `)
 
 		t.NewSubTest("tcp shift 100 percent to v1").Run(func(t test.TestHelper) {
 			t.Cleanup(func() {
@@ -54,14 +54,8 @@ func TestTcpTrafficShifting(t *testing.T) {
 			t.LogStep("Shifting all TCP traffic to v1")
 			oc.ApplyString(t, ns.Foo, EchoAllv1Yaml)
 
-			t.LogStep("make 20 requests and checking if all of them go to v1 (tolerance: 0%)")
-			retry.UntilSuccess(t, func(t test.TestHelper) {
-				tolerance := 0.0
-				checkTcpTrafficRatio(t, ns.Foo, "tcp-echo", "9000", 20, tolerance, map[string]float64{
-					"one": 1.0,
-					"two": 0.0,
-				})
-			})
+			t.LogStep("Check if all TCP connections go to v1")
+			traffic.CheckWeights(t, tcpEcho, echoVersion, traffic.Weights{"one": 1, "two": 0})
 		})
 
 		t.NewSubTest("tcp shift 20 percent to v2").Run(func(t test.TestHelper) {
@@ -72,148 +66,21 @@ func TestTcpTrafficShifting(t *testing.T) {
 			t.LogStep("Shifting 20 percent TCP traffic to v2")
 			oc.ApplyString(t, ns.Foo, Echo20v2Yaml)
 
-			t.LogStep("make 100 requests and checking if 20 percent of them go to v2 (tolerance: 10%)")
-			retry.UntilSuccess(t, func(t test.TestHelper) {
-				tolerance := 0.10
-				checkTcpTrafficRatio(t, ns.Foo, "tcp-echo", "9000", 100, tolerance, map[string]float64{
-					"one": 0.8,
-					"two": 0.2,
-				})
-			})
+			t.LogStep("Check if 20 percent of TCP connections go to v2")
+			traffic.CheckWeights(t, tcpEcho, echoVersion, traffic.Weights{"one": 0.8, "two": 0.2})
 		})
 	})
 }
 
-func checkTcpTrafficRatio(t test.TestHelper, ns, host, port string, numberOfRequests int, tolerance float64, ratios map[string]float64) {
-	counts := map[string]int{}
-	oc.Exec(t,
-		pod.MatchingSelector("app=sleep", ns),
-		"sleep",
-		fmt.Sprintf(`sh -c 'i=1; while [ $i -le %d ]; do date | nc %s %s; i=$((i+1)); done'`, numberOfRequests, host, port),
-		func(t test.TestHelper, output string) {
-			scanner := bufio.NewScanner(strings.NewReader(output))
-			for scanner.Scan() {
-				line := scanner.Text()
-				matched := false
-				for version := range ratios {
-					if strings.Contains(line, version) {
-						matched = true
-						counts[version]++
-					}
-				}
-				if !matched {
-					t.Fatalf("nc tcp-echo output did not match any expected version, got output: %s", output)
-				}
-			}
-		})
+var (
+	EchoAllv1Yaml = tcpEchoConfig(config.VirtualService("tcp-echo").TCPRoute(9000, "v1", 0))
+	Echo20v2Yaml  = tcpEchoConfig(config.VirtualService("tcp-echo").TCPRoute(9000, "v1", 80).TCPRoute(9000, "v2", 20))
+)
 
-	for version, count := range counts {
-		expectedRate := ratios[version]
-		actualRate := float64(count) / float64(numberOfRequests)
-		if util.IsWithinPercentage(count, numberOfRequests, expectedRate, tolerance) {
-			t.LogSuccessf("%d/%d responses matched %s (actual rate %f, expected %f, tolerance %f)", count, numberOfRequests, version, actualRate, expectedRate, tolerance)
-		} else {
-			t.Errorf("%d/%d responses matched %s (actual rate %f, expected %f, tolerance %f)", count, numberOfRequests, version, actualRate, expectedRate, tolerance)
-		}
-	}
+func tcpEchoConfig(vs config.VirtualServiceConfig) string {
+	return config.YAML(
+		config.Gateway("tcp-echo-gateway").WithServer(31400, "TCP", "*"),
+		config.DestinationRule("tcp-echo").WithName("tcp-echo-destination").WithVersionSubsets("v1", "v2"),
+		vs,
+	)
 }
-
-const (
-	EchoAllv1Yaml = `
-apiVersion: networking.istio.io/v1alpha3
-kind: Gateway
-metadata:
-  name: tcp-echo-gateway
-spec:
-  selector:
-    istio: ingressgateway
-  servers:
-  - port:
-      number: 31400
-      name: tcp
-      protocol: TCP
-    hosts:
-    - "*"
----
-apiVersion: networking.istio.io/v1alpha3
-kind: DestinationRule
-metadata:
-  name: tcp-echo-destination
-spec:
-  host: tcp-echo
-  subsets:
-  - name: v1
-    labels:
-      version: v1
-  - name: v2
-    labels:
-      version: v2
----
-apiVersion: networking.istio.io/v1alpha3
-kind: VirtualService
-metadata:
-  name: tcp-echo
-spec:
-  hosts:
-  - tcp-echo
-  tcp:
-  - route:
-    - destination:
-        host: tcp-echo
-        port:
-          number: 9000
-        subset: v1	
-`
-	Echo20v2Yaml = `
-apiVersion: networking.istio.io/v1alpha3
-kind: Gateway
-metadata:
-  name: tcp-echo-gateway
-spec:
-  selector:
-    istio: ingressgateway
-  servers:
-  - port:
-      number: 31400
-      name: tcp
-      protocol: TCP
-    hosts:
-    - "*"
----
-apiVersion: networking.istio.io/v1alpha3
-kind: DestinationRule
-metadata:
-  name: tcp-echo-destination
-spec:
-  host: tcp-echo
-  subsets:
-  - name: v1
-    labels:
-      version: v1
-  - name: v2
-    labels:
-      version: v2
----
-apiVersion: networking.istio.io/v1alpha3
-kind: VirtualService
-metadata:
-  name: tcp-echo
-spec:
-  hosts:
-  - tcp-echo
-  tcp:
-  - route:
-    - destination:
-        host: tcp-echo
-        port:
-          number: 9000
-        subset: v1
-      weight: 80
-    - destination:
-        host: tcp-echo
-        port:
-          number: 9000
-        subset: v2
-      weight: 20	
-`
-)
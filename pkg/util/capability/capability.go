@@ -0,0 +1,141 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package capability defines the capabilities that tests can require with TopLevelTest.RequiresCapability
+// and the probes that detect them. Importing the package registers the probes:
+//
+//	NewTest(t).Groups(Full).RequiresCapability(capability.LoadBalancer).Run(func(t TestHelper) { ... })
+package capability
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/cluster"
+	"github.com/maistra/maistra-test-tool/pkg/util/env"
+	"github.com/maistra/maistra-test-tool/pkg/util/ns"
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+	"github.com/maistra/maistra-test-tool/pkg/util/version"
+)
+
+const (
+	// IPv6 means that the cluster network supports IPv6
+	IPv6 test.Capability = "IPv6"
+	// LoadBalancer means that services of type LoadBalancer get an external address
+	LoadBalancer test.Capability = "LoadBalancer"
+	// TwoClusters means that a second cluster is available (KUBECONFIG2)
+	TwoClusters test.Capability = "TwoClusters"
+	// Internet means that resources on the internet (e.g. GitHub) can be downloaded
+	Internet test.Capability = "Internet"
+	// GatewayAPI means that the Gateway API CRDs are installed or can be installed for the SMCP version
+	GatewayAPI test.Capability = "GatewayAPI"
+	// ARM means that the cluster nodes use the arm64 architecture
+	ARM test.Capability = "ARM"
+)
+
+// loadBalancerProbeTimeout is how long probeLoadBalancer waits for the service to get an external address
+const loadBalancerProbeTimeout = 2 * time.Minute
+
+const loadBalancerProbeService = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: load-balancer-probe
+spec:
+  type: LoadBalancer
+  ports:
+  - name: http
+    port: 80
+`
+
+func init() {
+	test.RegisterCapability(IPv6, probeIPv6)
+	test.RegisterCapability(LoadBalancer, probeLoadBalancer)
+	test.RegisterCapability(TwoClusters, probeTwoClusters)
+	test.RegisterCapability(Internet, probeInternet)
+	test.RegisterCapability(GatewayAPI, probeGatewayAPI)
+	test.RegisterCapability(ARM, probeARM)
+}
+
+func probeIPv6(t test.TestHelper) (bool, string) {
+	if cluster.SupportsIPv6(t) {
+		return true, ""
+	}
+	return false, "the kubernetes service doesn't have the IPv6 IP family"
+}
+
+func probeLoadBalancer(t test.TestHelper) (bool, string) {
+	if arch := env.GetArch(); arch == "p" || arch == "z" {
+		return false, fmt.Sprintf("external load balancers are not supported on arch %s", arch)
+	}
+	if test.ProbeCapability(IPv6).Available {
+		return false, "external load balancers are not supported on IPv6 clusters"
+	}
+
+	// whether a platform provisions load balancers depends on its configuration (e.g. MetalLB or a cloud
+	// provider integration on OpenStack or vSphere), so we check whether a service actually gets an address
+	probeNs := ns.UniqueOutsideMesh(t, "capability-probe")
+	oc.ApplyString(t, probeNs, loadBalancerProbeService)
+	for deadline := time.Now().Add(loadBalancerProbeTimeout); time.Now().Before(deadline); time.Sleep(5 * time.Second) {
+		ingress := oc.GetJson(t, probeNs, "service", "load-balancer-probe", "{.status.loadBalancer.ingress}")
+		if ingress != "" && ingress != "[]" {
+			return true, ""
+		}
+	}
+	return false, fmt.Sprintf("a service of type LoadBalancer didn't get an external address within %v", loadBalancerProbeTimeout)
+}
+
+func probeTwoClusters(t test.TestHelper) (bool, string) {
+	count, err := cluster.ConfiguredClusterCount()
+	if err != nil {
+		return false, fmt.Sprintf("the CLUSTERS environment variable is invalid: %v", err)
+	}
+	if count < 2 {
+		return false, "neither KUBECONFIG2 nor CLUSTERS points to the kubeconfig of a second cluster"
+	}
+	return true, ""
+}
+
+// probeInternet checks whether the test runner can reach GitHub. This is independent of the test group:
+// in disconnected environments, the cluster has no internet access, but the runner often has.
+func probeInternet(t test.TestHelper) (bool, string) {
+	result := shell.RunWithTimeout(t, 30*time.Second, "curl -sS -o /dev/null --max-time 10 https://github.com")
+	if !result.Succeeded() {
+		return false, fmt.Sprintf("https://github.com is not reachable: %s", strings.TrimSpace(result.Stderr))
+	}
+	return true, ""
+}
+
+func probeGatewayAPI(t test.TestHelper) (bool, string) {
+	if oc.ResourceExists(t, "", "crd", "gateways.gateway.networking.k8s.io") {
+		return true, ""
+	}
+	if env.GetSMCPVersion().LessThan(version.SMCP_2_3) {
+		return false, "the Gateway API is supported since SMCP 2.3"
+	}
+	if status := test.ProbeCapability(Internet); !status.Available {
+		return false, "the Gateway API CRDs are not installed and can't be downloaded: " + status.Reason
+	}
+	return true, ""
+}
+
+func probeARM(t test.TestHelper) (bool, string) {
+	if env.GetArch() != "arm64" {
+		return false, fmt.Sprintf("the tests are running on arch %s (see OCP_ARCH)", env.GetArch())
+	}
+	return true, ""
+}
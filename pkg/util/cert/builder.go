@@ -0,0 +1,341 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type KeyType string
+
+const (
+	RSA     KeyType = "RSA"
+	ECDSA   KeyType = "ECDSA"
+	Ed25519 KeyType = "Ed25519"
+)
+
+// Certificate is a generated certificate with its private key and the certificates of the CAs that issued it
+type Certificate struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+	// Issuers are the CA certificates that signed Cert, starting with its direct issuer and ending with the root.
+	// They are empty for a self-signed certificate.
+	Issuers []*x509.Certificate
+}
+
+// Builder generates root CA, intermediate CA and leaf certificates. Unlike CertBuilder, it supports
+// RSA, ECDSA and Ed25519 keys, URI SANs and arbitrary validity windows.
+// Usage example: cert.NewLeaf("httpbin", ca).WithSPIFFEID("cluster.local", "foo", "httpbin").Build()
+type Builder struct {
+	keyType  KeyType
+	template *x509.Certificate
+	issuer   *Certificate
+	err      error
+}
+
+// NewRootCA returns a builder of a self-signed CA certificate
+func NewRootCA(commonName string) *Builder {
+	return newBuilder(commonName, true, nil)
+}
+
+// NewIntermediateCA returns a builder of a CA certificate signed by the issuer
+func NewIntermediateCA(commonName string, issuer *Certificate) *Builder {
+	return newBuilder(commonName, true, issuer)
+}
+
+// NewLeaf returns a builder of a client and server certificate signed by the issuer
+func NewLeaf(commonName string, issuer *Certificate) *Builder {
+	return newBuilder(commonName, false, issuer)
+}
+
+// NewCAChain returns an intermediate CA with the given number of CA levels between it and a new root CA,
+// e.g. NewCAChain(1) returns an intermediate signed directly by the root
+func NewCAChain(levels int, keyType KeyType) *Certificate {
+	ca := NewRootCA("Root CA").WithKeyType(keyType).Build()
+	for i := 1; i <= levels; i++ {
+		ca = NewIntermediateCA(fmt.Sprintf("Intermediate CA %d", i), ca).WithKeyType(keyType).Build()
+	}
+	return ca
+}
+
+func newBuilder(commonName string, isCA bool, issuer *Certificate) *Builder {
+	now := time.Now()
+	b := &Builder{
+		keyType: RSA,
+		issuer:  issuer,
+		template: &x509.Certificate{
+			Subject: pkix.Name{
+				Organization: []string{"example Inc."},
+				CommonName:   commonName,
+			},
+			NotBefore: now.Add(-time.Minute), // tolerate clock skew between the test runner and the cluster
+			NotAfter:  now.AddDate(1, 0, 0),
+		},
+	}
+	if isCA {
+		b.template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+		b.template.BasicConstraintsValid = true
+		b.template.IsCA = true
+	} else {
+		b.template.KeyUsage = x509.KeyUsageDigitalSignature
+		b.template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth}
+	}
+	return b
+}
+
+// WithKeyType sets the type of the generated key (RSA by default)
+func (b *Builder) WithKeyType(keyType KeyType) *Builder {
+	b.keyType = keyType
+	return b
+}
+
+func (b *Builder) WithOrganization(organization string) *Builder {
+	b.template.Subject.Organization = []string{organization}
+	return b
+}
+
+func (b *Builder) WithDNSNames(names ...string) *Builder {
+	b.template.DNSNames = append(b.template.DNSNames, names...)
+	return b
+}
+
+func (b *Builder) WithIPAddresses(ips ...net.IP) *Builder {
+	b.template.IPAddresses = append(b.template.IPAddresses, ips...)
+	return b
+}
+
+// WithURIs adds URI SANs (e.g. "spiffe://cluster.local/ns/foo/sa/httpbin")
+func (b *Builder) WithURIs(uris ...string) *Builder {
+	for _, uri := range uris {
+		u, err := url.Parse(uri)
+		if err != nil {
+			b.err = fmt.Errorf("invalid URI SAN %q: %v", uri, err)
+			continue
+		}
+		b.template.URIs = append(b.template.URIs, u)
+	}
+	return b
+}
+
+// WithSPIFFEID adds the SPIFFE ID of the service account, which is how Istio identifies workloads
+func (b *Builder) WithSPIFFEID(trustDomain, ns, serviceAccount string) *Builder {
+	return b.WithURIs(SPIFFEID(trustDomain, ns, serviceAccount))
+}
+
+// WithValidity sets the validity window of the certificate
+func (b *Builder) WithValidity(notBefore, notAfter time.Time) *Builder {
+	b.template.NotBefore, b.template.NotAfter = notBefore, notAfter
+	return b
+}
+
+// Expired makes the certificate expire a day before now
+func (b *Builder) Expired() *Builder {
+	now := time.Now()
+	return b.WithValidity(now.AddDate(0, 0, -2), now.AddDate(0, 0, -1))
+}
+
+// NotYetValid makes the certificate become valid a day after now
+func (b *Builder) NotYetValid() *Builder {
+	now := time.Now()
+	return b.WithValidity(now.AddDate(0, 0, 1), now.AddDate(0, 0, 2))
+}
+
+// Build generates the key and the certificate. Like CertBuilder, it panics if they can't be generated.
+func (b *Builder) Build() *Certificate {
+	if b.err != nil {
+		panic(b.err)
+	}
+	key, err := newKey(b.keyType)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to create a %s private key: %v", b.keyType, err))
+	}
+	b.template.SerialNumber, err = rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		panic("Failed to generate a serial number: " + err.Error())
+	}
+	if b.keyType == RSA && !b.template.IsCA {
+		b.template.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
+	parent, signer := b.template, key
+	var issuers []*x509.Certificate
+	if b.issuer != nil {
+		parent, signer = b.issuer.Cert, b.issuer.Key
+		issuers = append([]*x509.Certificate{b.issuer.Cert}, b.issuer.Issuers...)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, b.template, parent, key.Public(), signer)
+	if err != nil {
+		panic("Failed to create certificate: " + err.Error())
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic("Failed to parse certificate: " + err.Error())
+	}
+	return &Certificate{Cert: cert, Key: key, Issuers: issuers}
+}
+
+func newKey(keyType KeyType) (crypto.Signer, error) {
+	switch keyType {
+	case RSA:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case ECDSA:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case Ed25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", keyType)
+	}
+}
+
+// SPIFFEID returns the SPIFFE ID of the service account (e.g. "spiffe://cluster.local/ns/foo/sa/httpbin")
+func SPIFFEID(trustDomain, ns, serviceAccount string) string {
+	return fmt.Sprintf("spiffe://%s/ns/%s/sa/%s", trustDomain, ns, serviceAccount)
+}
+
+// Root returns the root CA certificate of the chain, which is the certificate itself if it's self-signed
+func (c *Certificate) Root() *x509.Certificate {
+	if len(c.Issuers) == 0 {
+		return c.Cert
+	}
+	return c.Issuers[len(c.Issuers)-1]
+}
+
+// Chain returns the certificate followed by its issuers
+func (c *Certificate) Chain() []*x509.Certificate {
+	return append([]*x509.Certificate{c.Cert}, c.Issuers...)
+}
+
+func (c *Certificate) CertPEM() []byte {
+	return encodeCerts(c.Cert)
+}
+
+// KeyPEM returns the private key in PKCS #8 format
+func (c *Certificate) KeyPEM() []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(c.Key)
+	if err != nil {
+		panic("Failed to marshal private key: " + err.Error())
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+// ChainPEM returns the certificate followed by its issuers
+func (c *Certificate) ChainPEM() []byte {
+	return encodeCerts(c.Chain()...)
+}
+
+func (c *Certificate) RootPEM() []byte {
+	return encodeCerts(c.Root())
+}
+
+func encodeCerts(certs ...*x509.Certificate) []byte {
+	var data []byte
+	for _, cert := range certs {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return data
+}
+
+// CACerts returns the files of an Istio "cacerts" secret that makes istiod sign workload certificates with this CA
+func (c *Certificate) CACerts() map[string][]byte {
+	return map[string][]byte{
+		"ca-cert.pem":    c.CertPEM(),
+		"ca-key.pem":     c.KeyPEM(),
+		"root-cert.pem":  c.RootPEM(),
+		"cert-chain.pem": c.ChainPEM(),
+	}
+}
+
+// WriteCACerts writes the CACerts files into the directory and returns them as "name=path" arguments for
+// oc.CreateGenericSecretFromFiles, in the same order as the Sample* files
+func (c *Certificate) WriteCACerts(dir string) []string {
+	var files []string
+	caCerts := c.CACerts()
+	for _, name := range []string{"ca-cert.pem", "ca-key.pem", "root-cert.pem", "cert-chain.pem"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, caCerts[name], 0o600); err != nil {
+			panic(fmt.Sprintf("Failed to write %s: %v", path, err))
+		}
+		files = append(files, name+"="+path)
+	}
+	return files
+}
+
+// NewCRL returns a PEM-encoded certificate revocation list, valid for a day, in which the CA revokes the certificates
+func (c *Certificate) NewCRL(revoked ...*Certificate) []byte {
+	now := time.Now()
+	list := &x509.RevocationList{
+		Number:     big.NewInt(now.Unix()),
+		ThisUpdate: now.Add(-time.Minute),
+		NextUpdate: now.AddDate(0, 0, 1),
+	}
+	for _, r := range revoked {
+		list.RevokedCertificateEntries = append(list.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   r.Cert.SerialNumber,
+			RevocationTime: now.Add(-time.Minute),
+		})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, list, c.Cert, c.Key)
+	if err != nil {
+		panic("Failed to create CRL: " + err.Error())
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+}
+
+// ParsePEM returns the certificates in the PEM data, skipping other blocks (e.g. keys) and any text around them,
+// such as the output of "openssl s_client -showcerts"
+func ParsePEM(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %v", err)
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// KeyTypeOf returns the type of the public key of the certificate
+func KeyTypeOf(cert *x509.Certificate) KeyType {
+	switch cert.PublicKeyAlgorithm {
+	case x509.RSA:
+		return RSA
+	case x509.ECDSA:
+		return ECDSA
+	case x509.Ed25519:
+		return Ed25519
+	default:
+		return KeyType(cert.PublicKeyAlgorithm.String())
+	}
+}
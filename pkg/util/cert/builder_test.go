@@ -0,0 +1,105 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBuilderChains(t *testing.T) {
+	for _, keyType := range []KeyType{RSA, ECDSA, Ed25519} {
+		t.Run(string(keyType), func(t *testing.T) {
+			ca := NewCAChain(2, keyType)
+			if len(ca.Issuers) != 2 || !ca.Root().IsCA || ca.Root().Subject.CommonName != "Root CA" {
+				t.Fatalf("expected an intermediate with 2 issuers, got %v", ca.Issuers)
+			}
+			leaf := NewLeaf("httpbin", ca).WithKeyType(keyType).
+				WithDNSNames("httpbin.foo.svc.cluster.local").
+				WithSPIFFEID("cluster.local", "foo", "httpbin").
+				Build()
+			if err := verify(leaf); err != nil {
+				t.Errorf("failed to verify the leaf certificate: %v", err)
+			}
+			if len(leaf.Cert.URIs) != 1 || leaf.Cert.URIs[0].String() != "spiffe://cluster.local/ns/foo/sa/httpbin" {
+				t.Errorf("unexpected URI SANs: %v", leaf.Cert.URIs)
+			}
+			if _, err := tls.X509KeyPair(leaf.ChainPEM(), leaf.KeyPEM()); err != nil {
+				t.Errorf("the chain and the key can't be used for TLS: %v", err)
+			}
+		})
+	}
+}
+
+func TestBuilderValidity(t *testing.T) {
+	ca := NewRootCA("Root CA").WithKeyType(ECDSA).Build()
+	if err := verify(NewLeaf("expired", ca).Expired().Build()); err == nil {
+		t.Error("expected the expired certificate to be rejected")
+	}
+	if err := verify(NewLeaf("future", ca).NotYetValid().Build()); err == nil {
+		t.Error("expected the certificate that isn't valid yet to be rejected")
+	}
+}
+
+func TestCACertsAndCRL(t *testing.T) {
+	ca := NewCAChain(1, RSA)
+	dir := t.TempDir()
+	files := ca.WriteCACerts(dir)
+	expected := []string{
+		"ca-cert.pem=" + filepath.Join(dir, "ca-cert.pem"),
+		"ca-key.pem=" + filepath.Join(dir, "ca-key.pem"),
+		"root-cert.pem=" + filepath.Join(dir, "root-cert.pem"),
+		"cert-chain.pem=" + filepath.Join(dir, "cert-chain.pem"),
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected files %v, got %v", expected, files)
+	}
+	chain, err := os.ReadFile(filepath.Join(dir, "cert-chain.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certs, err := ParsePEM(chain); err != nil || len(certs) != 2 || !certs[0].Equal(ca.Cert) || !certs[1].Equal(ca.Root()) {
+		t.Errorf("expected cert-chain.pem to contain the intermediate and the root")
+	}
+
+	revoked := NewLeaf("revoked", ca).Build()
+	block, _ := pem.Decode(ca.NewCRL(revoked))
+	crl, err := x509.ParseRevocationList(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := crl.CheckSignatureFrom(ca.Cert); err != nil {
+		t.Errorf("the CRL isn't signed by the CA: %v", err)
+	}
+	if len(crl.RevokedCertificateEntries) != 1 || crl.RevokedCertificateEntries[0].SerialNumber.Cmp(revoked.Cert.SerialNumber) != 0 {
+		t.Errorf("expected the CRL to revoke serial %v, got %v", revoked.Cert.SerialNumber, crl.RevokedCertificateEntries)
+	}
+}
+
+func verify(c *Certificate) error {
+	roots := x509.NewCertPool()
+	roots.AddCert(c.Root())
+	intermediates := x509.NewCertPool()
+	for _, issuer := range c.Issuers[:len(c.Issuers)-1] {
+		intermediates.AddCert(issuer)
+	}
+	_, err := c.Cert.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+	return err
+}
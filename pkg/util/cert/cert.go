@@ -107,7 +107,7 @@ func (cf *CertBuilder) newKey(bits int) error {
 	// create new private and public key
 	privKey, err := rsa.GenerateKey(rand.Reader, bits)
 	if err != nil {
-		return fmt.Errorf("Failed to create a private key:" + err.Error())
+		return fmt.Errorf("Failed to create a private key: %v", err)
 	}
 	cf.privateKey, cf.publicKey = privKey, &privKey.PublicKey
 	return nil
@@ -35,7 +35,7 @@ func TestTLSCertConfig(t *testing.T) {
 
 	serverCert, err := tls.X509KeyPair(certPEM, certPrivateKeyPEM)
 	if err != nil {
-		t.Errorf("Failed to configure server cert:" + err.Error())
+		t.Errorf("Failed to configure server cert: %v", err)
 	}
 
 	serverTLSConf := &tls.Config{
@@ -100,7 +100,7 @@ func verifyDCA(root, dca *x509.Certificate) error {
 	}
 
 	if _, err := dca.Verify(opts); err != nil {
-		return fmt.Errorf("failed to verify certificate: " + err.Error())
+		return fmt.Errorf("failed to verify certificate: %v", err)
 	}
 	fmt.Println("DCA verified")
 	return nil
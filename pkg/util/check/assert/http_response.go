@@ -25,13 +25,6 @@ import (
 	"github.com/maistra/maistra-test-tool/pkg/util/test"
 )
 
-func ResponseMatchesFile(file string, successMsg, failureMsg string, otherFiles ...string) curl.HTTPResponseCheckFunc {
-	return func(t test.TestHelper, resp *http.Response, responseBody []byte, responseErr error, duration time.Duration) {
-		t.T().Helper()
-		common.CheckResponseMatchesFile(t, resp, responseBody, file, successMsg, failureMsg, assertFailure, otherFiles...)
-	}
-}
-
 func ResponseStatus(expectedStatus int) curl.HTTPResponseCheckFunc {
 	return func(t test.TestHelper, resp *http.Response, responseBody []byte, responseErr error, duration time.Duration) {
 		t.T().Helper()
@@ -20,58 +20,11 @@ import (
 	"strings"
 	"time"
 
-	"github.com/maistra/maistra-test-tool/pkg/util"
 	"github.com/maistra/maistra-test-tool/pkg/util/test"
 )
 
 type FailureFunc func(t test.TestHelper, msg string, detailedMsg string)
 
-func CheckResponseMatchesFile(t test.TestHelper, resp *http.Response, responseBody []byte, file, successMsg, failureMsg string, failure FailureFunc, otherFiles ...string) {
-	t.T().Helper()
-	requireNonNilResponse(t, resp)
-
-	err := util.CompareHTTPResponse(responseBody, file)
-	if err == nil {
-		if successMsg == "" {
-			successMsg = fmt.Sprintf("response matches file %s", file)
-		}
-		logSuccess(t, successMsg)
-	} else {
-		var detailMsg string
-		if len(otherFiles) > 0 {
-			matchedFile := findMatchingFile(responseBody, otherFiles)
-			if matchedFile == "" {
-				detailMsg = fmt.Sprintf("expected the response to match file %q, but it didn't match that or any other file", file)
-				if !t.WillRetry() {
-					detailMsg += "\ndiff between the expected (-) and actual response (+):\n" + err.Error()
-				}
-			} else {
-				detailMsg = fmt.Sprintf("expected the response to match file %q, but it matched %q", file, matchedFile)
-			}
-		} else {
-			detailMsg = fmt.Sprintf("expected the response to match file %q, but it didn't", file)
-			if !t.WillRetry() {
-				detailMsg += "\ndiff between the expected (-) and actual response (+):\n" + err.Error()
-			}
-		}
-		failure(t, failureMsg, detailMsg)
-	}
-}
-
-func findMatchingFile(body []byte, otherFiles []string) string {
-	for _, file := range otherFiles {
-		if matchesFile(body, file) {
-			return file
-		}
-	}
-	return ""
-}
-
-func matchesFile(body []byte, file string) bool {
-	err := util.CompareHTTPResponse(body, file)
-	return err == nil
-}
-
 func CheckResponseStatus(t test.TestHelper, resp *http.Response, responseBody []byte, expectedStatus int, failure FailureFunc) {
 	t.T().Helper()
 	requireNonNilResponse(t, resp)
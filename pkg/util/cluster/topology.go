@@ -0,0 +1,255 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/cert"
+	"github.com/maistra/maistra-test-tool/pkg/util/env"
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// EastWestGatewayService is the name of the service of the east-west gateway in the Istio multi-cluster samples
+const EastWestGatewayService = "istio-eastwestgateway"
+
+// Cluster is one of the clusters of a multi-cluster test
+type Cluster struct {
+	Name       string
+	Kubeconfig string
+	OC         *oc.OC
+	// MeshNamespace is the namespace of the control plane (ISTIO_NAMESPACE by default)
+	MeshNamespace string
+	// Network is the Istio network of the cluster ("network1", "network2", etc. by default). Clusters on
+	// different networks reach each other's workloads through the east-west gateways.
+	Network string
+}
+
+// Topology contains the clusters of a multi-cluster test. The first cluster is the primary cluster.
+type Topology struct {
+	Clusters []*Cluster
+}
+
+type namedKubeconfig struct {
+	name       string
+	kubeconfig string
+}
+
+// LoadTopology returns the clusters configured in CLUSTERS or, if it's empty, the clusters "cluster1" and
+// "cluster2" from KUBECONFIG and KUBECONFIG2. The cluster of KUBECONFIG uses oc.DefaultOC.
+func LoadTopology(t test.TestHelper) *Topology {
+	t.T().Helper()
+	kubeconfigs, err := parseClusters(env.GetClusters(), env.GetKubeconfig(), env.GetKubeconfig2())
+	if err != nil {
+		t.Fatalf("invalid cluster configuration: %v", err)
+	}
+	topology := &Topology{}
+	for i, k := range kubeconfigs {
+		o := oc.DefaultOC
+		if k.kubeconfig != env.GetKubeconfig() {
+			o = oc.WithKubeconfig(k.kubeconfig)
+		}
+		topology.Clusters = append(topology.Clusters, &Cluster{
+			Name:          k.name,
+			Kubeconfig:    k.kubeconfig,
+			OC:            o,
+			MeshNamespace: env.GetIstioNamespace(),
+			Network:       fmt.Sprintf("network%d", i+1),
+		})
+	}
+	return topology
+}
+
+// ConfiguredClusterCount returns the number of clusters that LoadTopology would return
+func ConfiguredClusterCount() (int, error) {
+	kubeconfigs, err := parseClusters(env.GetClusters(), env.GetKubeconfig(), env.GetKubeconfig2())
+	return len(kubeconfigs), err
+}
+
+func parseClusters(clusters, kubeconfig, kubeconfig2 string) ([]namedKubeconfig, error) {
+	if clusters == "" {
+		kubeconfigs := []namedKubeconfig{{name: "cluster1", kubeconfig: kubeconfig}}
+		if kubeconfig2 != "" {
+			kubeconfigs = append(kubeconfigs, namedKubeconfig{name: "cluster2", kubeconfig: kubeconfig2})
+		}
+		return kubeconfigs, nil
+	}
+
+	var kubeconfigs []namedKubeconfig
+	names := map[string]bool{}
+	for _, entry := range strings.Split(clusters, ",") {
+		name, path, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || name == "" || path == "" {
+			return nil, fmt.Errorf("expected name=kubeconfig, got %q", entry)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("cluster %s is defined more than once", name)
+		}
+		names[name] = true
+		kubeconfigs = append(kubeconfigs, namedKubeconfig{name: name, kubeconfig: path})
+	}
+	return kubeconfigs, nil
+}
+
+// Require fails the test if the topology has fewer clusters than needed. Tests should also declare
+// capability.TwoClusters, so that they're skipped instead when the clusters aren't configured.
+func (tp *Topology) Require(t test.TestHelper, clusters int) {
+	t.T().Helper()
+	if len(tp.Clusters) < clusters {
+		t.Fatalf("the test requires %d clusters, but only %d are configured (see CLUSTERS)", clusters, len(tp.Clusters))
+	}
+}
+
+// Cluster returns the cluster with the name
+func (tp *Topology) Cluster(t test.TestHelper, name string) *Cluster {
+	t.T().Helper()
+	for _, c := range tp.Clusters {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("cluster %s is not configured; clusters: %v", name, tp.Names())
+	return nil
+}
+
+// Primary returns the first cluster
+func (tp *Topology) Primary() *Cluster {
+	return tp.Clusters[0]
+}
+
+// Remotes returns all clusters except the first one
+func (tp *Topology) Remotes() []*Cluster {
+	return tp.Clusters[1:]
+}
+
+func (tp *Topology) Names() []string {
+	var names []string
+	for _, c := range tp.Clusters {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// SingleCluster returns whether all clusters are in fact the same cluster (e.g. when a federation test runs
+// both meshes in one cluster), in which case services must be exposed with ClusterIP instead of LoadBalancer
+func (tp *Topology) SingleCluster() bool {
+	for _, c := range tp.Clusters {
+		if c.Kubeconfig != tp.Clusters[0].Kubeconfig {
+			return false
+		}
+	}
+	return true
+}
+
+// PlugInSharedRootCA creates a cacerts secret in the mesh namespace of each cluster with an intermediate CA
+// signed by a new shared root CA, so that the workloads of all clusters trust each other, and returns the root CA
+func (tp *Topology) PlugInSharedRootCA(t test.TestHelper) *cert.Certificate {
+	t.T().Helper()
+	root := cert.NewRootCA("Root CA").Build()
+	for _, c := range tp.Clusters {
+		dir := filepath.Join(t.T().TempDir(), c.Name)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("could not create directory for the certificates of cluster %s: %v", c.Name, err)
+		}
+		ca := cert.NewIntermediateCA(c.Name+" Intermediate CA", root).WithOrganization("Istio").Build()
+		t.Logf("Create cacerts secret with intermediate CA in namespace %s of cluster %s", c.MeshNamespace, c.Name)
+		c.OC.CreateGenericSecretFromFiles(t, c.MeshNamespace, "cacerts", ca.WriteCACerts(dir)...)
+	}
+	return root
+}
+
+// RootCerts returns the PEM root certificates of the meshes, by cluster name (see Cluster.RootCert)
+func (tp *Topology) RootCerts(t test.TestHelper) map[string]string {
+	t.T().Helper()
+	certs := map[string]string{}
+	for _, c := range tp.Clusters {
+		certs[c.Name] = c.RootCert(t)
+	}
+	return certs
+}
+
+func (tp *Topology) String() string {
+	var clusters []string
+	for _, c := range tp.Clusters {
+		clusters = append(clusters, fmt.Sprintf("%s (%s, %s)", c.Name, c.Kubeconfig, c.Network))
+	}
+	sort.Strings(clusters)
+	return strings.Join(clusters, ", ")
+}
+
+// RootCert returns the PEM root certificate that the mesh distributes to its workloads in the istio-ca-root-cert ConfigMap,
+// which the other meshes must trust (e.g. in a ServiceMeshPeer)
+func (c *Cluster) RootCert(t test.TestHelper) string {
+	t.T().Helper()
+	rootCert := c.OC.GetConfigMapData(t, c.MeshNamespace, "istio-ca-root-cert")["root-cert.pem"]
+	if rootCert == "" {
+		t.Fatalf("ConfigMap %s/istio-ca-root-cert in cluster %s doesn't contain root-cert.pem", c.MeshNamespace, c.Name)
+	}
+	return rootCert
+}
+
+// LoadBalancerAddress waits up to 10 minutes until the LoadBalancer service gets an IP or hostname and returns it
+func (c *Cluster) LoadBalancerAddress(t test.TestHelper, ns, service string) string {
+	t.T().Helper()
+	var address string
+	retryFor10Minutes := retry.Options().MaxAttempts(6 * 10).DelayBetweenAttempts(10 * time.Second)
+	retry.UntilSuccessWithOptions(t, retryFor10Minutes, func(t test.TestHelper) {
+		t.T().Helper()
+		address = c.OC.GetLoadBalancerAddress(t, ns, service)
+		if address == "" {
+			t.Fatalf("could not get ingress address from LoadBalancer service %s/%s in cluster %s", ns, service, c.Name)
+		}
+	})
+	return address
+}
+
+// EastWestGatewayAddress returns the address of the east-west gateway in the mesh namespace
+func (c *Cluster) EastWestGatewayAddress(t test.TestHelper) string {
+	t.T().Helper()
+	return c.LoadBalancerAddress(t, c.MeshNamespace, EastWestGatewayService)
+}
+
+// CheckResponses sends requests to the URL with curl from the container of the client pod in this cluster until
+// each of the expected strings has appeared in a response, e.g. the versions of a service deployed in different
+// clusters, which shows that traffic is load balanced across the clusters
+func (c *Cluster) CheckResponses(t test.TestHelper, client oc.PodLocatorFunc, container, url string, expected ...string) {
+	t.T().Helper()
+	seen := map[string]bool{}
+	retry.UntilSuccess(t, func(t test.TestHelper) {
+		t.T().Helper()
+		output := c.OC.Exec(t, client, container, fmt.Sprintf("curl -sS %s", url))
+		var missing []string
+		for _, e := range expected {
+			if strings.Contains(output, e) {
+				seen[e] = true
+			}
+			if !seen[e] {
+				missing = append(missing, e)
+			}
+		}
+		if len(missing) > 0 {
+			t.Errorf("no response from %s in cluster %s contained %v yet; last response: %s", url, c.Name, missing, output)
+			return
+		}
+		t.LogSuccess(fmt.Sprintf("responses from %s in cluster %s contained %v", url, c.Name, expected))
+	})
+}
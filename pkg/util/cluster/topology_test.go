@@ -0,0 +1,112 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/cert"
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+func TestParseClusters(t *testing.T) {
+	cases := []struct {
+		name        string
+		clusters    string
+		kubeconfig2 string
+		expected    []namedKubeconfig
+		err         bool
+	}{
+		{
+			name:     "single cluster",
+			expected: []namedKubeconfig{{"cluster1", "/kube1"}},
+		},
+		{
+			name:        "KUBECONFIG2",
+			kubeconfig2: "/kube2",
+			expected:    []namedKubeconfig{{"cluster1", "/kube1"}, {"cluster2", "/kube2"}},
+		},
+		{
+			name:        "CLUSTERS takes precedence",
+			clusters:    "east=/east, west=/west,north=/north",
+			kubeconfig2: "/kube2",
+			expected:    []namedKubeconfig{{"east", "/east"}, {"west", "/west"}, {"north", "/north"}},
+		},
+		{name: "missing kubeconfig", clusters: "east=/east,west", err: true},
+		{name: "duplicate name", clusters: "east=/east,east=/west", err: true},
+	}
+	for _, c := range cases {
+		actual, err := parseClusters(c.clusters, "/kube1", c.kubeconfig2)
+		if (err != nil) != c.err {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		if !c.err && !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestClusterHelpers(t *testing.T) {
+	th := test.NewTestHelper(t)
+	o, _ := oc.NewFakeOC(th, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: istio-system
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: istio-ca-root-cert
+  namespace: istio-system
+data:
+  root-cert.pem: ROOT
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-eastwestgateway
+  namespace: istio-system
+spec:
+  type: LoadBalancer
+status:
+  loadBalancer:
+    ingress:
+    - hostname: eastwest.example.com
+`)
+	c := &Cluster{Name: "east", OC: o, MeshNamespace: "istio-system"}
+	topology := &Topology{Clusters: []*Cluster{c}}
+
+	if rootCert := c.RootCert(th); rootCert != "ROOT" {
+		t.Errorf("expected root certificate ROOT, got %q", rootCert)
+	}
+	if address := c.EastWestGatewayAddress(th); address != "eastwest.example.com" {
+		t.Errorf("expected east-west gateway address eastwest.example.com, got %q", address)
+	}
+
+	root := topology.PlugInSharedRootCA(th)
+	data := o.GetJson(th, "istio-system", "secret", "cacerts", "{.data.root-cert\\.pem}")
+	pem, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certs, err := cert.ParsePEM(pem); err != nil || len(certs) != 1 || !certs[0].Equal(root.Cert) {
+		t.Errorf("expected the cacerts secret to contain the shared root certificate")
+	}
+}
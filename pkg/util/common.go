@@ -19,12 +19,8 @@ import (
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
-	"os"
-	"path/filepath"
 
 	"golang.org/x/net/publicsuffix"
-
-	"github.com/maistra/maistra-test-tool/pkg/util/env"
 )
 
 var (
@@ -64,15 +60,3 @@ func containsSessionCookie(cookies []*http.Cookie) bool {
 	}
 	return false
 }
-
-// CompareHTTPResponse compares a HTTP Response body with a model HTML file
-// modelFile is the file name. Not the file path.
-// resources directory is github.com/maistra/maistra-test-tool/testdata/resources
-func CompareHTTPResponse(body []byte, modelFile string) error {
-	modelPath := filepath.Join(env.GetRootDir()+"/testdata/resources/html", modelFile)
-	if err := CompareToFile(body, modelPath); err != nil {
-		os.WriteFile("/tmp/diffbody", body, 0644)
-		return err
-	}
-	return nil
-}
@@ -17,6 +17,7 @@ package env
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -34,17 +35,23 @@ func getenv(key, fallback string) string {
 	return value
 }
 
-// GetRootDir gets the project root dir from the current working directory (which is usually the current test's package dir)
+// GetRootDir gets the project root dir from the current working directory (which is usually the current test's package dir).
+// Outside of pkg/tests (e.g. in unit tests of the framework itself), the root dir is the closest parent dir containing go.mod.
 func GetRootDir() string {
 	dir, err := os.Getwd()
 	if err != nil {
 		panic(err)
 	}
 	index := strings.LastIndex(dir, "/pkg/tests/")
-	if index == -1 {
-		panic("expected working dir to be a subdir of .../pkg/tests/, but was " + dir)
+	if index != -1 {
+		return dir[:index]
 	}
-	return dir[:index]
+	for d := dir; d != filepath.Dir(d); d = filepath.Dir(d) {
+		if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil {
+			return d
+		}
+	}
+	panic("expected working dir to be a subdir of .../pkg/tests/, but was " + dir)
 }
 
 func IsRosa() bool {
@@ -63,6 +70,18 @@ func GetIstioNamespace() string {
 	return getenv("ISTIO_NAMESPACE", "istio-system")
 }
 
+// GetIstioVersion returns the version of the OSSM 3 control plane, as expected in the spec.version field
+// of the Istio resource (e.g. "v1.24-latest" or "v1.24.3")
+func GetIstioVersion() string {
+	return getenv("ISTIO_VERSION", "v1.24-latest")
+}
+
+// IsOSSM3ControlPlane returns whether ossm.DeployControlPlane deploys an OSSM 3 control plane (Istio and IstioCNI)
+// instead of an SMCP, which is selected with CONTROL_PLANE=ossm3
+func IsOSSM3ControlPlane() bool {
+	return getenv("CONTROL_PLANE", "smcp") == "ossm3"
+}
+
 func GetDefaultSMCPName() string {
 	return getenv("SMCP_NAME", "basic")
 }
@@ -95,8 +114,22 @@ func GetMustGatherTag() string {
 	return getenv("MUST_GATHER_TAG", fmt.Sprintf("%d.%d", GetOperatorVersion().Major, GetOperatorVersion().Minor))
 }
 
+// IsMustGatherEnabled returns true if a full must-gather is captured for each failed test. It takes
+// minutes and produces huge archives, so it's disabled by default in favor of IsDiagnosticsEnabled.
 func IsMustGatherEnabled() bool {
-	return getenv("MUST_GATHER", "true") == "true"
+	return getenv("MUST_GATHER", "false") == "true"
+}
+
+// IsDiagnosticsEnabled returns true if the diagnostics relevant to a failed test (events and logs from the
+// namespaces it used, proxy config of the pods it exec'd into, istiod logs, etc.) are collected into OUTPUT_DIR
+func IsDiagnosticsEnabled() bool {
+	return getenv("DIAGNOSTICS", "true") == "true"
+}
+
+// IsNativeClientEnabled returns true if the oc package should talk to the cluster through
+// client-go instead of executing the oc binary (see oc.NewNativeOC)
+func IsNativeClientEnabled() bool {
+	return getenv("NATIVE_CLIENT", "false") == "true"
 }
 
 func GetKubeconfig() string {
@@ -107,6 +140,13 @@ func GetKubeconfig2() string {
 	return getenv("KUBECONFIG2", "")
 }
 
+// GetClusters returns the named kubeconfigs of the clusters used by multi-cluster tests, as a comma-separated
+// list of name=kubeconfig pairs (e.g. "east=/path/to/east.kubeconfig,west=/path/to/west.kubeconfig").
+// If it's empty, the clusters are read from KUBECONFIG and KUBECONFIG2 (see cluster.LoadTopology).
+func GetClusters() string {
+	return getenv("CLUSTERS", "")
+}
+
 func GetOperatorNamespace() string {
 	return "openshift-operators"
 }
@@ -119,6 +159,61 @@ func GetOutputDir() string {
 	return getenv("OUTPUT_DIR", fmt.Sprintf("%s/tests/result-%s/%s", GetRootDir(), initTime.Format("20060102150405"), GetSMCPVersion()))
 }
 
+// GetReportDir returns the directory into which each test package writes its JSON report (see test.Report)
+func GetReportDir() string {
+	return getenv("REPORT_DIR", GetOutputDir()+"/reports")
+}
+
+// GetRetryStatsFile returns the JSON lines file to which retry.UntilSuccess appends the statistics of each
+// retry loop. All test packages executed against an SMCP version append to the same file.
+func GetRetryStatsFile() string {
+	return getenv("RETRY_STATS_FILE", GetOutputDir()+"/retry-stats.jsonl")
+}
+
+// GetCommandTimeout returns how long a command executed through oc.Invoke may run before it's killed
+func GetCommandTimeout() time.Duration {
+	timeout, err := time.ParseDuration(getenv("COMMAND_TIMEOUT", "10m"))
+	if err != nil {
+		panic(fmt.Sprintf("invalid COMMAND_TIMEOUT: %v", err))
+	}
+	return timeout
+}
+
+// GetRunId returns the ID of the test run, with which the objects created by tests are labeled
+// (see oc.TestRunLabel). All test packages executed by scripts/runtests.sh share the same ID.
+func GetRunId() string {
+	return getenv("RUN_ID", initTime.Format("20060102150405"))
+}
+
+// IsAutoCleanupEnabled returns true if the objects that a test creates with oc.ApplyString,
+// oc.ApplyTemplate or oc.ApplyFile are deleted when the test ends
+func IsAutoCleanupEnabled() bool {
+	return getenv("AUTO_CLEANUP", "true") == "true"
+}
+
+// GetLeakCheck returns what happens when a test leaves behind cluster-scoped objects or Istio resources
+// outside of its namespaces: "warn" logs a warning, "fail" fails the test and "off" disables the check
+func GetLeakCheck() string {
+	mode := getenv("LEAK_CHECK", "warn")
+	switch mode {
+	case "warn", "fail", "off":
+		return mode
+	}
+	panic(fmt.Sprintf("invalid LEAK_CHECK %q: must be warn, fail or off", mode))
+}
+
+// GetDriftCheck returns what happens when a test leaves the mesh modified (see oc.TakeSnapshot): "warn" logs
+// a warning, "fail" fails the test, "restore" logs a warning and restores the state that the test found
+// and "off" disables the check
+func GetDriftCheck() string {
+	mode := getenv("DRIFT_CHECK", "warn")
+	switch mode {
+	case "warn", "fail", "restore", "off":
+		return mode
+	}
+	panic(fmt.Sprintf("invalid DRIFT_CHECK %q: must be warn, fail, restore or off", mode))
+}
+
 func IsMetalLBInternalIPEnabled() bool {
 	return getenv("METALLB_INTERNAL_IP_ENABLED", "false") == "true"
 }
@@ -0,0 +1,108 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config builds Istio networking and security resources in Go instead of YAML strings, e.g.
+//
+//	oc.ApplyString(t, ns.Bookinfo, config.VirtualService("reviews").Route("v1", 50).Route("v3", 50).Manifest())
+//
+// The constructors (VirtualService, DestinationRule, etc.) return a value with the defaults of the Istio samples,
+// and its methods return a modified copy, so that a base configuration can be shared between subtests.
+// The specs are typed structs with the field names of the Istio API, which renders them with YAML.
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// NetworkingAPIVersion is the API version of the networking resources, which is served by all supported
+	// versions of OSSM 2 and 3
+	NetworkingAPIVersion = "networking.istio.io/v1beta1"
+	SecurityAPIVersion   = "security.istio.io/v1beta1"
+)
+
+// Resource is an Istio resource that renders as a YAML manifest
+type Resource interface {
+	Manifest() string
+}
+
+// YAML returns the manifests of the resources as a single YAML document stream, which can be passed to
+// oc.ApplyString and oc.DeleteFromString
+func YAML(resources ...Resource) string {
+	var manifests []string
+	for _, r := range resources {
+		manifests = append(manifests, r.Manifest())
+	}
+	return strings.Join(manifests, "---\n")
+}
+
+// Metadata is the metadata of a resource. The namespace is usually left empty and passed to oc.ApplyString instead.
+type Metadata struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// WorkloadSelector selects the pods to which a gateway or policy applies by their labels
+type WorkloadSelector struct {
+	MatchLabels map[string]string `json:"matchLabels"`
+}
+
+// Percent is a percentage between 0 and 100, e.g. of the requests that are affected by a fault
+type Percent struct {
+	Value float64 `json:"value"`
+}
+
+func manifest(apiVersion, kind string, metadata Metadata, spec any) string {
+	out, err := yaml.Marshal(map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   metadata,
+		"spec":       spec,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("could not marshal %s %s: %v", kind, metadata.Name, err))
+	}
+	return string(out)
+}
+
+// duration formats the duration in seconds (e.g. "0.5s"), which is the format that Istio accepts for all durations
+func duration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+// selector returns a selector for the labels given as "name=value"
+func selector(labels []string) *WorkloadSelector {
+	s := &WorkloadSelector{MatchLabels: map[string]string{}}
+	for _, label := range labels {
+		name, value, _ := strings.Cut(label, "=")
+		s.MatchLabels[name] = value
+	}
+	return s
+}
+
+func withLabel(labels map[string]string, name, value string) map[string]string {
+	copied := map[string]string{name: value}
+	for k, v := range labels {
+		if k != name {
+			copied[k] = v
+		}
+	}
+	return copied
+}
@@ -0,0 +1,311 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	validation "github.com/maistra/maistra-test-tool/pkg/util/manifest"
+)
+
+func TestVirtualService(t *testing.T) {
+	assertManifest(t, VirtualService("reviews").Route("v1", 50).Route("v3", 50), `
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: reviews
+spec:
+  hosts:
+  - reviews
+  http:
+  - route:
+    - destination:
+        host: reviews
+        subset: v1
+      weight: 50
+    - destination:
+        host: reviews
+        subset: v3
+      weight: 50`)
+
+	assertManifest(t, VirtualService("ratings").
+		WithHTTPRoute(HTTPRoute{}.MatchHeader("end-user", "jason").WithAbort(100, 500).To("ratings", "v1", 0)).
+		Route("v1", 0), `
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: ratings
+spec:
+  hosts:
+  - ratings
+  http:
+  - match:
+    - headers:
+        end-user:
+          exact: jason
+    fault:
+      abort:
+        percentage:
+          value: 100
+        httpStatus: 500
+    route:
+    - destination:
+        host: ratings
+        subset: v1
+  - route:
+    - destination:
+        host: ratings
+        subset: v1`)
+
+	assertManifest(t, VirtualService("reviews").
+		WithHTTPRoute(HTTPRoute{}.To("reviews", "v2", 0).WithTimeout(500*time.Millisecond).WithRetries(3, 2*time.Second, "5xx")), `
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: reviews
+spec:
+  hosts:
+  - reviews
+  http:
+  - route:
+    - destination:
+        host: reviews
+        subset: v2
+    timeout: 0.5s
+    retries:
+      attempts: 3
+      perTryTimeout: 2s
+      retryOn: 5xx`)
+
+	assertManifest(t, VirtualService("tcp-echo").WithHosts("*").WithGateways("tcp-echo-gateway").
+		TCPRoute(9000, "v1", 80).TCPRoute(9000, "v2", 20), `
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: tcp-echo
+spec:
+  hosts:
+  - "*"
+  gateways:
+  - tcp-echo-gateway
+  tcp:
+  - route:
+    - destination:
+        host: tcp-echo
+        port:
+          number: 9000
+        subset: v1
+      weight: 80
+    - destination:
+        host: tcp-echo
+        port:
+          number: 9000
+        subset: v2
+      weight: 20`)
+}
+
+func TestBuildersReturnCopies(t *testing.T) {
+	base := VirtualService("reviews").Route("v1", 50)
+	_ = base.Route("v3", 50)
+	_ = base.InNamespace("bookinfo").WithLabel("app", "reviews")
+
+	if len(base.Spec.HTTP[0].Route) != 1 || base.Metadata.Namespace != "" || base.Metadata.Labels != nil {
+		t.Errorf("expected the base VirtualService to be unchanged, got %+v", base)
+	}
+
+	dr := DestinationRule("reviews").WithTLS(ClientTLSSettings{Mode: TLSIstioMutual})
+	_ = dr.WithCircuitBreaker(1, 1, 1)
+	if dr.Spec.TrafficPolicy.ConnectionPool != nil {
+		t.Errorf("expected the base DestinationRule to be unchanged, got %+v", dr.Spec.TrafficPolicy)
+	}
+}
+
+func TestDestinationRule(t *testing.T) {
+	assertManifest(t, DestinationRule("httpbin").WithCircuitBreaker(1, 1, 1).
+		WithOutlierDetection(1, time.Second, 3*time.Minute, 100), `
+apiVersion: networking.istio.io/v1beta1
+kind: DestinationRule
+metadata:
+  name: httpbin
+spec:
+  host: httpbin
+  trafficPolicy:
+    connectionPool:
+      tcp:
+        maxConnections: 1
+      http:
+        http1MaxPendingRequests: 1
+        maxRequestsPerConnection: 1
+    outlierDetection:
+      consecutive5xxErrors: 1
+      interval: 1s
+      baseEjectionTime: 180s
+      maxEjectionPercent: 100`)
+
+	assertManifest(t, DestinationRule("tcp-echo").WithName("tcp-echo-destination").WithVersionSubsets("v1", "v2"), `
+apiVersion: networking.istio.io/v1beta1
+kind: DestinationRule
+metadata:
+  name: tcp-echo-destination
+spec:
+  host: tcp-echo
+  subsets:
+  - name: v1
+    labels:
+      version: v1
+  - name: v2
+    labels:
+      version: v2`)
+}
+
+func TestGateway(t *testing.T) {
+	assertManifest(t, Gateway("tcp-echo-gateway").WithServer(31400, "TCP", "*"), `
+apiVersion: networking.istio.io/v1beta1
+kind: Gateway
+metadata:
+  name: tcp-echo-gateway
+spec:
+  selector:
+    istio: ingressgateway
+  servers:
+  - port:
+      number: 31400
+      name: tcp-31400
+      protocol: TCP
+    hosts:
+    - "*"`)
+
+	assertManifest(t, Gateway("mygateway").WithSelector("istio=egressgateway").
+		WithTLSServer(443, ServerTLSSimple, "httpbin-credential", "httpbin.example.com"), `
+apiVersion: networking.istio.io/v1beta1
+kind: Gateway
+metadata:
+  name: mygateway
+spec:
+  selector:
+    istio: egressgateway
+  servers:
+  - port:
+      number: 443
+      name: https-443
+      protocol: HTTPS
+    hosts:
+    - httpbin.example.com
+    tls:
+      mode: SIMPLE
+      credentialName: httpbin-credential`)
+}
+
+func TestServiceEntry(t *testing.T) {
+	assertManifest(t, ServiceEntry("cnn", "edition.cnn.com").WithPort(80, "HTTP").WithPort(443, "HTTPS"), `
+apiVersion: networking.istio.io/v1beta1
+kind: ServiceEntry
+metadata:
+  name: cnn
+spec:
+  hosts:
+  - edition.cnn.com
+  ports:
+  - number: 80
+    name: http-80
+    protocol: HTTP
+  - number: 443
+    name: https-443
+    protocol: HTTPS
+  location: MESH_EXTERNAL
+  resolution: DNS`)
+}
+
+func TestSecurity(t *testing.T) {
+	assertManifest(t, AuthorizationPolicy("allow-nothing"), `
+apiVersion: security.istio.io/v1beta1
+kind: AuthorizationPolicy
+metadata:
+  name: allow-nothing
+spec: {}`)
+
+	assertManifest(t, AuthorizationPolicy("details-viewer").WithSelector("app=details").WithAction(ActionAllow).
+		WithRule(Rule{}.FromPrincipals("cluster.local/ns/bookinfo/sa/bookinfo-productpage").ToMethods("GET")), `
+apiVersion: security.istio.io/v1beta1
+kind: AuthorizationPolicy
+metadata:
+  name: details-viewer
+spec:
+  selector:
+    matchLabels:
+      app: details
+  action: ALLOW
+  rules:
+  - from:
+    - source:
+        principals: ["cluster.local/ns/bookinfo/sa/bookinfo-productpage"]
+    to:
+    - operation:
+        methods: ["GET"]`)
+
+	assertManifest(t, PeerAuthentication("default", MTLSStrict).WithSelector("app=httpbin").WithPortMode(8080, MTLSDisable), `
+apiVersion: security.istio.io/v1beta1
+kind: PeerAuthentication
+metadata:
+  name: default
+spec:
+  selector:
+    matchLabels:
+      app: httpbin
+  mtls:
+    mode: STRICT
+  portLevelMtls:
+    8080:
+      mode: DISABLE`)
+}
+
+func TestYAML(t *testing.T) {
+	out := YAML(Gateway("tcp-echo-gateway").WithServer(31400, "TCP", "*"), VirtualService("tcp-echo").TCPRoute(9000, "v1", 0))
+	docs := strings.Split(out, "---\n")
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 documents, got %d:\n%s", len(docs), out)
+	}
+	for i, kind := range []string{"Gateway", "VirtualService"} {
+		if !strings.Contains(docs[i], "kind: "+kind+"\n") {
+			t.Errorf("expected document %d to be a %s, got:\n%s", i, kind, docs[i])
+		}
+	}
+}
+
+func assertManifest(t *testing.T, resource Resource, expected string) {
+	t.Helper()
+	var actualObj, expectedObj map[string]any
+	if err := yaml.Unmarshal([]byte(resource.Manifest()), &actualObj); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal([]byte(expected), &expectedObj); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actualObj, expectedObj) {
+		t.Errorf("expected manifest\n%s\ngot\n%s", strings.TrimSpace(expected), resource.Manifest())
+	}
+	errs, skipped := validation.DefaultValidator().Validate(resource.Manifest())
+	for _, err := range errs {
+		t.Error(err)
+	}
+	if len(skipped) > 0 {
+		t.Errorf("no schema for %v", skipped)
+	}
+}
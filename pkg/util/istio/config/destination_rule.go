@@ -0,0 +1,168 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"slices"
+	"time"
+)
+
+// TLS modes of a DestinationRule
+const (
+	TLSDisable     = "DISABLE"
+	TLSSimple      = "SIMPLE"
+	TLSMutual      = "MUTUAL"
+	TLSIstioMutual = "ISTIO_MUTUAL"
+)
+
+type DestinationRuleConfig struct {
+	Metadata Metadata
+	Spec     DestinationRuleSpec
+}
+
+type DestinationRuleSpec struct {
+	Host          string         `json:"host"`
+	TrafficPolicy *TrafficPolicy `json:"trafficPolicy,omitempty"`
+	Subsets       []Subset       `json:"subsets,omitempty"`
+}
+
+type TrafficPolicy struct {
+	ConnectionPool   *ConnectionPoolSettings `json:"connectionPool,omitempty"`
+	OutlierDetection *OutlierDetection       `json:"outlierDetection,omitempty"`
+	TLS              *ClientTLSSettings      `json:"tls,omitempty"`
+}
+
+type ConnectionPoolSettings struct {
+	TCP  *TCPSettings  `json:"tcp,omitempty"`
+	HTTP *HTTPSettings `json:"http,omitempty"`
+}
+
+type TCPSettings struct {
+	MaxConnections int `json:"maxConnections,omitempty"`
+}
+
+type HTTPSettings struct {
+	HTTP1MaxPendingRequests  int `json:"http1MaxPendingRequests,omitempty"`
+	MaxRequestsPerConnection int `json:"maxRequestsPerConnection,omitempty"`
+}
+
+type OutlierDetection struct {
+	Consecutive5xxErrors int    `json:"consecutive5xxErrors,omitempty"`
+	Interval             string `json:"interval,omitempty"`
+	BaseEjectionTime     string `json:"baseEjectionTime,omitempty"`
+	MaxEjectionPercent   int    `json:"maxEjectionPercent,omitempty"`
+}
+
+type ClientTLSSettings struct {
+	Mode              string `json:"mode"`
+	CredentialName    string `json:"credentialName,omitempty"`
+	SNI               string `json:"sni,omitempty"`
+	ClientCertificate string `json:"clientCertificate,omitempty"`
+	PrivateKey        string `json:"privateKey,omitempty"`
+	CACertificates    string `json:"caCertificates,omitempty"`
+}
+
+type Subset struct {
+	Name          string            `json:"name"`
+	Labels        map[string]string `json:"labels"`
+	TrafficPolicy *TrafficPolicy    `json:"trafficPolicy,omitempty"`
+}
+
+// DestinationRule returns a DestinationRule for the host, which is also its name
+func DestinationRule(host string) DestinationRuleConfig {
+	return DestinationRuleConfig{
+		Metadata: Metadata{Name: host},
+		Spec:     DestinationRuleSpec{Host: host},
+	}
+}
+
+func (d DestinationRuleConfig) WithName(name string) DestinationRuleConfig {
+	d.Metadata.Name = name
+	return d
+}
+
+func (d DestinationRuleConfig) InNamespace(ns string) DestinationRuleConfig {
+	d.Metadata.Namespace = ns
+	return d
+}
+
+func (d DestinationRuleConfig) WithLabel(name, value string) DestinationRuleConfig {
+	d.Metadata.Labels = withLabel(d.Metadata.Labels, name, value)
+	return d
+}
+
+// WithVersionSubsets adds a subset for each version, which selects the pods with the label version=<version>,
+// like the destination rules of the bookinfo sample
+func (d DestinationRuleConfig) WithVersionSubsets(versions ...string) DestinationRuleConfig {
+	subsets := slices.Clone(d.Spec.Subsets)
+	for _, version := range versions {
+		subsets = append(subsets, Subset{Name: version, Labels: map[string]string{"version": version}})
+	}
+	d.Spec.Subsets = subsets
+	return d
+}
+
+func (d DestinationRuleConfig) WithSubset(subset Subset) DestinationRuleConfig {
+	d.Spec.Subsets = append(slices.Clone(d.Spec.Subsets), subset)
+	return d
+}
+
+// WithTLS sets the TLS settings of the client, e.g. ClientTLSSettings{Mode: TLSIstioMutual}
+func (d DestinationRuleConfig) WithTLS(tls ClientTLSSettings) DestinationRuleConfig {
+	policy := d.trafficPolicy()
+	policy.TLS = &tls
+	d.Spec.TrafficPolicy = policy
+	return d
+}
+
+// WithCircuitBreaker limits the connections and requests to the host, as in the circuit breaking task.
+// Limits of 0 are omitted.
+func (d DestinationRuleConfig) WithCircuitBreaker(maxConnections, maxPendingRequests, maxRequestsPerConnection int) DestinationRuleConfig {
+	policy := d.trafficPolicy()
+	policy.ConnectionPool = &ConnectionPoolSettings{
+		TCP: &TCPSettings{MaxConnections: maxConnections},
+		HTTP: &HTTPSettings{
+			HTTP1MaxPendingRequests:  maxPendingRequests,
+			MaxRequestsPerConnection: maxRequestsPerConnection,
+		},
+	}
+	d.Spec.TrafficPolicy = policy
+	return d
+}
+
+// WithOutlierDetection ejects a host for the ejection time after the number of consecutive 5xx errors
+func (d DestinationRuleConfig) WithOutlierDetection(consecutive5xxErrors int, interval, baseEjectionTime time.Duration, maxEjectionPercent int) DestinationRuleConfig {
+	policy := d.trafficPolicy()
+	policy.OutlierDetection = &OutlierDetection{
+		Consecutive5xxErrors: consecutive5xxErrors,
+		Interval:             duration(interval),
+		BaseEjectionTime:     duration(baseEjectionTime),
+		MaxEjectionPercent:   maxEjectionPercent,
+	}
+	d.Spec.TrafficPolicy = policy
+	return d
+}
+
+func (d DestinationRuleConfig) trafficPolicy() *TrafficPolicy {
+	if d.Spec.TrafficPolicy == nil {
+		return &TrafficPolicy{}
+	}
+	policy := *d.Spec.TrafficPolicy
+	return &policy
+}
+
+func (d DestinationRuleConfig) Manifest() string {
+	return manifest(NetworkingAPIVersion, "DestinationRule", d.Metadata, d.Spec)
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// TLS modes of a Gateway server
+const (
+	ServerTLSPassthrough     = "PASSTHROUGH"
+	ServerTLSSimple          = "SIMPLE"
+	ServerTLSMutual          = "MUTUAL"
+	ServerTLSIstioMutual     = "ISTIO_MUTUAL"
+	ServerTLSAutoPassthrough = "AUTO_PASSTHROUGH"
+)
+
+type GatewayConfig struct {
+	Metadata Metadata
+	Spec     GatewaySpec
+}
+
+type GatewaySpec struct {
+	Selector map[string]string `json:"selector"`
+	Servers  []Server          `json:"servers"`
+}
+
+type Server struct {
+	Port  ServerPort       `json:"port"`
+	Hosts []string         `json:"hosts"`
+	TLS   *ServerTLSConfig `json:"tls,omitempty"`
+}
+
+type ServerPort struct {
+	Number   int    `json:"number"`
+	Name     string `json:"name"`
+	Protocol string `json:"protocol"`
+}
+
+type ServerTLSConfig struct {
+	Mode           string `json:"mode"`
+	CredentialName string `json:"credentialName,omitempty"`
+	HTTPSRedirect  bool   `json:"httpsRedirect,omitempty"`
+}
+
+// Gateway returns a Gateway for the default ingress gateway (istio=ingressgateway) without servers
+func Gateway(name string) GatewayConfig {
+	return GatewayConfig{
+		Metadata: Metadata{Name: name},
+		Spec:     GatewaySpec{Selector: map[string]string{"istio": "ingressgateway"}},
+	}
+}
+
+func (g GatewayConfig) InNamespace(ns string) GatewayConfig {
+	g.Metadata.Namespace = ns
+	return g
+}
+
+func (g GatewayConfig) WithLabel(name, value string) GatewayConfig {
+	g.Metadata.Labels = withLabel(g.Metadata.Labels, name, value)
+	return g
+}
+
+// WithSelector replaces the selector of the gateway pods with the labels given as "name=value",
+// e.g. "istio=egressgateway"
+func (g GatewayConfig) WithSelector(labels ...string) GatewayConfig {
+	g.Spec.Selector = selector(labels).MatchLabels
+	return g
+}
+
+// WithServer adds a server that accepts the protocol (e.g. "HTTP" or "TCP") on the port for the hosts.
+// The port is named after the protocol and the number, e.g. "http-80".
+func (g GatewayConfig) WithServer(port int, protocol string, hosts ...string) GatewayConfig {
+	return g.withServer(Server{Port: serverPort(port, protocol), Hosts: hosts})
+}
+
+// WithTLSServer adds an HTTPS server that terminates TLS with the certificate in the secret (credentialName)
+// or, in mode ServerTLSPassthrough, a TLS server that routes by SNI without terminating TLS
+func (g GatewayConfig) WithTLSServer(port int, mode, credentialName string, hosts ...string) GatewayConfig {
+	protocol := "HTTPS"
+	if mode == ServerTLSPassthrough || mode == ServerTLSAutoPassthrough {
+		protocol = "TLS"
+	}
+	return g.withServer(Server{
+		Port:  serverPort(port, protocol),
+		Hosts: hosts,
+		TLS:   &ServerTLSConfig{Mode: mode, CredentialName: credentialName},
+	})
+}
+
+func (g GatewayConfig) withServer(server Server) GatewayConfig {
+	g.Spec.Servers = append(slices.Clone(g.Spec.Servers), server)
+	return g
+}
+
+func (g GatewayConfig) Manifest() string {
+	return manifest(NetworkingAPIVersion, "Gateway", g.Metadata, g.Spec)
+}
+
+func serverPort(number int, protocol string) ServerPort {
+	return ServerPort{Number: number, Name: fmt.Sprintf("%s-%d", strings.ToLower(protocol), number), Protocol: protocol}
+}
@@ -0,0 +1,227 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"slices"
+)
+
+// mTLS modes of a PeerAuthentication
+const (
+	MTLSStrict     = "STRICT"
+	MTLSPermissive = "PERMISSIVE"
+	MTLSDisable    = "DISABLE"
+)
+
+// Actions of an AuthorizationPolicy
+const (
+	ActionAllow  = "ALLOW"
+	ActionDeny   = "DENY"
+	ActionAudit  = "AUDIT"
+	ActionCustom = "CUSTOM"
+)
+
+type PeerAuthenticationConfig struct {
+	Metadata Metadata
+	Spec     PeerAuthenticationSpec
+}
+
+type PeerAuthenticationSpec struct {
+	Selector      *WorkloadSelector `json:"selector,omitempty"`
+	MTLS          *MutualTLS        `json:"mtls,omitempty"`
+	PortLevelMTLS map[int]MutualTLS `json:"portLevelMtls,omitempty"`
+}
+
+type MutualTLS struct {
+	Mode string `json:"mode"`
+}
+
+// PeerAuthentication returns a PeerAuthentication with the mTLS mode for all workloads in its namespace, or for the
+// whole mesh if it's applied in the control plane namespace. PeerAuthentication("default", MTLSStrict) enforces mTLS.
+func PeerAuthentication(name, mode string) PeerAuthenticationConfig {
+	return PeerAuthenticationConfig{
+		Metadata: Metadata{Name: name},
+		Spec:     PeerAuthenticationSpec{MTLS: &MutualTLS{Mode: mode}},
+	}
+}
+
+func (p PeerAuthenticationConfig) InNamespace(ns string) PeerAuthenticationConfig {
+	p.Metadata.Namespace = ns
+	return p
+}
+
+func (p PeerAuthenticationConfig) WithLabel(name, value string) PeerAuthenticationConfig {
+	p.Metadata.Labels = withLabel(p.Metadata.Labels, name, value)
+	return p
+}
+
+// WithSelector restricts the policy to the workloads with the labels given as "name=value", e.g. "app=httpbin"
+func (p PeerAuthenticationConfig) WithSelector(labels ...string) PeerAuthenticationConfig {
+	p.Spec.Selector = selector(labels)
+	return p
+}
+
+// WithPortMode overrides the mTLS mode for a port of the selected workloads (see WithSelector)
+func (p PeerAuthenticationConfig) WithPortMode(port int, mode string) PeerAuthenticationConfig {
+	ports := map[int]MutualTLS{port: {Mode: mode}}
+	for k, v := range p.Spec.PortLevelMTLS {
+		if k != port {
+			ports[k] = v
+		}
+	}
+	p.Spec.PortLevelMTLS = ports
+	return p
+}
+
+func (p PeerAuthenticationConfig) Manifest() string {
+	return manifest(SecurityAPIVersion, "PeerAuthentication", p.Metadata, p.Spec)
+}
+
+type AuthorizationPolicyConfig struct {
+	Metadata Metadata
+	Spec     AuthorizationPolicySpec
+}
+
+type AuthorizationPolicySpec struct {
+	Selector *WorkloadSelector `json:"selector,omitempty"`
+	Action   string            `json:"action,omitempty"`
+	Rules    []Rule            `json:"rules,omitempty"`
+}
+
+// Rule matches requests from any of the sources (From), to any of the operations (To) and with all the conditions
+// (When). An empty rule matches all requests. The methods return a modified copy, so that a rule can be built with
+// e.g. Rule{}.FromPrincipals("cluster.local/ns/bookinfo/sa/bookinfo-productpage").ToMethods("GET").
+type Rule struct {
+	From []RuleFrom  `json:"from,omitempty"`
+	To   []RuleTo    `json:"to,omitempty"`
+	When []Condition `json:"when,omitempty"`
+}
+
+type RuleFrom struct {
+	Source Source `json:"source"`
+}
+
+type Source struct {
+	Principals        []string `json:"principals,omitempty"`
+	NotPrincipals     []string `json:"notPrincipals,omitempty"`
+	RequestPrincipals []string `json:"requestPrincipals,omitempty"`
+	Namespaces        []string `json:"namespaces,omitempty"`
+	NotNamespaces     []string `json:"notNamespaces,omitempty"`
+	IPBlocks          []string `json:"ipBlocks,omitempty"`
+	RemoteIPBlocks    []string `json:"remoteIpBlocks,omitempty"`
+}
+
+type RuleTo struct {
+	Operation Operation `json:"operation"`
+}
+
+type Operation struct {
+	Hosts    []string `json:"hosts,omitempty"`
+	Ports    []string `json:"ports,omitempty"`
+	Methods  []string `json:"methods,omitempty"`
+	Paths    []string `json:"paths,omitempty"`
+	NotPaths []string `json:"notPaths,omitempty"`
+}
+
+type Condition struct {
+	Key       string   `json:"key"`
+	Values    []string `json:"values,omitempty"`
+	NotValues []string `json:"notValues,omitempty"`
+}
+
+// AuthorizationPolicy returns an AuthorizationPolicy without selector, action and rules, which denies all requests
+// to the workloads in its namespace (the "allow-nothing" policy). Add rules to allow requests.
+func AuthorizationPolicy(name string) AuthorizationPolicyConfig {
+	return AuthorizationPolicyConfig{Metadata: Metadata{Name: name}}
+}
+
+func (a AuthorizationPolicyConfig) InNamespace(ns string) AuthorizationPolicyConfig {
+	a.Metadata.Namespace = ns
+	return a
+}
+
+func (a AuthorizationPolicyConfig) WithLabel(name, value string) AuthorizationPolicyConfig {
+	a.Metadata.Labels = withLabel(a.Metadata.Labels, name, value)
+	return a
+}
+
+// WithSelector restricts the policy to the workloads with the labels given as "name=value", e.g. "app=productpage"
+func (a AuthorizationPolicyConfig) WithSelector(labels ...string) AuthorizationPolicyConfig {
+	a.Spec.Selector = selector(labels)
+	return a
+}
+
+// WithAction sets the action (e.g. ActionDeny), which is ALLOW if it's not set
+func (a AuthorizationPolicyConfig) WithAction(action string) AuthorizationPolicyConfig {
+	a.Spec.Action = action
+	return a
+}
+
+// WithRule adds a rule. The action applies to a request if it matches any of the rules.
+func (a AuthorizationPolicyConfig) WithRule(rule Rule) AuthorizationPolicyConfig {
+	a.Spec.Rules = append(slices.Clone(a.Spec.Rules), rule)
+	return a
+}
+
+func (a AuthorizationPolicyConfig) Manifest() string {
+	return manifest(SecurityAPIVersion, "AuthorizationPolicy", a.Metadata, a.Spec)
+}
+
+// FromPrincipals matches requests from workloads with the identities, e.g. "cluster.local/ns/foo/sa/sleep"
+func (r Rule) FromPrincipals(principals ...string) Rule {
+	return r.from(Source{Principals: principals})
+}
+
+func (r Rule) FromNamespaces(namespaces ...string) Rule {
+	return r.from(Source{Namespaces: namespaces})
+}
+
+// FromRequestPrincipals matches requests with a JWT of the identities ("<issuer>/<subject>", or "*" for any valid JWT)
+func (r Rule) FromRequestPrincipals(principals ...string) Rule {
+	return r.from(Source{RequestPrincipals: principals})
+}
+
+func (r Rule) from(source Source) Rule {
+	r.From = append(slices.Clone(r.From), RuleFrom{Source: source})
+	return r
+}
+
+func (r Rule) ToMethods(methods ...string) Rule {
+	return r.to(Operation{Methods: methods})
+}
+
+func (r Rule) ToPaths(paths ...string) Rule {
+	return r.to(Operation{Paths: paths})
+}
+
+func (r Rule) ToPorts(ports ...string) Rule {
+	return r.to(Operation{Ports: ports})
+}
+
+// ToOperation matches requests to the operation, e.g. to a combination of methods and paths
+func (r Rule) ToOperation(operation Operation) Rule {
+	return r.to(operation)
+}
+
+func (r Rule) to(operation Operation) Rule {
+	r.To = append(slices.Clone(r.To), RuleTo{Operation: operation})
+	return r
+}
+
+// WithCondition matches requests for which the attribute (e.g. "request.headers[version]") has one of the values
+func (r Rule) WithCondition(key string, values ...string) Rule {
+	r.When = append(slices.Clone(r.When), Condition{Key: key, Values: values})
+	return r
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"slices"
+)
+
+// Locations and resolutions of a ServiceEntry
+const (
+	MeshExternal = "MESH_EXTERNAL"
+	MeshInternal = "MESH_INTERNAL"
+
+	ResolutionNone   = "NONE"
+	ResolutionStatic = "STATIC"
+	ResolutionDNS    = "DNS"
+)
+
+type ServiceEntryConfig struct {
+	Metadata Metadata
+	Spec     ServiceEntrySpec
+}
+
+type ServiceEntrySpec struct {
+	Hosts      []string      `json:"hosts"`
+	Addresses  []string      `json:"addresses,omitempty"`
+	Ports      []ServicePort `json:"ports,omitempty"`
+	Location   string        `json:"location,omitempty"`
+	Resolution string        `json:"resolution"`
+	Endpoints  []Endpoint    `json:"endpoints,omitempty"`
+}
+
+type ServicePort struct {
+	Number     int    `json:"number"`
+	Protocol   string `json:"protocol"`
+	Name       string `json:"name"`
+	TargetPort int    `json:"targetPort,omitempty"`
+}
+
+type Endpoint struct {
+	Address string         `json:"address"`
+	Ports   map[string]int `json:"ports,omitempty"`
+}
+
+// ServiceEntry returns a ServiceEntry that adds the external hosts, resolved with DNS, to the service registry
+func ServiceEntry(name string, hosts ...string) ServiceEntryConfig {
+	return ServiceEntryConfig{
+		Metadata: Metadata{Name: name},
+		Spec: ServiceEntrySpec{
+			Hosts:      hosts,
+			Location:   MeshExternal,
+			Resolution: ResolutionDNS,
+		},
+	}
+}
+
+func (s ServiceEntryConfig) InNamespace(ns string) ServiceEntryConfig {
+	s.Metadata.Namespace = ns
+	return s
+}
+
+func (s ServiceEntryConfig) WithLabel(name, value string) ServiceEntryConfig {
+	s.Metadata.Labels = withLabel(s.Metadata.Labels, name, value)
+	return s
+}
+
+// WithPort adds a port with the protocol (e.g. "HTTP" or "TLS"), which is named like the ports of a Gateway
+func (s ServiceEntryConfig) WithPort(number int, protocol string) ServiceEntryConfig {
+	port := serverPort(number, protocol)
+	s.Spec.Ports = append(slices.Clone(s.Spec.Ports), ServicePort{Number: port.Number, Protocol: port.Protocol, Name: port.Name})
+	return s
+}
+
+func (s ServiceEntryConfig) WithAddresses(addresses ...string) ServiceEntryConfig {
+	s.Spec.Addresses = addresses
+	return s
+}
+
+func (s ServiceEntryConfig) WithLocation(location string) ServiceEntryConfig {
+	s.Spec.Location = location
+	return s
+}
+
+func (s ServiceEntryConfig) WithResolution(resolution string) ServiceEntryConfig {
+	s.Spec.Resolution = resolution
+	return s
+}
+
+// WithEndpoint adds an endpoint, which is required with ResolutionStatic
+func (s ServiceEntryConfig) WithEndpoint(address string) ServiceEntryConfig {
+	s.Spec.Endpoints = append(slices.Clone(s.Spec.Endpoints), Endpoint{Address: address})
+	return s
+}
+
+func (s ServiceEntryConfig) Manifest() string {
+	return manifest(NetworkingAPIVersion, "ServiceEntry", s.Metadata, s.Spec)
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"slices"
+	"time"
+)
+
+type VirtualServiceConfig struct {
+	Metadata Metadata
+	Spec     VirtualServiceSpec
+
+	// host is the destination of Route and TCPRoute, which stays the same when the hosts are replaced
+	host string
+}
+
+type VirtualServiceSpec struct {
+	Hosts    []string    `json:"hosts"`
+	Gateways []string    `json:"gateways,omitempty"`
+	HTTP     []HTTPRoute `json:"http,omitempty"`
+	TCP      []TCPRoute  `json:"tcp,omitempty"`
+}
+
+// HTTPRoute is a rule for HTTP traffic. The methods return a modified copy, so that a route can be built with
+// e.g. HTTPRoute{}.MatchHeader("end-user", "jason").WithAbort(100, 500).To("ratings", "v1", 0).
+type HTTPRoute struct {
+	Match            []HTTPMatchRequest `json:"match,omitempty"`
+	Route            []RouteDestination `json:"route,omitempty"`
+	Fault            *HTTPFault         `json:"fault,omitempty"`
+	Timeout          string             `json:"timeout,omitempty"`
+	Retries          *HTTPRetry         `json:"retries,omitempty"`
+	Mirror           *Destination       `json:"mirror,omitempty"`
+	MirrorPercentage *Percent           `json:"mirrorPercentage,omitempty"`
+}
+
+type HTTPMatchRequest struct {
+	Headers map[string]StringMatch `json:"headers,omitempty"`
+	URI     *StringMatch           `json:"uri,omitempty"`
+	Port    int                    `json:"port,omitempty"`
+}
+
+// StringMatch matches a string exactly, by prefix or by a regular expression (only one may be set)
+type StringMatch struct {
+	Exact  string `json:"exact,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+	Regex  string `json:"regex,omitempty"`
+}
+
+type RouteDestination struct {
+	Destination Destination `json:"destination"`
+	// Weight is the share of the traffic in percent; it may be omitted if there's only one destination
+	Weight int `json:"weight,omitempty"`
+}
+
+type Destination struct {
+	Host   string        `json:"host"`
+	Subset string        `json:"subset,omitempty"`
+	Port   *PortSelector `json:"port,omitempty"`
+}
+
+type PortSelector struct {
+	Number int `json:"number"`
+}
+
+type HTTPFault struct {
+	Delay *HTTPFaultDelay `json:"delay,omitempty"`
+	Abort *HTTPFaultAbort `json:"abort,omitempty"`
+}
+
+type HTTPFaultDelay struct {
+	Percentage Percent `json:"percentage"`
+	FixedDelay string  `json:"fixedDelay"`
+}
+
+type HTTPFaultAbort struct {
+	Percentage Percent `json:"percentage"`
+	HTTPStatus int     `json:"httpStatus"`
+}
+
+type HTTPRetry struct {
+	Attempts      int    `json:"attempts"`
+	PerTryTimeout string `json:"perTryTimeout,omitempty"`
+	RetryOn       string `json:"retryOn,omitempty"`
+}
+
+type TCPRoute struct {
+	Match []L4MatchAttributes `json:"match,omitempty"`
+	Route []RouteDestination  `json:"route"`
+}
+
+type L4MatchAttributes struct {
+	Port int `json:"port,omitempty"`
+}
+
+// VirtualService returns a VirtualService for the host, which is also its name
+func VirtualService(host string) VirtualServiceConfig {
+	return VirtualServiceConfig{
+		Metadata: Metadata{Name: host},
+		Spec:     VirtualServiceSpec{Hosts: []string{host}},
+		host:     host,
+	}
+}
+
+func (v VirtualServiceConfig) WithName(name string) VirtualServiceConfig {
+	v.Metadata.Name = name
+	return v
+}
+
+func (v VirtualServiceConfig) InNamespace(ns string) VirtualServiceConfig {
+	v.Metadata.Namespace = ns
+	return v
+}
+
+func (v VirtualServiceConfig) WithLabel(name, value string) VirtualServiceConfig {
+	v.Metadata.Labels = withLabel(v.Metadata.Labels, name, value)
+	return v
+}
+
+// WithHosts replaces the hosts, e.g. with "*" for a VirtualService that is bound to a gateway. Route and TCPRoute
+// still route to the host passed to VirtualService.
+func (v VirtualServiceConfig) WithHosts(hosts ...string) VirtualServiceConfig {
+	v.Spec.Hosts = hosts
+	return v
+}
+
+func (v VirtualServiceConfig) WithGateways(gateways ...string) VirtualServiceConfig {
+	v.Spec.Gateways = gateways
+	return v
+}
+
+// WithHTTPRoute appends the route. Istio applies the first route that matches a request, so routes with
+// matches must be added before the default route (see Route).
+func (v VirtualServiceConfig) WithHTTPRoute(route HTTPRoute) VirtualServiceConfig {
+	v.Spec.HTTP = append(slices.Clone(v.Spec.HTTP), route)
+	return v
+}
+
+// Route sends the weight (in percent) of the requests that aren't matched by another route to the subset of the
+// host passed to VirtualService. A weight of 0 omits the weight, which sends all requests to the subset if it's the only destination.
+func (v VirtualServiceConfig) Route(subset string, weight int) VirtualServiceConfig {
+	routes := slices.Clone(v.Spec.HTTP)
+	if len(routes) == 0 || len(routes[len(routes)-1].Match) > 0 {
+		routes = append(routes, HTTPRoute{})
+	}
+	routes[len(routes)-1] = routes[len(routes)-1].To(v.host, subset, weight)
+	v.Spec.HTTP = routes
+	return v
+}
+
+// TCPRoute sends the weight (in percent) of the TCP connections to the port of the subset of the host passed to
+// VirtualService
+func (v VirtualServiceConfig) TCPRoute(port int, subset string, weight int) VirtualServiceConfig {
+	routes := slices.Clone(v.Spec.TCP)
+	if len(routes) == 0 {
+		routes = append(routes, TCPRoute{})
+	}
+	last := &routes[len(routes)-1]
+	last.Route = append(slices.Clone(last.Route), RouteDestination{
+		Destination: Destination{Host: v.host, Subset: subset, Port: &PortSelector{Number: port}},
+		Weight:      weight,
+	})
+	v.Spec.TCP = routes
+	return v
+}
+
+func (v VirtualServiceConfig) Manifest() string {
+	return manifest(NetworkingAPIVersion, "VirtualService", v.Metadata, v.Spec)
+}
+
+// MatchHeader restricts the route to requests with the header value, e.g. ("end-user", "jason") for the user
+// that is signed in to bookinfo
+func (r HTTPRoute) MatchHeader(name, value string) HTTPRoute {
+	r.Match = append(slices.Clone(r.Match), HTTPMatchRequest{Headers: map[string]StringMatch{name: {Exact: value}}})
+	return r
+}
+
+// MatchURIPrefix restricts the route to requests whose path starts with the prefix
+func (r HTTPRoute) MatchURIPrefix(prefix string) HTTPRoute {
+	r.Match = append(slices.Clone(r.Match), HTTPMatchRequest{URI: &StringMatch{Prefix: prefix}})
+	return r
+}
+
+// To adds a destination with the weight (in percent, or 0 to omit it) to the route. The subset may be empty.
+func (r HTTPRoute) To(host, subset string, weight int) HTTPRoute {
+	r.Route = append(slices.Clone(r.Route), RouteDestination{Destination: Destination{Host: host, Subset: subset}, Weight: weight})
+	return r
+}
+
+func (r HTTPRoute) WithTimeout(timeout time.Duration) HTTPRoute {
+	r.Timeout = duration(timeout)
+	return r
+}
+
+// WithRetries retries failed requests up to the number of attempts. The per-try timeout and the retry
+// conditions (e.g. "5xx,gateway-error") are omitted if they're empty.
+func (r HTTPRoute) WithRetries(attempts int, perTryTimeout time.Duration, retryOn string) HTTPRoute {
+	r.Retries = &HTTPRetry{Attempts: attempts, RetryOn: retryOn}
+	if perTryTimeout > 0 {
+		r.Retries.PerTryTimeout = duration(perTryTimeout)
+	}
+	return r
+}
+
+// WithDelay delays the percentage of the requests by the delay
+func (r HTTPRoute) WithDelay(percent float64, delay time.Duration) HTTPRoute {
+	r.Fault = r.fault()
+	r.Fault.Delay = &HTTPFaultDelay{Percentage: Percent{Value: percent}, FixedDelay: duration(delay)}
+	return r
+}
+
+// WithAbort aborts the percentage of the requests with the HTTP status
+func (r HTTPRoute) WithAbort(percent float64, httpStatus int) HTTPRoute {
+	r.Fault = r.fault()
+	r.Fault.Abort = &HTTPFaultAbort{Percentage: Percent{Value: percent}, HTTPStatus: httpStatus}
+	return r
+}
+
+func (r HTTPRoute) fault() *HTTPFault {
+	if r.Fault == nil {
+		return &HTTPFault{}
+	}
+	fault := *r.Fault
+	return &fault
+}
+
+// MirrorTo mirrors the percentage of the requests to the subset of the host
+func (r HTTPRoute) MirrorTo(host, subset string, percent float64) HTTPRoute {
+	r.Mirror = &Destination{Host: host, Subset: subset}
+	r.MirrorPercentage = &Percent{Value: percent}
+	return r
+}
@@ -0,0 +1,517 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/check/common"
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// EnvoyAdmin queries the Envoy admin API of the istio-proxy container of a sidecar or gateway pod.
+// The requests are sent with pilot-agent, so they also work with proxy images that don't contain curl.
+type EnvoyAdmin struct {
+	oc         *oc.OC
+	podLocator oc.PodLocatorFunc
+}
+
+func NewEnvoyAdmin(oc *oc.OC, podLocator oc.PodLocatorFunc) EnvoyAdmin {
+	return EnvoyAdmin{oc: oc, podLocator: podLocator}
+}
+
+// Get returns the raw response of the admin API endpoint (e.g. "clusters" or "stats?filter=http")
+func (a EnvoyAdmin) Get(t test.TestHelper, path string, checks ...common.CheckFunc) string {
+	t.T().Helper()
+	return a.oc.Exec(t, a.podLocator, "istio-proxy", fmt.Sprintf("pilot-agent request GET '%s'", path), checks...)
+}
+
+func (a EnvoyAdmin) getJson(t test.TestHelper, path string, v interface{}) {
+	t.T().Helper()
+	output := a.Get(t, path)
+	if err := json.Unmarshal([]byte(output), v); err != nil {
+		t.Fatalf("could not decode the response of the Envoy admin endpoint %s: %v", path, err)
+	}
+}
+
+// ConfigDump returns the listeners, routes, clusters and secrets that the proxy received
+func (a EnvoyAdmin) ConfigDump(t test.TestHelper) *ConfigDump {
+	t.T().Helper()
+	dump, err := ParseConfigDump([]byte(a.Get(t, "config_dump")))
+	if err != nil {
+		t.Fatalf("could not decode the Envoy config dump: %v", err)
+	}
+	return dump
+}
+
+// Clusters returns the clusters with the status of their endpoints
+func (a EnvoyAdmin) Clusters(t test.TestHelper) []ClusterStatus {
+	t.T().Helper()
+	var clusters struct {
+		ClusterStatuses []ClusterStatus `json:"cluster_statuses"`
+	}
+	a.getJson(t, "clusters?format=json", &clusters)
+	return clusters.ClusterStatuses
+}
+
+func (a EnvoyAdmin) ServerInfo(t test.TestHelper) ServerInfo {
+	t.T().Helper()
+	var info ServerInfo
+	a.getJson(t, "server_info", &info)
+	return info
+}
+
+// Stats returns the values of the counters and gauges whose names match the regular expression filter
+// (all of them if the filter is empty). Histograms are omitted.
+func (a EnvoyAdmin) Stats(t test.TestHelper, filter string) map[string]int64 {
+	t.T().Helper()
+	path := "stats?format=json"
+	if filter != "" {
+		path += "&filter=" + filter
+	}
+	var stats struct {
+		Stats []struct {
+			Name  string `json:"name"`
+			Value *int64 `json:"value"`
+		} `json:"stats"`
+	}
+	a.getJson(t, path, &stats)
+	values := map[string]int64{}
+	for _, stat := range stats.Stats {
+		if stat.Name != "" && stat.Value != nil {
+			values[stat.Name] = *stat.Value
+		}
+	}
+	return values
+}
+
+// Certs returns the certificates loaded by the proxy
+func (a EnvoyAdmin) Certs(t test.TestHelper) []Certificates {
+	t.T().Helper()
+	var certs struct {
+		Certificates []Certificates `json:"certificates"`
+	}
+	a.getJson(t, "certs", &certs)
+	return certs.Certificates
+}
+
+// CheckConfigDump retries until all checks pass on the config dump of the proxy, since it takes a while until
+// istiod pushes configuration changes to the proxy
+func (a EnvoyAdmin) CheckConfigDump(t test.TestHelper, checks ...ConfigDumpCheck) {
+	t.T().Helper()
+	retry.UntilSuccess(t, func(t test.TestHelper) {
+		t.T().Helper()
+		dump := a.ConfigDump(t)
+		for _, check := range checks {
+			check(t, dump)
+		}
+	})
+}
+
+// CheckCerts retries until all checks pass on the certificates of the proxy
+func (a EnvoyAdmin) CheckCerts(t test.TestHelper, checks ...CertsCheck) {
+	t.T().Helper()
+	retry.UntilSuccess(t, func(t test.TestHelper) {
+		t.T().Helper()
+		certs := a.Certs(t)
+		for _, check := range checks {
+			check(t, certs)
+		}
+	})
+}
+
+// ConfigDump is the configuration that the proxy received from istiod (or from its bootstrap config).
+// Only the commonly asserted fields are decoded; the Raw fields contain the complete objects.
+type ConfigDump struct {
+	Listeners []Listener
+	Routes    []RouteConfiguration
+	Clusters  []Cluster
+	Secrets   []Secret
+}
+
+// ParseConfigDump decodes the response of the config_dump endpoint
+func ParseConfigDump(data []byte) (*ConfigDump, error) {
+	var raw struct {
+		Configs []json.RawMessage `json:"configs"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	dump := &ConfigDump{}
+	for _, config := range raw.Configs {
+		var typed struct {
+			Type string `json:"@type"`
+
+			StaticListeners []struct {
+				Listener Listener `json:"listener"`
+			} `json:"static_listeners"`
+			DynamicListeners []struct {
+				ActiveState *struct {
+					Listener Listener `json:"listener"`
+				} `json:"active_state"`
+			} `json:"dynamic_listeners"`
+
+			StaticRouteConfigs []struct {
+				RouteConfig RouteConfiguration `json:"route_config"`
+			} `json:"static_route_configs"`
+			DynamicRouteConfigs []struct {
+				RouteConfig RouteConfiguration `json:"route_config"`
+			} `json:"dynamic_route_configs"`
+
+			StaticClusters []struct {
+				Cluster Cluster `json:"cluster"`
+			} `json:"static_clusters"`
+			DynamicActiveClusters []struct {
+				Cluster Cluster `json:"cluster"`
+			} `json:"dynamic_active_clusters"`
+
+			StaticSecrets []struct {
+				Secret Secret `json:"secret"`
+			} `json:"static_secrets"`
+			DynamicActiveSecrets []struct {
+				Secret Secret `json:"secret"`
+			} `json:"dynamic_active_secrets"`
+		}
+		if err := json.Unmarshal(config, &typed); err != nil {
+			return nil, err
+		}
+		switch typed.Type[strings.LastIndex(typed.Type, ".")+1:] {
+		case "ListenersConfigDump":
+			for _, l := range typed.StaticListeners {
+				dump.Listeners = append(dump.Listeners, l.Listener)
+			}
+			for _, l := range typed.DynamicListeners {
+				if l.ActiveState != nil {
+					dump.Listeners = append(dump.Listeners, l.ActiveState.Listener)
+				}
+			}
+		case "RoutesConfigDump":
+			for _, r := range typed.StaticRouteConfigs {
+				dump.Routes = append(dump.Routes, r.RouteConfig)
+			}
+			for _, r := range typed.DynamicRouteConfigs {
+				dump.Routes = append(dump.Routes, r.RouteConfig)
+			}
+		case "ClustersConfigDump":
+			for _, c := range typed.StaticClusters {
+				dump.Clusters = append(dump.Clusters, c.Cluster)
+			}
+			for _, c := range typed.DynamicActiveClusters {
+				dump.Clusters = append(dump.Clusters, c.Cluster)
+			}
+		case "SecretsConfigDump":
+			for _, s := range typed.StaticSecrets {
+				dump.Secrets = append(dump.Secrets, s.Secret)
+			}
+			for _, s := range typed.DynamicActiveSecrets {
+				dump.Secrets = append(dump.Secrets, s.Secret)
+			}
+		}
+	}
+	return dump, nil
+}
+
+// Listener returns the listener with the given name (e.g. "virtualInbound" or "0.0.0.0_8080"), or nil
+func (d *ConfigDump) Listener(name string) *Listener {
+	for i := range d.Listeners {
+		if d.Listeners[i].Name == name {
+			return &d.Listeners[i]
+		}
+	}
+	return nil
+}
+
+// Cluster returns the cluster with the given name (e.g. "outbound|9080||reviews.bookinfo.svc.cluster.local"), or nil
+func (d *ConfigDump) Cluster(name string) *Cluster {
+	for i := range d.Clusters {
+		if d.Clusters[i].Name == name {
+			return &d.Clusters[i]
+		}
+	}
+	return nil
+}
+
+// Secret returns the secret with the given name (e.g. "default" or "ROOTCA"), or nil
+func (d *ConfigDump) Secret(name string) *Secret {
+	for i := range d.Secrets {
+		if d.Secrets[i].Name == name {
+			return &d.Secrets[i]
+		}
+	}
+	return nil
+}
+
+// VirtualHosts returns the virtual hosts whose name or domains match the host (e.g. "reviews.bookinfo.svc.cluster.local:9080"
+// or "reviews"), in all route configurations
+func (d *ConfigDump) VirtualHosts(host string) []VirtualHost {
+	var hosts []VirtualHost
+	for _, routeConfig := range d.Routes {
+		for _, vh := range routeConfig.VirtualHosts {
+			if vh.Name == host || contains(vh.Domains, host) {
+				hosts = append(hosts, vh)
+			}
+		}
+	}
+	return hosts
+}
+
+type Listener struct {
+	Name             string         `json:"name"`
+	Address          Address        `json:"address"`
+	TrafficDirection string         `json:"traffic_direction"`
+	FilterChains     []FilterChain  `json:"filter_chains"`
+	ListenerFilters  []Filter       `json:"listener_filters"`
+	Raw              map[string]any `json:"-"`
+}
+
+func (l *Listener) UnmarshalJSON(data []byte) error {
+	type listener Listener
+	if err := json.Unmarshal(data, (*listener)(l)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &l.Raw)
+}
+
+// FilterNames returns the names of the listener filters, the network filters and the HTTP filters
+// of the HTTP connection managers in all filter chains
+func (l *Listener) FilterNames() []string {
+	var names []string
+	for _, filter := range l.ListenerFilters {
+		names = append(names, filter.Name)
+	}
+	for _, chain := range l.FilterChains {
+		for _, filter := range chain.Filters {
+			names = append(names, filter.Name)
+			for _, httpFilter := range filter.TypedConfig.HTTPFilters {
+				names = append(names, httpFilter.Name)
+			}
+		}
+	}
+	return names
+}
+
+type FilterChain struct {
+	Name    string   `json:"name"`
+	Filters []Filter `json:"filters"`
+}
+
+type Filter struct {
+	Name        string            `json:"name"`
+	TypedConfig FilterTypedConfig `json:"typed_config"`
+}
+
+// FilterTypedConfig contains the fields of the filter configurations that tests commonly assert on
+type FilterTypedConfig struct {
+	Type string `json:"@type"`
+	// HTTPFilters are the filters of an HTTP connection manager
+	HTTPFilters []Filter `json:"http_filters"`
+	// RDS references the route configuration of an HTTP connection manager
+	RDS *struct {
+		RouteConfigName string `json:"route_config_name"`
+	} `json:"rds"`
+}
+
+type Address struct {
+	SocketAddress SocketAddress `json:"socket_address"`
+}
+
+type SocketAddress struct {
+	Address   string `json:"address"`
+	PortValue int    `json:"port_value"`
+}
+
+func (a SocketAddress) String() string {
+	return fmt.Sprintf("%s:%d", a.Address, a.PortValue)
+}
+
+type RouteConfiguration struct {
+	Name         string        `json:"name"`
+	VirtualHosts []VirtualHost `json:"virtual_hosts"`
+}
+
+type VirtualHost struct {
+	Name    string   `json:"name"`
+	Domains []string `json:"domains"`
+	Routes  []Route  `json:"routes"`
+}
+
+type Route struct {
+	Name           string          `json:"name"`
+	Match          RouteMatch      `json:"match"`
+	Route          *RouteAction    `json:"route"`
+	Redirect       json.RawMessage `json:"redirect"`
+	DirectResponse json.RawMessage `json:"direct_response"`
+}
+
+type RouteMatch struct {
+	Prefix    string `json:"prefix"`
+	Path      string `json:"path"`
+	SafeRegex *struct {
+		Regex string `json:"regex"`
+	} `json:"safe_regex"`
+}
+
+type RouteAction struct {
+	Cluster          string `json:"cluster"`
+	WeightedClusters *struct {
+		Clusters []struct {
+			Name   string `json:"name"`
+			Weight int    `json:"weight"`
+		} `json:"clusters"`
+	} `json:"weighted_clusters"`
+	// Timeout is the route timeout in the protobuf JSON format (e.g. "0.500s"); see TimeoutDuration
+	Timeout     string `json:"timeout"`
+	RetryPolicy *struct {
+		RetryOn       string `json:"retry_on"`
+		NumRetries    int    `json:"num_retries"`
+		PerTryTimeout string `json:"per_try_timeout"`
+	} `json:"retry_policy"`
+}
+
+// TimeoutDuration returns the route timeout. Envoy's default timeout of 15s applies if the timeout isn't set.
+func (a *RouteAction) TimeoutDuration() time.Duration {
+	if a.Timeout == "" {
+		return 15 * time.Second
+	}
+	timeout, err := time.ParseDuration(a.Timeout)
+	if err != nil {
+		return -1
+	}
+	return timeout
+}
+
+type Cluster struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	ConnectTimeout string `json:"connect_timeout"`
+	LbPolicy       string `json:"lb_policy"`
+	// TransportSocket is set if the proxy originates TLS (e.g. ISTIO_MUTUAL)
+	TransportSocket *struct {
+		Name string `json:"name"`
+	} `json:"transport_socket"`
+	OutlierDetection json.RawMessage `json:"outlier_detection"`
+	CircuitBreakers  json.RawMessage `json:"circuit_breakers"`
+	Raw              map[string]any  `json:"-"`
+}
+
+func (c *Cluster) UnmarshalJSON(data []byte) error {
+	type cluster Cluster
+	if err := json.Unmarshal(data, (*cluster)(c)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &c.Raw)
+}
+
+type Secret struct {
+	Name           string `json:"name"`
+	TLSCertificate *struct {
+		CertificateChain DataSource `json:"certificate_chain"`
+	} `json:"tls_certificate"`
+	ValidationContext *struct {
+		TrustedCA DataSource `json:"trusted_ca"`
+	} `json:"validation_context"`
+}
+
+// DataSource contains the PEM data of a certificate (private keys are redacted in config dumps)
+type DataSource struct {
+	InlineBytes []byte `json:"inline_bytes"`
+}
+
+// ClusterStatus is a cluster returned by the clusters endpoint
+type ClusterStatus struct {
+	Name         string       `json:"name"`
+	AddedViaAPI  bool         `json:"added_via_api"`
+	HostStatuses []HostStatus `json:"host_statuses"`
+}
+
+type HostStatus struct {
+	Address      Address `json:"address"`
+	HealthStatus struct {
+		EdsHealthStatus string `json:"eds_health_status"`
+	} `json:"health_status"`
+	Weight int `json:"weight"`
+	Stats  []struct {
+		Name  string `json:"name"`
+		Value int64  `json:"value,string"`
+	} `json:"stats"`
+}
+
+// Stat returns the value of the host statistic (e.g. "rq_total"), or 0 if it isn't set
+func (h HostStatus) Stat(name string) int64 {
+	for _, stat := range h.Stats {
+		if stat.Name == name {
+			return stat.Value
+		}
+	}
+	return 0
+}
+
+type ServerInfo struct {
+	Version            string `json:"version"`
+	State              string `json:"state"`
+	UptimeCurrentEpoch string `json:"uptime_current_epoch"`
+	Node               struct {
+		Id       string         `json:"id"`
+		Cluster  string         `json:"cluster"`
+		Metadata map[string]any `json:"metadata"`
+	} `json:"node"`
+}
+
+// Certificates are the CA certificates and the certificate chain of a secret loaded by the proxy
+type Certificates struct {
+	CACert    []CertificateDetails `json:"ca_cert"`
+	CertChain []CertificateDetails `json:"cert_chain"`
+}
+
+type CertificateDetails struct {
+	Path            string `json:"path"`
+	SerialNumber    string `json:"serial_number"`
+	SubjectAltNames []struct {
+		URI       string `json:"uri"`
+		DNS       string `json:"dns"`
+		IPAddress string `json:"ip_address"`
+	} `json:"subject_alt_names"`
+	DaysUntilExpiration string    `json:"days_until_expiration"`
+	ValidFrom           time.Time `json:"valid_from"`
+	ExpirationTime      time.Time `json:"expiration_time"`
+}
+
+// SANs returns the subject alternative names of the certificate (e.g. "spiffe://cluster.local/ns/foo/sa/default")
+func (c CertificateDetails) SANs() []string {
+	var sans []string
+	for _, san := range c.SubjectAltNames {
+		for _, value := range []string{san.URI, san.DNS, san.IPAddress} {
+			if value != "" {
+				sans = append(sans, value)
+			}
+		}
+	}
+	return sans
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// ConfigDumpCheck asserts on the config dump of a proxy (see EnvoyAdmin.CheckConfigDump)
+type ConfigDumpCheck func(t test.TestHelper, dump *ConfigDump)
+
+// CertsCheck asserts on the certificates of a proxy (see EnvoyAdmin.CheckCerts)
+type CertsCheck func(t test.TestHelper, certs []Certificates)
+
+// ClusterExists checks that the proxy has the cluster (e.g. "outbound|9080|v1|reviews.bookinfo.svc.cluster.local")
+func ClusterExists(name string) ConfigDumpCheck {
+	return func(t test.TestHelper, dump *ConfigDump) {
+		t.T().Helper()
+		if dump.Cluster(name) == nil {
+			t.Errorf("expected the proxy to have cluster %s, but it doesn't; clusters: %v", name, clusterNames(dump))
+			return
+		}
+		t.LogSuccess(fmt.Sprintf("cluster %s exists", name))
+	}
+}
+
+// ClusterDoesNotExist checks that the proxy doesn't have the cluster
+func ClusterDoesNotExist(name string) ConfigDumpCheck {
+	return func(t test.TestHelper, dump *ConfigDump) {
+		t.T().Helper()
+		if dump.Cluster(name) != nil {
+			t.Errorf("expected the proxy not to have cluster %s, but it does", name)
+			return
+		}
+		t.LogSuccess(fmt.Sprintf("cluster %s doesn't exist", name))
+	}
+}
+
+// RouteHasTimeout checks that a route of the virtual host (see ConfigDump.VirtualHosts) has the timeout
+func RouteHasTimeout(host string, timeout time.Duration) ConfigDumpCheck {
+	return func(t test.TestHelper, dump *ConfigDump) {
+		t.T().Helper()
+		hosts := dump.VirtualHosts(host)
+		if len(hosts) == 0 {
+			t.Errorf("expected the proxy to have a virtual host %s, but it doesn't", host)
+			return
+		}
+		var timeouts []time.Duration
+		for _, vh := range hosts {
+			for _, route := range vh.Routes {
+				if route.Route == nil {
+					continue
+				}
+				if route.Route.TimeoutDuration() == timeout {
+					t.LogSuccess(fmt.Sprintf("route %q of virtual host %s has timeout %v", route.Name, vh.Name, timeout))
+					return
+				}
+				timeouts = append(timeouts, route.Route.TimeoutDuration())
+			}
+		}
+		t.Errorf("expected a route of virtual host %s to have timeout %v, but the routes have timeouts %v", host, timeout, timeouts)
+	}
+}
+
+// ListenerHasFilter checks that the listener (e.g. "virtualInbound") contains the listener, network or HTTP filter
+// (e.g. "envoy.filters.http.rbac")
+func ListenerHasFilter(listener string, filter string) ConfigDumpCheck {
+	return func(t test.TestHelper, dump *ConfigDump) {
+		t.T().Helper()
+		l := dump.Listener(listener)
+		if l == nil {
+			t.Errorf("expected the proxy to have listener %s, but it doesn't", listener)
+			return
+		}
+		names := l.FilterNames()
+		if !contains(names, filter) {
+			t.Errorf("expected listener %s to contain filter %s, but it contains %v", listener, filter, names)
+			return
+		}
+		t.LogSuccess(fmt.Sprintf("listener %s contains filter %s", listener, filter))
+	}
+}
+
+// CertificateSANs checks that the workload certificate of the proxy (the first certificate of its chain)
+// contains the subject alternative names (e.g. "spiffe://cluster.local/ns/foo/sa/default")
+func CertificateSANs(sans ...string) CertsCheck {
+	return func(t test.TestHelper, certs []Certificates) {
+		t.T().Helper()
+		for _, c := range certs {
+			if len(c.CertChain) == 0 {
+				continue
+			}
+			actual := c.CertChain[0].SANs()
+			for _, san := range sans {
+				if !contains(actual, san) {
+					t.Errorf("expected the workload certificate to contain SAN %s, but it contains %v", san, actual)
+					return
+				}
+			}
+			t.LogSuccess(fmt.Sprintf("the workload certificate contains SANs %v", sans))
+			return
+		}
+		t.Error("expected the proxy to have a workload certificate, but it doesn't")
+	}
+}
+
+func clusterNames(dump *ConfigDump) []string {
+	var names []string
+	for _, c := range dump.Clusters {
+		names = append(names, c.Name)
+	}
+	return names
+}
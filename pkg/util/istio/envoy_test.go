@@ -0,0 +1,207 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+const configDumpJson = `{
+  "configs": [
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.BootstrapConfigDump",
+      "bootstrap": {"node": {"id": "sidecar~10.0.0.1~productpage-v1-1.bookinfo~bookinfo.svc.cluster.local"}}
+    },
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.ClustersConfigDump",
+      "static_clusters": [{"cluster": {"name": "prometheus_stats", "type": "STATIC"}}],
+      "dynamic_active_clusters": [
+        {
+          "version_info": "2026-01-01T00:00:00Z/10",
+          "cluster": {
+            "@type": "type.googleapis.com/envoy.config.cluster.v3.Cluster",
+            "name": "outbound|9080|v1|reviews.bookinfo.svc.cluster.local",
+            "type": "EDS",
+            "connect_timeout": "10s",
+            "transport_socket": {"name": "envoy.transport_sockets.tls"}
+          }
+        }
+      ]
+    },
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.ListenersConfigDump",
+      "dynamic_listeners": [
+        {
+          "name": "virtualInbound",
+          "active_state": {
+            "listener": {
+              "@type": "type.googleapis.com/envoy.config.listener.v3.Listener",
+              "name": "virtualInbound",
+              "address": {"socket_address": {"address": "0.0.0.0", "port_value": 15006}},
+              "traffic_direction": "INBOUND",
+              "listener_filters": [{"name": "envoy.filters.listener.tls_inspector"}],
+              "filter_chains": [
+                {
+                  "name": "0.0.0.0_9080",
+                  "filters": [
+                    {
+                      "name": "envoy.filters.network.http_connection_manager",
+                      "typed_config": {
+                        "@type": "type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager",
+                        "http_filters": [{"name": "envoy.filters.http.rbac"}, {"name": "envoy.filters.http.router"}]
+                      }
+                    }
+                  ]
+                }
+              ]
+            }
+          }
+        },
+        {"name": "draining", "draining_state": {}}
+      ]
+    },
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.RoutesConfigDump",
+      "dynamic_route_configs": [
+        {
+          "route_config": {
+            "name": "9080",
+            "virtual_hosts": [
+              {
+                "name": "reviews.bookinfo.svc.cluster.local:9080",
+                "domains": ["reviews.bookinfo.svc.cluster.local", "reviews"],
+                "routes": [
+                  {"name": "default", "match": {"prefix": "/"}, "route": {"cluster": "outbound|9080|v1|reviews.bookinfo.svc.cluster.local", "timeout": "0.500s"}}
+                ]
+              },
+              {
+                "name": "ratings.bookinfo.svc.cluster.local:9080",
+                "domains": ["ratings"],
+                "routes": [{"match": {"prefix": "/"}, "route": {"cluster": "outbound|9080||ratings.bookinfo.svc.cluster.local"}}]
+              }
+            ]
+          }
+        }
+      ]
+    },
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.SecretsConfigDump",
+      "dynamic_active_secrets": [
+        {"name": "ROOTCA", "secret": {"name": "ROOTCA", "validation_context": {"trusted_ca": {"inline_bytes": "Q0E="}}}}
+      ]
+    }
+  ]
+}`
+
+func TestParseConfigDump(t *testing.T) {
+	dump, err := ParseConfigDump([]byte(configDumpJson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := clusterNames(dump); !reflect.DeepEqual(names, []string{"prometheus_stats", "outbound|9080|v1|reviews.bookinfo.svc.cluster.local"}) {
+		t.Errorf("unexpected clusters: %v", names)
+	}
+	if c := dump.Cluster("outbound|9080|v1|reviews.bookinfo.svc.cluster.local"); c.TransportSocket == nil || c.Raw["connect_timeout"] != "10s" {
+		t.Errorf("unexpected cluster: %+v", c)
+	}
+	if len(dump.Listeners) != 1 || dump.Listeners[0].Address.SocketAddress.String() != "0.0.0.0:15006" {
+		t.Errorf("unexpected listeners: %+v", dump.Listeners)
+	}
+	expectedFilters := []string{"envoy.filters.listener.tls_inspector", "envoy.filters.network.http_connection_manager", "envoy.filters.http.rbac", "envoy.filters.http.router"}
+	if filters := dump.Listener("virtualInbound").FilterNames(); !reflect.DeepEqual(filters, expectedFilters) {
+		t.Errorf("expected filters %v, got %v", expectedFilters, filters)
+	}
+	if hosts := dump.VirtualHosts("ratings"); len(hosts) != 1 || hosts[0].Routes[0].Route.TimeoutDuration() != 15*time.Second {
+		t.Errorf("unexpected virtual hosts: %+v", hosts)
+	}
+	if secret := dump.Secret("ROOTCA"); secret == nil || string(secret.ValidationContext.TrustedCA.InlineBytes) != "CA" {
+		t.Errorf("unexpected secret: %+v", secret)
+	}
+}
+
+func TestConfigDumpChecks(t *testing.T) {
+	dump, err := ParseConfigDump([]byte(configDumpJson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	th := test.NewTestHelper(t)
+	cases := []struct {
+		name   string
+		check  ConfigDumpCheck
+		passes bool
+	}{
+		{"cluster exists", ClusterExists("outbound|9080|v1|reviews.bookinfo.svc.cluster.local"), true},
+		{"cluster is missing", ClusterExists("outbound|9080|v2|reviews.bookinfo.svc.cluster.local"), false},
+		{"cluster doesn't exist", ClusterDoesNotExist("outbound|9080|v2|reviews.bookinfo.svc.cluster.local"), true},
+		{"route has timeout", RouteHasTimeout("reviews", 500*time.Millisecond), true},
+		{"route has default timeout", RouteHasTimeout("ratings.bookinfo.svc.cluster.local:9080", 15*time.Second), true},
+		{"route has another timeout", RouteHasTimeout("reviews", time.Second), false},
+		{"unknown virtual host", RouteHasTimeout("details", time.Second), false},
+		{"listener has HTTP filter", ListenerHasFilter("virtualInbound", "envoy.filters.http.rbac"), true},
+		{"listener lacks filter", ListenerHasFilter("virtualInbound", "envoy.filters.http.jwt_authn"), false},
+		{"unknown listener", ListenerHasFilter("virtualOutbound", "envoy.filters.http.rbac"), false},
+	}
+	for _, c := range cases {
+		attempt := retry.Attempt(th, func(t test.TestHelper) {
+			c.check(t, dump)
+		})
+		if passed := !attempt.Failed(); passed != c.passes {
+			t.Errorf("%s: got pass=%v, want %v", c.name, passed, c.passes)
+		}
+	}
+}
+
+func TestEnvoyAdminCertsAndStats(t *testing.T) {
+	th := test.NewTestHelper(t)
+	fake := shell.NewFakeExecutor()
+	defer shell.SetExecutor(fake)()
+	fake.OnPattern(`pilot-agent request GET 'certs'`).Return(`{"certificates": [
+  {"ca_cert": [{"path": "<inline>", "subject_alt_names": []}],
+   "cert_chain": [{"path": "<inline>", "serial_number": "1", "valid_from": "2026-01-01T00:00:00Z",
+     "subject_alt_names": [{"uri": "spiffe://cluster.local/ns/bookinfo/sa/bookinfo-productpage"}]}]}]}`)
+	fake.OnPattern(`pilot-agent request GET 'stats\?format=json&filter=upstream_rq'`).Return(`{"stats": [
+  {"name": "cluster.outbound|9080||reviews.bookinfo.svc.cluster.local.upstream_rq_200", "value": 12},
+  {"histograms": {"supported_quantiles": [0, 50]}}]}`)
+
+	admin := NewEnvoyAdmin(oc.NewOC(""), func(t test.TestHelper, _ *oc.OC) oc.NamespacedName {
+		return oc.NewNamespacedName("bookinfo", "productpage-v1-1")
+	})
+	certs := admin.Certs(th)
+	if len(certs) != 1 || !certs[0].CertChain[0].ValidFrom.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected certs: %+v", certs)
+	}
+	passing := retry.Attempt(th, func(t test.TestHelper) {
+		CertificateSANs("spiffe://cluster.local/ns/bookinfo/sa/bookinfo-productpage")(t, certs)
+	})
+	failing := retry.Attempt(th, func(t test.TestHelper) {
+		CertificateSANs("spiffe://cluster.local/ns/bookinfo/sa/default")(t, certs)
+	})
+	if passing.Failed() || !failing.Failed() {
+		t.Errorf("unexpected CertificateSANs results: passing failed=%v, failing failed=%v", passing.Failed(), failing.Failed())
+	}
+
+	stats := admin.Stats(th, "upstream_rq")
+	expected := map[string]int64{"cluster.outbound|9080||reviews.bookinfo.svc.cluster.local.upstream_rq_200": 12}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("expected stats %v, got %v", expected, stats)
+	}
+}
@@ -50,7 +50,7 @@ func GetProxyMetric(t test.TestHelper, oc *oc.OC, podLocator oc.PodLocatorFunc,
 
 func GetProxyMetrics(t test.TestHelper, oc *oc.OC, podLocator oc.PodLocatorFunc, metric string, labels ...string) []*prometheus.Metric {
 	t.T().Helper()
-	output := oc.Exec(t, podLocator, "istio-proxy", "curl -sS localhost:15000/stats/prometheus")
+	output := NewEnvoyAdmin(oc, podLocator).Get(t, "stats/prometheus")
 
 	var parser expfmt.TextParser
 	families, err := parser.TextToMetricFamilies(strings.NewReader(output))
@@ -0,0 +1,186 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/cert"
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// CertChainCheck asserts on a certificate chain, which starts with the workload certificate
+// (see EnvoyAdmin.CheckWorkloadCertChain and CheckCertChain)
+type CertChainCheck func(t test.TestHelper, chain []*x509.Certificate)
+
+// WorkloadCertChain returns the certificate chain of the workload, which the proxy received from istiod (or
+// from istio-csr) over SDS and serves in mTLS connections
+func (a EnvoyAdmin) WorkloadCertChain(t test.TestHelper) []*x509.Certificate {
+	t.T().Helper()
+	secret := a.ConfigDump(t).Secret("default")
+	if secret == nil || secret.TLSCertificate == nil {
+		t.Fatal("the proxy hasn't received the workload certificate (SDS secret \"default\") yet")
+	}
+	chain, err := cert.ParsePEM(secret.TLSCertificate.CertificateChain.InlineBytes)
+	if err != nil || len(chain) == 0 {
+		t.Fatalf("could not parse the workload certificate chain: %v", err)
+	}
+	return chain
+}
+
+// CheckWorkloadCertChain retries until all checks pass on the workload certificate chain of the proxy,
+// since the proxy only receives a new certificate when it's rotated
+func (a EnvoyAdmin) CheckWorkloadCertChain(t test.TestHelper, checks ...CertChainCheck) {
+	t.T().Helper()
+	retry.UntilSuccess(t, func(t test.TestHelper) {
+		t.T().Helper()
+		CheckCertChain(t, a.WorkloadCertChain(t), checks...)
+	})
+}
+
+// ServedCertChain returns the certificate chain that the server at the address (e.g. "details:9080") presents
+// in a TLS handshake initiated with openssl from the container of the client pod (e.g. the istio-proxy of a sleep pod)
+func ServedCertChain(t test.TestHelper, o *oc.OC, client oc.PodLocatorFunc, container string, address string) []*x509.Certificate {
+	t.T().Helper()
+	// the handshake fails if the server requires a client certificate, but the server certificates are printed anyway
+	output := o.Exec(t, client, container, fmt.Sprintf("openssl s_client -showcerts -connect %s || true", address))
+	chain, err := cert.ParsePEM([]byte(output))
+	if err != nil {
+		t.Fatalf("could not parse the certificates served by %s: %v", address, err)
+	}
+	if len(chain) == 0 {
+		t.Fatalf("%s didn't present any certificate:\n%s", address, output)
+	}
+	return chain
+}
+
+// CheckCertChain runs the checks on the certificate chain
+func CheckCertChain(t test.TestHelper, chain []*x509.Certificate, checks ...CertChainCheck) {
+	t.T().Helper()
+	for _, check := range checks {
+		check(t, chain)
+	}
+}
+
+// IssuedBy checks that the workload certificate chains up to the CA certificate, which can be the root CA
+// or an intermediate CA (e.g. the one in the cacerts secret)
+func IssuedBy(ca *x509.Certificate) CertChainCheck {
+	return func(t test.TestHelper, chain []*x509.Certificate) {
+		t.T().Helper()
+		roots := x509.NewCertPool()
+		roots.AddCert(ca)
+		intermediates := x509.NewCertPool()
+		for _, c := range chain[1:] {
+			intermediates.AddCert(c)
+		}
+		_, err := chain[0].Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		if err != nil {
+			t.Errorf("expected the workload certificate to be issued by %q, but it was issued by %q: %v",
+				ca.Subject.String(), chain[0].Issuer.String(), err)
+			return
+		}
+		t.LogSuccess(fmt.Sprintf("the workload certificate is issued by %q", ca.Subject.String()))
+	}
+}
+
+// HasSPIFFEID checks that the workload certificate has the SPIFFE ID (see cert.SPIFFEID)
+func HasSPIFFEID(id string) CertChainCheck {
+	return func(t test.TestHelper, chain []*x509.Certificate) {
+		t.T().Helper()
+		ids := spiffeIDs(chain[0])
+		for _, actual := range ids {
+			if actual.String() == id {
+				t.LogSuccess(fmt.Sprintf("the workload certificate has SPIFFE ID %s", id))
+				return
+			}
+		}
+		t.Errorf("expected the workload certificate to have SPIFFE ID %s, but it has %v", id, ids)
+	}
+}
+
+// InTrustDomain checks that the SPIFFE IDs of the workload certificate belong to the trust domain
+func InTrustDomain(trustDomain string) CertChainCheck {
+	return func(t test.TestHelper, chain []*x509.Certificate) {
+		t.T().Helper()
+		ids := spiffeIDs(chain[0])
+		if len(ids) == 0 {
+			t.Errorf("expected the workload certificate to have a SPIFFE ID in trust domain %s, but it has none", trustDomain)
+			return
+		}
+		for _, id := range ids {
+			if id.Host != trustDomain {
+				t.Errorf("expected the workload certificate to be in trust domain %s, but its SPIFFE ID is %s", trustDomain, id)
+				return
+			}
+		}
+		t.LogSuccess(fmt.Sprintf("the workload certificate is in trust domain %s", trustDomain))
+	}
+}
+
+// HasKeyType checks the type of the public key of the workload certificate
+func HasKeyType(keyType cert.KeyType) CertChainCheck {
+	return func(t test.TestHelper, chain []*x509.Certificate) {
+		t.T().Helper()
+		if actual := cert.KeyTypeOf(chain[0]); actual != keyType {
+			t.Errorf("expected the workload certificate to have a %s key, but it has a %s key", keyType, actual)
+			return
+		}
+		t.LogSuccess(fmt.Sprintf("the workload certificate has a %s key", keyType))
+	}
+}
+
+// ValidForAtLeast checks that the workload certificate doesn't expire within the duration
+func ValidForAtLeast(d time.Duration) CertChainCheck {
+	return func(t test.TestHelper, chain []*x509.Certificate) {
+		t.T().Helper()
+		if remaining := time.Until(chain[0].NotAfter); remaining < d {
+			t.Errorf("expected the workload certificate to be valid for at least %v, but it expires in %v", d, remaining.Round(time.Second))
+			return
+		}
+		t.LogSuccess(fmt.Sprintf("the workload certificate is valid for at least %v", d))
+	}
+}
+
+// ValidForAtMost checks that the workload certificate expires within the duration (e.g. the configured
+// workload certificate TTL)
+func ValidForAtMost(d time.Duration) CertChainCheck {
+	return func(t test.TestHelper, chain []*x509.Certificate) {
+		t.T().Helper()
+		if remaining := time.Until(chain[0].NotAfter); remaining > d {
+			t.Errorf("expected the workload certificate to be valid for at most %v, but it expires in %v", d, remaining.Round(time.Second))
+			return
+		}
+		t.LogSuccess(fmt.Sprintf("the workload certificate is valid for at most %v", d))
+	}
+}
+
+func spiffeIDs(c *x509.Certificate) []*url.URL {
+	var ids []*url.URL
+	for _, uri := range c.URIs {
+		if uri.Scheme == "spiffe" {
+			ids = append(ids, uri)
+		}
+	}
+	return ids
+}
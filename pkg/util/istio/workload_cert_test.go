@@ -0,0 +1,89 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istio
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/cert"
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+func TestWorkloadCertChain(t *testing.T) {
+	th := test.NewTestHelper(t)
+	ca := cert.NewCAChain(1, cert.RSA)
+	workload := cert.NewLeaf("", ca).WithKeyType(cert.ECDSA).
+		WithSPIFFEID("cluster.local", "bookinfo", "bookinfo-details").
+		WithValidity(time.Now().Add(-time.Hour), time.Now().Add(23*time.Hour)).
+		Build()
+
+	fake := shell.NewFakeExecutor()
+	defer shell.SetExecutor(fake)()
+	fake.OnPattern(`pilot-agent request GET 'config_dump'`).Return(fmt.Sprintf(`{"configs": [{
+  "@type": "type.googleapis.com/envoy.admin.v3.SecretsConfigDump",
+  "dynamic_active_secrets": [{"name": "default", "secret": {"name": "default",
+    "tls_certificate": {"certificate_chain": {"inline_bytes": %q}, "private_key": {"inline_bytes": "W3JlZGFjdGVkXQ=="}}}}]}]}`,
+		base64.StdEncoding.EncodeToString(workload.ChainPEM())))
+	fake.OnPattern(`openssl s_client -showcerts -connect details:9080`).Return(
+		"CONNECTED(00000003)\n---\nCertificate chain\n" + string(workload.ChainPEM()) + "---\nServer certificate\n")
+
+	admin := NewEnvoyAdmin(oc.NewOC(""), func(t test.TestHelper, _ *oc.OC) oc.NamespacedName {
+		return oc.NewNamespacedName("bookinfo", "details-v1-1")
+	})
+	chain := admin.WorkloadCertChain(th)
+	if len(chain) != 3 || !chain[0].Equal(workload.Cert) {
+		t.Fatalf("expected the workload certificate followed by the CA chain, got %d certificates", len(chain))
+	}
+	served := ServedCertChain(th, oc.NewOC(""), func(t test.TestHelper, _ *oc.OC) oc.NamespacedName {
+		return oc.NewNamespacedName("bookinfo", "productpage-v1-1")
+	}, "istio-proxy", "details:9080")
+	if len(served) != 3 || !served[0].Equal(workload.Cert) {
+		t.Fatalf("expected the served chain to match the workload chain, got %d certificates", len(served))
+	}
+
+	cases := []struct {
+		name   string
+		check  CertChainCheck
+		passes bool
+	}{
+		{"issued by intermediate", IssuedBy(ca.Cert), true},
+		{"issued by root", IssuedBy(ca.Root()), true},
+		{"issued by another CA", IssuedBy(cert.NewRootCA("Other CA").Build().Cert), false},
+		{"SPIFFE ID", HasSPIFFEID("spiffe://cluster.local/ns/bookinfo/sa/bookinfo-details"), true},
+		{"other SPIFFE ID", HasSPIFFEID("spiffe://cluster.local/ns/bookinfo/sa/default"), false},
+		{"trust domain", InTrustDomain("cluster.local"), true},
+		{"other trust domain", InTrustDomain("example.com"), false},
+		{"key type", HasKeyType(cert.ECDSA), true},
+		{"other key type", HasKeyType(cert.RSA), false},
+		{"valid for at least", ValidForAtLeast(12 * time.Hour), true},
+		{"not valid long enough", ValidForAtLeast(48 * time.Hour), false},
+		{"valid for at most", ValidForAtMost(24 * time.Hour), true},
+		{"valid for too long", ValidForAtMost(time.Hour), false},
+	}
+	for _, c := range cases {
+		attempt := retry.Attempt(th, func(t test.TestHelper) {
+			CheckCertChain(t, chain, c.check)
+		})
+		if passed := !attempt.Failed(); passed != c.passes {
+			t.Errorf("%s: got pass=%v, want %v", c.name, passed, c.passes)
+		}
+	}
+}
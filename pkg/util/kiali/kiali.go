@@ -0,0 +1,395 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kiali
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+const (
+	// ServiceAccount is the service account whose token is used when the kubeconfig doesn't contain an OAuth token
+	ServiceAccount = "kiali-service-account"
+	// Port is the port of the kiali service
+	Port = 20001
+)
+
+// Client calls the Kiali API with a bearer token, which Kiali accepts with both the "openshift" and "token"
+// authentication strategies
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// NewClient returns a client for the Kiali API at the base URL (e.g. "https://kiali-istio-system.apps.example.com")
+func NewClient(baseURL string, token string) *Client {
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		http: &http.Client{
+			Timeout: 30 * time.Second,
+			Transport: &http.Transport{
+				// the route and the service use certificates that aren't trusted outside the cluster
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		},
+	}
+}
+
+// Connect returns a client for the Kiali instance exposed by the "kiali" route in the namespace
+func Connect(t test.TestHelper, o *oc.OC, ns string) *Client {
+	t.T().Helper()
+	host := o.GetRouteURL(t, ns, "kiali")
+	return NewClient("https://"+host, o.GetToken(t, ns, ServiceAccount))
+}
+
+// ConnectWithPortForward returns a client for the Kiali instance in the namespace, which it reaches through a
+// port-forward to the kiali service. Use it when the route isn't reachable from the machine running the tests.
+func ConnectWithPortForward(t test.TestHelper, o *oc.OC, ns string) *Client {
+	t.T().Helper()
+	port := o.PortForward(t, ns, "svc/kiali", Port)
+	return NewClient(fmt.Sprintf("https://localhost:%d", port), o.GetToken(t, ns, ServiceAccount))
+}
+
+// Get returns the body of the response to a GET request to the API path (e.g. "/api/status")
+func (c *Client) Get(t test.TestHelper, path string, query url.Values) []byte {
+	t.T().Helper()
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, u, nil)
+	if err != nil {
+		t.Fatalf("could not create Kiali request %s: %v", u, err)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		t.Fatalf("Kiali request %s failed: %v", u, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("could not read the response to Kiali request %s: %v", u, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Kiali request %s returned %s: %s", u, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body
+}
+
+func (c *Client) getJson(t test.TestHelper, path string, query url.Values, v interface{}) {
+	t.T().Helper()
+	if err := json.Unmarshal(c.Get(t, path, query), v); err != nil {
+		t.Fatalf("could not decode the response to Kiali request %s: %v", path, err)
+	}
+}
+
+// Graph returns the versioned app graph of the traffic between the namespaces in the last 10 minutes
+func (c *Client) Graph(t test.TestHelper, namespaces ...string) *Graph {
+	t.T().Helper()
+	var graph Graph
+	c.getJson(t, "/api/namespaces/graph", url.Values{
+		"namespaces": {strings.Join(namespaces, ",")},
+		"graphType":  {"versionedApp"},
+		"duration":   {"600s"},
+	}, &graph)
+	return &graph
+}
+
+// WorkloadHealth returns the health of the workloads in the namespace, by workload name
+func (c *Client) WorkloadHealth(t test.TestHelper, ns string) map[string]WorkloadHealth {
+	t.T().Helper()
+	health := map[string]WorkloadHealth{}
+	c.getJson(t, fmt.Sprintf("/api/namespaces/%s/health", ns), url.Values{
+		"type":         {"workload"},
+		"rateInterval": {"60s"},
+	}, &health)
+	return health
+}
+
+// IstioConfig returns the Istio config objects in the namespace and their validations
+func (c *Client) IstioConfig(t test.TestHelper, ns string) *IstioConfigList {
+	t.T().Helper()
+	var list IstioConfigList
+	c.getJson(t, fmt.Sprintf("/api/namespaces/%s/istio", ns), url.Values{"validate": {"true"}}, &list)
+	return &list
+}
+
+// Check retries until all checks pass, since Kiali computes the graph and the health from Prometheus
+// metrics that are only scraped periodically
+func (c *Client) Check(t test.TestHelper, checks ...Check) {
+	t.T().Helper()
+	retry.UntilSuccess(t, func(t test.TestHelper) {
+		t.T().Helper()
+		for _, check := range checks {
+			check(t, c)
+		}
+	})
+}
+
+// Graph is the Kiali graph in the cytoscape format
+type Graph struct {
+	GraphType string `json:"graphType"`
+	Elements  struct {
+		Nodes []struct {
+			Data Node `json:"data"`
+		} `json:"nodes"`
+		Edges []struct {
+			Data Edge `json:"data"`
+		} `json:"edges"`
+	} `json:"elements"`
+}
+
+type Node struct {
+	ID string `json:"id"`
+	// NodeType is "app", "service", "workload", "box" (a node grouping other nodes) or "unknown"
+	NodeType  string `json:"nodeType"`
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	Workload  string `json:"workload"`
+	App       string `json:"app"`
+	Version   string `json:"version"`
+	Service   string `json:"service"`
+}
+
+// Matches returns whether the node is the workload, app or service in the namespace
+func (n Node) Matches(ns, name string) bool {
+	return n.NodeType != "box" && n.Namespace == ns && (n.Workload == name || n.App == name || n.Service == name)
+}
+
+func (n Node) String() string {
+	name := n.Workload
+	if name == "" {
+		name = n.App
+	}
+	if name == "" {
+		name = n.Service
+	}
+	return fmt.Sprintf("%s %s/%s", n.NodeType, n.Namespace, name)
+}
+
+type Edge struct {
+	ID      string  `json:"id"`
+	Source  string  `json:"source"`
+	Target  string  `json:"target"`
+	Traffic Traffic `json:"traffic"`
+}
+
+type Traffic struct {
+	// Protocol is "http", "grpc" or "tcp"
+	Protocol string            `json:"protocol"`
+	Rates    map[string]string `json:"rates"`
+}
+
+// Node returns the node with the ID, or nil if there's no such node
+func (g *Graph) Node(id string) *Node {
+	for i := range g.Elements.Nodes {
+		if g.Elements.Nodes[i].Data.ID == id {
+			return &g.Elements.Nodes[i].Data
+		}
+	}
+	return nil
+}
+
+// EdgesBetween returns the edges from a node matching from to a node matching to (see Node.Matches),
+// where from and to are "namespace/name"
+func (g *Graph) EdgesBetween(from, to string) []Edge {
+	fromNs, fromName := splitName(from)
+	toNs, toName := splitName(to)
+	var edges []Edge
+	for _, e := range g.Elements.Edges {
+		source, target := g.Node(e.Data.Source), g.Node(e.Data.Target)
+		if source != nil && target != nil && source.Matches(fromNs, fromName) && target.Matches(toNs, toName) {
+			edges = append(edges, e.Data)
+		}
+	}
+	return edges
+}
+
+// edgeNames returns the edges as "source -> target (protocol)", for error messages
+func (g *Graph) edgeNames() []string {
+	var names []string
+	for _, e := range g.Elements.Edges {
+		source, target := g.Node(e.Data.Source), g.Node(e.Data.Target)
+		if source != nil && target != nil {
+			names = append(names, fmt.Sprintf("%s -> %s (%s)", source, target, e.Data.Traffic.Protocol))
+		}
+	}
+	return names
+}
+
+func splitName(name string) (string, string) {
+	if i := strings.Index(name, "/"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+type WorkloadHealth struct {
+	WorkloadStatus *WorkloadStatus `json:"workloadStatus"`
+	Requests       RequestHealth   `json:"requests"`
+}
+
+type WorkloadStatus struct {
+	Name              string `json:"name"`
+	DesiredReplicas   int32  `json:"desiredReplicas"`
+	CurrentReplicas   int32  `json:"currentReplicas"`
+	AvailableReplicas int32  `json:"availableReplicas"`
+	// SyncedProxies is the number of proxies that received the latest config from istiod, or -1 if unknown
+	SyncedProxies int32 `json:"syncedProxies"`
+}
+
+// RequestHealth contains the request rates by protocol and response code (e.g. Inbound["http"]["503"])
+type RequestHealth struct {
+	Inbound  map[string]map[string]float64 `json:"inbound"`
+	Outbound map[string]map[string]float64 `json:"outbound"`
+}
+
+// Healthy returns whether all desired replicas are available and their proxies are synced
+func (h WorkloadHealth) Healthy() bool {
+	s := h.WorkloadStatus
+	if s == nil {
+		return false
+	}
+	return s.AvailableReplicas >= s.DesiredReplicas && (s.SyncedProxies < 0 || s.SyncedProxies >= s.AvailableReplicas)
+}
+
+// ErrorRatio returns the fraction of the inbound HTTP and gRPC requests that failed with a 5xx status
+func (h WorkloadHealth) ErrorRatio() float64 {
+	var total, errors float64
+	for _, codes := range h.Requests.Inbound {
+		for code, rate := range codes {
+			total += rate
+			if strings.HasPrefix(code, "5") {
+				errors += rate
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return errors / total
+}
+
+// IstioConfigList contains the Istio config objects in a namespace. Kiali returns them as lists keyed by kind,
+// which are flattened into Objects.
+type IstioConfigList struct {
+	Objects []ConfigObject
+	// Validations are the validations by object type and name
+	Validations map[string]map[string]ObjectValidation
+}
+
+type ConfigObject struct {
+	// Kind is the kind of the object or, if the response doesn't include it, the key of the list containing it
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+type ObjectValidation struct {
+	Name       string            `json:"name"`
+	ObjectType string            `json:"objectType"`
+	Valid      bool              `json:"valid"`
+	Checks     []ValidationCheck `json:"checks"`
+}
+
+type ValidationCheck struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Severity is "error", "warning" or "info"
+	Severity string `json:"severity"`
+	Path     string `json:"path"`
+}
+
+func (l *IstioConfigList) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if raw, found := fields["validations"]; found {
+		if err := json.Unmarshal(raw, &l.Validations); err != nil {
+			return fmt.Errorf("could not decode validations: %v", err)
+		}
+	}
+	// newer Kiali versions return the lists in "resources", keyed by group/version/kind
+	if raw, found := fields["resources"]; found {
+		var resources map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &resources); err == nil {
+			for key, value := range resources {
+				fields[key] = value
+			}
+		}
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var objects []struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Namespace string `json:"namespace"`
+				Name      string `json:"name"`
+			} `json:"metadata"`
+		}
+		// fields that aren't lists of objects (e.g. "namespace" or "validations") are skipped
+		if err := json.Unmarshal(fields[key], &objects); err != nil {
+			continue
+		}
+		for _, obj := range objects {
+			if obj.Metadata.Name == "" {
+				continue
+			}
+			kind := obj.Kind
+			if kind == "" {
+				kind = key
+			}
+			l.Objects = append(l.Objects, ConfigObject{Kind: kind, Namespace: obj.Metadata.Namespace, Name: obj.Metadata.Name})
+		}
+	}
+	return nil
+}
+
+// Errors returns the validation checks with severity "error", as "type name: message"
+func (l *IstioConfigList) Errors() []string {
+	var errors []string
+	for objectType, validations := range l.Validations {
+		for name, validation := range validations {
+			for _, check := range validation.Checks {
+				if check.Severity == "error" {
+					errors = append(errors, fmt.Sprintf("%s %s: %s (%s)", objectType, name, check.Message, check.Code))
+				}
+			}
+		}
+	}
+	sort.Strings(errors)
+	return errors
+}
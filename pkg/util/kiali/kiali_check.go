@@ -0,0 +1,81 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kiali
+
+import (
+	"fmt"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// Check asserts on the data returned by Kiali (see Client.Check)
+type Check func(t test.TestHelper, c *Client)
+
+// GraphHasEdge checks that the graph contains traffic with the protocol (e.g. "http" or "tcp") from one
+// workload, app or service to another, where from and to are "namespace/name" (e.g. "bookinfo/productpage")
+func GraphHasEdge(from, to, protocol string) Check {
+	return func(t test.TestHelper, c *Client) {
+		t.T().Helper()
+		fromNs, _ := splitName(from)
+		toNs, _ := splitName(to)
+		namespaces := []string{fromNs}
+		if toNs != fromNs {
+			namespaces = append(namespaces, toNs)
+		}
+		graph := c.Graph(t, namespaces...)
+		var protocols []string
+		for _, edge := range graph.EdgesBetween(from, to) {
+			if edge.Traffic.Protocol == protocol {
+				t.LogSuccess(fmt.Sprintf("Kiali graph has %s traffic from %s to %s", protocol, from, to))
+				return
+			}
+			protocols = append(protocols, edge.Traffic.Protocol)
+		}
+		if len(protocols) > 0 {
+			t.Errorf("expected %s traffic from %s to %s in the Kiali graph, but found only %v traffic", protocol, from, to, protocols)
+			return
+		}
+		t.Errorf("expected %s traffic from %s to %s in the Kiali graph, but there's none; edges: %v", protocol, from, to, graph.edgeNames())
+	}
+}
+
+// NoValidationErrors checks that Kiali doesn't report validation errors for the Istio config in the namespace
+func NoValidationErrors(ns string) Check {
+	return func(t test.TestHelper, c *Client) {
+		t.T().Helper()
+		if errors := c.IstioConfig(t, ns).Errors(); len(errors) > 0 {
+			t.Errorf("expected no Kiali validation errors in namespace %s, got %d: %v", ns, len(errors), errors)
+			return
+		}
+		t.LogSuccess(fmt.Sprintf("Kiali reports no validation errors in namespace %s", ns))
+	}
+}
+
+// WorkloadHealthy checks that Kiali reports all replicas of the workload as available with synced proxies
+func WorkloadHealthy(ns, workload string) Check {
+	return func(t test.TestHelper, c *Client) {
+		t.T().Helper()
+		health, found := c.WorkloadHealth(t, ns)[workload]
+		if !found {
+			t.Errorf("expected Kiali to report the health of workload %s/%s, but it doesn't", ns, workload)
+			return
+		}
+		if !health.Healthy() {
+			t.Errorf("expected workload %s/%s to be healthy, but its status is %+v", ns, workload, health.WorkloadStatus)
+			return
+		}
+		t.LogSuccess(fmt.Sprintf("Kiali reports workload %s/%s as healthy", ns, workload))
+	}
+}
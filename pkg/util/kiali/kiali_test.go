@@ -0,0 +1,171 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kiali
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+const graphJson = `{
+  "graphType": "versionedApp",
+  "elements": {
+    "nodes": [
+      {"data": {"id": "n0", "nodeType": "box", "namespace": "bookinfo", "app": "reviews"}},
+      {"data": {"id": "n1", "nodeType": "app", "namespace": "bookinfo", "workload": "productpage-v1", "app": "productpage", "version": "v1"}},
+      {"data": {"id": "n2", "nodeType": "service", "namespace": "bookinfo", "service": "reviews"}},
+      {"data": {"id": "n3", "nodeType": "app", "namespace": "bookinfo", "workload": "reviews-v1", "app": "reviews", "version": "v1"}},
+      {"data": {"id": "n4", "nodeType": "service", "namespace": "mongo", "service": "mongodb"}}
+    ],
+    "edges": [
+      {"data": {"id": "e0", "source": "n1", "target": "n2", "traffic": {"protocol": "http", "rates": {"http": "1.00"}}}},
+      {"data": {"id": "e1", "source": "n2", "target": "n3", "traffic": {"protocol": "http", "rates": {"http": "1.00"}}}},
+      {"data": {"id": "e2", "source": "n3", "target": "n4", "traffic": {"protocol": "tcp", "rates": {"tcp": "120.00"}}}}
+    ]
+  }
+}`
+
+const healthJson = `{
+  "productpage-v1": {
+    "workloadStatus": {"name": "productpage-v1", "desiredReplicas": 1, "currentReplicas": 1, "availableReplicas": 1, "syncedProxies": 1},
+    "requests": {"inbound": {"http": {"200": 0.9, "503": 0.1}}, "outbound": {}}
+  },
+  "reviews-v1": {
+    "workloadStatus": {"name": "reviews-v1", "desiredReplicas": 2, "currentReplicas": 2, "availableReplicas": 1, "syncedProxies": 1},
+    "requests": {}
+  }
+}`
+
+const istioConfigJson = `{
+  "namespace": {"name": "bookinfo"},
+  "gateways": [{"kind": "Gateway", "metadata": {"name": "bookinfo-gateway", "namespace": "bookinfo"}}],
+  "virtualServices": [{"metadata": {"name": "reviews", "namespace": "bookinfo"}}],
+  "destinationRules": [],
+  "validations": {
+    "virtualservice": {
+      "reviews": {"name": "reviews", "objectType": "virtualservice", "valid": false, "checks": [
+        {"code": "KIA1107", "message": "Subset not found", "severity": "error", "path": "spec/http[0]/route[0]/destination"},
+        {"code": "KIA1106", "message": "More than one Virtual Service for same host", "severity": "warning", "path": "spec/hosts"}
+      ]}
+    },
+    "gateway": {
+      "bookinfo-gateway": {"name": "bookinfo-gateway", "objectType": "gateway", "valid": true, "checks": []}
+    }
+  }
+}`
+
+const validIstioConfigJson = `{
+  "resources": {
+    "networking.istio.io/v1, Kind=Gateway": [{"kind": "Gateway", "metadata": {"name": "bookinfo-gateway", "namespace": "bookinfo"}}]
+  },
+  "validations": {}
+}`
+
+func newTestServer(t *testing.T) *httptest.Server {
+	responses := map[string]string{
+		"/api/namespaces/graph":           graphJson,
+		"/api/namespaces/bookinfo/health": healthJson,
+		"/api/namespaces/bookinfo/istio":  istioConfigJson,
+		"/api/namespaces/mongo/istio":     validIstioConfigJson,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		response, found := responses[r.URL.Path]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient(t *testing.T) {
+	th := test.NewTestHelper(t)
+	client := NewClient(newTestServer(t).URL, "secret")
+
+	graph := client.Graph(th, "bookinfo")
+	if edges := graph.EdgesBetween("bookinfo/productpage", "bookinfo/reviews"); len(edges) != 1 || edges[0].ID != "e0" {
+		t.Errorf("expected edge e0 from productpage to reviews, got %+v", edges)
+	}
+
+	health := client.WorkloadHealth(th, "bookinfo")
+	if !health["productpage-v1"].Healthy() || health["reviews-v1"].Healthy() {
+		t.Errorf("unexpected workload health: %+v", health)
+	}
+	if ratio := health["productpage-v1"].ErrorRatio(); ratio < 0.099 || ratio > 0.101 {
+		t.Errorf("expected error ratio 0.1, got %v", ratio)
+	}
+
+	config := client.IstioConfig(th, "bookinfo")
+	expectedObjects := []ConfigObject{
+		{Kind: "Gateway", Namespace: "bookinfo", Name: "bookinfo-gateway"},
+		{Kind: "virtualServices", Namespace: "bookinfo", Name: "reviews"},
+	}
+	if !reflect.DeepEqual(config.Objects, expectedObjects) {
+		t.Errorf("expected objects %+v, got %+v", expectedObjects, config.Objects)
+	}
+	expectedErrors := []string{"virtualservice reviews: Subset not found (KIA1107)"}
+	if errors := config.Errors(); !reflect.DeepEqual(errors, expectedErrors) {
+		t.Errorf("expected errors %v, got %v", expectedErrors, errors)
+	}
+	if objects := client.IstioConfig(th, "mongo").Objects; len(objects) != 1 || objects[0].Kind != "Gateway" {
+		t.Errorf("expected a Gateway in the resources list, got %+v", objects)
+	}
+
+	unauthorized := retry.Attempt(th, func(t test.TestHelper) {
+		NewClient(client.baseURL, "wrong").Graph(t, "bookinfo")
+	})
+	if !unauthorized.Failed() {
+		t.Errorf("expected request with a wrong token to fail")
+	}
+}
+
+func TestChecks(t *testing.T) {
+	th := test.NewTestHelper(t)
+	client := NewClient(newTestServer(t).URL, "secret")
+	cases := []struct {
+		name   string
+		check  Check
+		passes bool
+	}{
+		{"http edge", GraphHasEdge("bookinfo/productpage", "bookinfo/reviews", "http"), true},
+		{"tcp edge to another namespace", GraphHasEdge("bookinfo/reviews", "mongo/mongodb", "tcp"), true},
+		{"edge with another protocol", GraphHasEdge("bookinfo/productpage", "bookinfo/reviews", "grpc"), false},
+		{"missing edge", GraphHasEdge("bookinfo/reviews", "bookinfo/productpage", "http"), false},
+		{"validation errors", NoValidationErrors("bookinfo"), false},
+		{"no validation errors", NoValidationErrors("mongo"), true},
+		{"healthy workload", WorkloadHealthy("bookinfo", "productpage-v1"), true},
+		{"unhealthy workload", WorkloadHealthy("bookinfo", "reviews-v1"), false},
+		{"unknown workload", WorkloadHealthy("bookinfo", "details-v1"), false},
+	}
+	for _, c := range cases {
+		attempt := retry.Attempt(th, func(t test.TestHelper) {
+			c.check(t, client)
+		})
+		if passed := !attempt.Failed(); passed != c.passes {
+			t.Errorf("%s: got pass=%v, want %v", c.name, passed, c.passes)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lease coordinates access to cluster-scoped resources (e.g. the SMCP) between tests that
+// run in parallel, possibly in different test processes. A lease is backed by coordination.k8s.io
+// Lease objects in the default namespace, so it's visible to every process that uses the cluster.
+//
+// Any number of tests can hold a shared lease on a resource at the same time, but an exclusive
+// lease can only be held by one test, and only while no other test holds a shared lease. Tests that
+// merely use a resource should take a shared lease, while tests that modify it take an exclusive one.
+// Leases are released when the test completes.
+package lease
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util"
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// ControlPlane is the default SMCP and its member roll in the mesh namespace
+const ControlPlane = "control-plane"
+
+const (
+	leaseNamespace  = "default"
+	leaseLabelValue = "lease"
+	namePrefix      = "mtt-"
+)
+
+var (
+	// duration is how long a lease is valid. Leases are never renewed, so this must be longer than any test;
+	// it only matters when a test process is killed before it can release its leases.
+	duration = time.Hour
+
+	// timeout is how long a test waits for a lease before failing
+	timeout = time.Hour
+
+	pollInterval = 5 * time.Second
+)
+
+// Shared acquires a shared lease on the given resource and holds it until the test completes.
+// It blocks while another test holds an exclusive lease on the resource.
+func Shared(t test.TestHelper, resource string) {
+	t.T().Helper()
+	me := holderIdentity(t)
+	if l := ownedLease(list(t, resource), me); l != nil {
+		return // an exclusive lease also grants shared access
+	}
+
+	name := fmt.Sprintf("%s%s-shared-%s", namePrefix, resource, util.NewShortNonce())
+	t.Cleanup(func() {
+		oc.DeleteResource(t, leaseNamespace, "lease", name)
+	})
+	acquire(t, resource, "shared", func(leases []lease) string {
+		t.T().Helper()
+		if l := exclusiveLease(leases, resource); l != nil {
+			return l.holder
+		}
+		oc.CreateString(t, leaseNamespace, leaseYaml(name, me, time.Now()))
+		// an exclusive lease may have been created after we listed the leases; since the test
+		// holding it waits for all shared leases to be released, we need to back off
+		if l := exclusiveLease(list(t, resource), resource); l != nil {
+			oc.DeleteResource(t, leaseNamespace, "lease", name)
+			return l.holder
+		}
+		return ""
+	})
+}
+
+// Exclusive acquires an exclusive lease on the given resource and holds it until the test completes.
+// It blocks while another test holds any lease on the resource.
+func Exclusive(t test.TestHelper, resource string) {
+	t.T().Helper()
+	me := holderIdentity(t)
+	if l := ownedLease(list(t, resource), me); l != nil {
+		if l.name == exclusiveName(resource) {
+			return
+		}
+		t.Fatalf("Cannot acquire exclusive lease on %s, because the test already holds a shared lease on it", resource)
+	}
+
+	name := exclusiveName(resource)
+	acquire(t, resource, "exclusive", func(leases []lease) string {
+		t.T().Helper()
+		if !oc.CreateString(t, leaseNamespace, leaseYaml(name, me, time.Now())) {
+			if l := exclusiveLease(leases, resource); l != nil {
+				return l.holder
+			}
+			return "another test"
+		}
+		// the lease has a fixed name, so the cleanup must only be registered once the lease is ours
+		t.Cleanup(func() {
+			oc.DeleteResource(t, leaseNamespace, "lease", name)
+		})
+		return ""
+	})
+	// no new shared leases can be acquired now, but we must wait for the existing ones to be released
+	acquire(t, resource, "exclusive", func(leases []lease) string {
+		t.T().Helper()
+		for _, l := range leases {
+			if l.name != name {
+				return l.holder
+			}
+		}
+		return ""
+	})
+}
+
+type lease struct {
+	name       string
+	holder     string
+	renewTime  time.Time
+	expiration time.Time
+}
+
+func (l lease) expired() bool {
+	return time.Now().After(l.expiration)
+}
+
+// acquire calls try until it returns an empty string. The try function returns the holder of
+// the lease that prevents it from acquiring the lease.
+func acquire(t test.TestHelper, resource string, mode string, try func(leases []lease) string) {
+	t.T().Helper()
+	deadline := time.Now().Add(timeout)
+	lastHolder := ""
+	for {
+		holder := try(deleteExpired(t, list(t, resource)))
+		if holder == "" {
+			t.Logf("Acquired %s lease on %s", mode, resource)
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out after %v waiting for %s lease on %s, which is held by %s", timeout, mode, resource, holder)
+		}
+		if holder != lastHolder {
+			t.Logf("Waiting for %s lease on %s, which is held by %s", mode, resource, holder)
+			lastHolder = holder
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// list returns all leases on the given resource
+func list(t test.TestHelper, resource string) []lease {
+	t.T().Helper()
+	output := oc.GetJson(t, leaseNamespace, "leases", "",
+		`{range .items[*]}{.metadata.name}{" "}{.spec.holderIdentity}{" "}{.spec.renewTime}{" "}{.spec.leaseDurationSeconds}{"\n"}{end}`)
+	var leases []lease
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 4 || !isLeaseOn(fields[0], resource) {
+			continue
+		}
+		renewTime, err := time.Parse(time.RFC3339Nano, fields[2])
+		if err != nil {
+			t.Fatalf("Invalid renewTime in lease %s: %v", fields[0], err)
+		}
+		seconds, err := strconv.Atoi(fields[3])
+		if err != nil {
+			t.Fatalf("Invalid leaseDurationSeconds in lease %s: %v", fields[0], err)
+		}
+		leases = append(leases, lease{
+			name:       fields[0],
+			holder:     fields[1],
+			renewTime:  renewTime,
+			expiration: renewTime.Add(time.Duration(seconds) * time.Second),
+		})
+	}
+	return leases
+}
+
+// deleteExpired deletes leases left behind by test processes that were killed and returns the remaining ones
+func deleteExpired(t test.TestHelper, leases []lease) []lease {
+	t.T().Helper()
+	var valid []lease
+	for _, l := range leases {
+		if l.expired() {
+			t.Logf("Deleting expired lease %s held by %s since %s", l.name, l.holder, l.renewTime.Format(time.RFC3339))
+			oc.DeleteResource(t, leaseNamespace, "lease", l.name)
+			continue
+		}
+		valid = append(valid, l)
+	}
+	return valid
+}
+
+func isLeaseOn(name, resource string) bool {
+	return name == exclusiveName(resource) || strings.HasPrefix(name, namePrefix+resource+"-shared-")
+}
+
+func exclusiveName(resource string) string {
+	return namePrefix + resource
+}
+
+func exclusiveLease(leases []lease, resource string) *lease {
+	for i := range leases {
+		if leases[i].name == exclusiveName(resource) {
+			return &leases[i]
+		}
+	}
+	return nil
+}
+
+// ownedLease returns the lease held by the given holder or by one of its parent tests,
+// because a subtest runs while its parent holds the lease
+func ownedLease(leases []lease, holder string) *lease {
+	for i := range leases {
+		if leases[i].holder == holder || strings.HasPrefix(holder, leases[i].holder+"/") {
+			return &leases[i]
+		}
+	}
+	return nil
+}
+
+// holderIdentity identifies the test and the process running it, so that we can tell which
+// test we're waiting for even if it's running on a different machine
+func holderIdentity(t test.TestHelper) string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s/%d/%s", hostname, os.Getpid(), t.T().Name())
+}
+
+func leaseYaml(name, holder string, acquireTime time.Time) string {
+	microTime := acquireTime.UTC().Format("2006-01-02T15:04:05.000000Z07:00")
+	return fmt.Sprintf(`
+apiVersion: coordination.k8s.io/v1
+kind: Lease
+metadata:
+  name: %s
+  labels:
+    %s: %s
+spec:
+  holderIdentity: %q
+  leaseDurationSeconds: %d
+  acquireTime: %s
+  renewTime: %s
+`, name, oc.MaistraTestLabel, leaseLabelValue, holder, int(duration.Seconds()), microTime, microTime)
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+const otherHolder = "other-host/1/TestOther"
+
+func TestSharedLeasesDoNotBlockEachOther(t *testing.T) {
+	th, cluster := setup(t)
+	cluster.Add(th, leaseYaml("mtt-control-plane-shared-other", otherHolder, time.Now()))
+
+	Shared(th, ControlPlane)
+
+	if n := len(list(th, ControlPlane)); n != 2 {
+		t.Fatalf("expected 2 shared leases, but got %d", n)
+	}
+}
+
+func TestSharedWaitsForExclusiveLease(t *testing.T) {
+	th, cluster := setup(t)
+	cluster.Add(th, leaseYaml("mtt-control-plane", otherHolder, time.Now()))
+
+	attempt := retry.Attempt(th, func(t test.TestHelper) {
+		Shared(t, ControlPlane)
+	})
+	if !attempt.Failed() {
+		t.Fatal("expected shared lease to time out")
+	}
+	if leases := list(th, ControlPlane); len(leases) != 1 || leases[0].holder != otherHolder {
+		t.Fatalf("expected only the exclusive lease to remain, but got %v", leases)
+	}
+}
+
+func TestExclusiveWaitsForSharedLeases(t *testing.T) {
+	th, cluster := setup(t)
+	cluster.Add(th, leaseYaml("mtt-control-plane-shared-other", otherHolder, time.Now()))
+
+	attempt := retry.Attempt(th, func(t test.TestHelper) {
+		Exclusive(t, ControlPlane)
+	})
+	if !attempt.Failed() {
+		t.Fatal("expected exclusive lease to time out")
+	}
+}
+
+func TestExclusiveDoesNotReleaseLeaseOfAnotherTest(t *testing.T) {
+	th, cluster := setup(t)
+	cluster.Add(th, leaseYaml("mtt-control-plane", otherHolder, time.Now()))
+
+	t.Run("waiter", func(t *testing.T) {
+		attempt := retry.Attempt(test.NewTestHelper(t), func(t test.TestHelper) {
+			Exclusive(t, ControlPlane)
+		})
+		if !attempt.Failed() {
+			t.Fatal("expected exclusive lease to time out")
+		}
+	})
+
+	if leases := list(th, ControlPlane); len(leases) != 1 || leases[0].holder != otherHolder {
+		t.Fatalf("expected the exclusive lease of the other test to remain, but got %v", leases)
+	}
+}
+
+func TestExclusiveReplacesExpiredLease(t *testing.T) {
+	th, cluster := setup(t)
+	cluster.Add(th, leaseYaml("mtt-control-plane", otherHolder, time.Now().Add(-2*duration)))
+
+	Exclusive(th, ControlPlane)
+
+	if l := exclusiveLease(list(th, ControlPlane), ControlPlane); l == nil || l.holder != holderIdentity(th) {
+		t.Fatalf("expected exclusive lease to be held by this test, but got %v", l)
+	}
+}
+
+func TestLeaseIsReleasedWhenTestCompletes(t *testing.T) {
+	th, cluster := setup(t)
+
+	t.Run("holder", func(t *testing.T) {
+		Exclusive(test.NewTestHelper(t), ControlPlane)
+	})
+
+	if cluster.Get(th, leaseNamespace, "lease", "mtt-control-plane") != nil {
+		t.Fatal("expected exclusive lease to be released")
+	}
+}
+
+func TestSubtestSharesParentLease(t *testing.T) {
+	th, _ := setup(t)
+	Exclusive(th, ControlPlane)
+
+	t.Run("subtest", func(t *testing.T) {
+		Shared(test.NewTestHelper(t), ControlPlane)
+		Exclusive(test.NewTestHelper(t), ControlPlane)
+	})
+
+	if n := len(list(th, ControlPlane)); n != 1 {
+		t.Fatalf("expected a single lease, but got %d", n)
+	}
+}
+
+func setup(t *testing.T) (test.TestHelper, *oc.FakeCluster) {
+	th := test.NewTestHelper(t)
+	cluster := oc.NewFakeCluster()
+	cluster.UseAsDefault(th)
+
+	origTimeout, origPollInterval := timeout, pollInterval
+	timeout, pollInterval = 50*time.Millisecond, 10*time.Millisecond
+	t.Cleanup(func() {
+		timeout, pollInterval = origTimeout, origPollInterval
+	})
+	return th, cluster
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// Check asserts on the result of a load run (see Run)
+type Check func(t test.TestHelper, r *Result)
+
+// CodeRatioAtLeast checks that at least the ratio of the requests (e.g. 0.05 for 5%) received the HTTP status code,
+// e.g. 503 from a tripped circuit breaker or 429 from a rate limit
+func CodeRatioAtLeast(code int, ratio float64) Check {
+	return compareRatio(strconv.Itoa(code), fmt.Sprintf("at least %.1f%%", 100*ratio), func(actual float64) bool {
+		return actual >= ratio
+	})
+}
+
+// CodeRatioAtMost checks that at most the ratio of the requests received the HTTP status code
+func CodeRatioAtMost(code int, ratio float64) Check {
+	return compareRatio(strconv.Itoa(code), fmt.Sprintf("at most %.1f%%", 100*ratio), func(actual float64) bool {
+		return actual <= ratio
+	})
+}
+
+// AllCodes checks that all requests received the HTTP status code, e.g. 200 when retries hide the failures
+func AllCodes(code int) Check {
+	return compareRatio(strconv.Itoa(code), "100%", func(actual float64) bool {
+		return actual == 1
+	})
+}
+
+// GRPCStatusRatioAtLeast checks that at least the ratio of the gRPC requests received the status (e.g. "SERVING")
+func GRPCStatusRatioAtLeast(status string, ratio float64) Check {
+	return compareRatio(status, fmt.Sprintf("at least %.1f%%", 100*ratio), func(actual float64) bool {
+		return actual >= ratio
+	})
+}
+
+func compareRatio(code, description string, matches func(actual float64) bool) Check {
+	return func(t test.TestHelper, r *Result) {
+		t.T().Helper()
+		if actual := r.Ratio(code); !matches(actual) {
+			t.Errorf("expected %s of the requests to receive code %s, got %.1f%% (%s)", description, code, 100*actual, r)
+			return
+		}
+		t.LogSuccessf("%.1f%% of the requests received code %s, which is %s", 100*r.Ratio(code), code, description)
+	}
+}
+
+// NoConnectionErrors checks that all requests received a response
+func NoConnectionErrors() Check {
+	return func(t test.TestHelper, r *Result) {
+		t.T().Helper()
+		if r.ConnectionErrors > 0 {
+			t.Errorf("expected all requests to receive a response, but %d/%d failed (%s)", r.ConnectionErrors, r.Requests(), r)
+			return
+		}
+		t.LogSuccessf("all %d requests received a response", r.Requests())
+	}
+}
+
+// P50Below checks that the median latency is below the limit
+func P50Below(limit time.Duration) Check {
+	return PercentileBelow(50, limit)
+}
+
+// P99Below checks that 99% of the requests completed within the limit
+func P99Below(limit time.Duration) Check {
+	return PercentileBelow(99, limit)
+}
+
+// PercentileBelow checks that the percentage of requests (e.g. 90) completed within the limit
+func PercentileBelow(percentile float64, limit time.Duration) Check {
+	return comparePercentile(percentile, "below", limit, func(latency time.Duration) bool {
+		return latency < limit
+	})
+}
+
+// PercentileAbove checks that the latency of the percentile is above the limit, e.g. that the median is above
+// an injected delay
+func PercentileAbove(percentile float64, limit time.Duration) Check {
+	return comparePercentile(percentile, "above", limit, func(latency time.Duration) bool {
+		return latency > limit
+	})
+}
+
+func comparePercentile(percentile float64, description string, limit time.Duration, matches func(latency time.Duration) bool) Check {
+	return func(t test.TestHelper, r *Result) {
+		t.T().Helper()
+		if latency := r.Percentile(percentile); !matches(latency) {
+			t.Errorf("expected the p%v latency to be %s %v, got %v (%s)", percentile, description, limit, roundLatency(latency), r)
+			return
+		}
+		t.LogSuccessf("p%v latency %v is %s %v", percentile, roundLatency(r.Percentile(percentile)), description, limit)
+	}
+}
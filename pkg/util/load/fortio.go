@@ -0,0 +1,111 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// fortioConnectionError is the code under which fortio counts HTTP requests that failed without a response
+const fortioConnectionError = "-1"
+
+// FromFortio runs "fortio load" against the URL in the container of the client pod (e.g. the fortio app), so that
+// the requests go through the client's sidecar. The flags are passed to fortio as they are (e.g. "-resolve", ip).
+func FromFortio(client oc.PodLocatorFunc, container, url string, flags ...string) Generator {
+	return fortioGenerator(client, container, url, flags)
+}
+
+// FromFortioGRPC sends gRPC ping requests with "fortio load -grpc -ping" from the container of the client pod to
+// the fortio gRPC server at the destination (e.g. "fortio:8079"). The codes of the result are the serving statuses.
+func FromFortioGRPC(client oc.PodLocatorFunc, container, destination string, flags ...string) Generator {
+	return fortioGenerator(client, container, destination, append([]string{"-grpc", "-ping"}, flags...))
+}
+
+func fortioGenerator(client oc.PodLocatorFunc, container, target string, flags []string) Generator {
+	return func(t test.TestHelper, options LoadOptions) *Result {
+		t.T().Helper()
+		output := oc.Exec(t, client, container, fortioCommand(options, target, flags))
+		result, err := parseFortioResult(output)
+		if err != nil {
+			t.Fatalf("could not parse the output of fortio: %v\n%s", err, output)
+		}
+		return result
+	}
+}
+
+func fortioCommand(options LoadOptions, target string, flags []string) string {
+	args := []string{"/usr/bin/fortio", "load", "-json", "-", "-loglevel", "Error",
+		"-c", fmt.Sprint(max(options.connections, 1)),
+		"-n", fmt.Sprint(options.requests),
+		"-qps", fmt.Sprint(options.qps)}
+	if options.timeout > 0 {
+		args = append(args, "-timeout", options.timeout.String())
+	}
+	args = append(args, flags...)
+	return strings.Join(append(args, target), " ")
+}
+
+// fortioResult is the part of fortio's JSON result that Result is made of
+type fortioResult struct {
+	ActualDuration    time.Duration
+	DurationHistogram struct {
+		Data []struct {
+			// Start and End are in seconds
+			Start float64
+			End   float64
+			Count int
+		}
+	}
+	RetCodes map[string]int
+}
+
+// parseFortioResult parses the JSON result that "fortio load -json -" prints to stdout. The output may also contain
+// log messages, which fortio prints before the result.
+func parseFortioResult(output string) (*Result, error) {
+	start := strings.Index(output, "\n{")
+	if strings.HasPrefix(output, "{") {
+		start = 0
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("no JSON result found")
+	}
+	var fr fortioResult
+	if err := json.NewDecoder(strings.NewReader(output[start:])).Decode(&fr); err != nil {
+		return nil, err
+	}
+
+	result := &Result{Codes: map[string]int{}, Duration: fr.ActualDuration}
+	for code, count := range fr.RetCodes {
+		if code == fortioConnectionError {
+			result.ConnectionErrors += count
+		} else {
+			result.Codes[code] += count
+		}
+	}
+	for _, b := range fr.DurationHistogram.Data {
+		result.Latencies = append(result.Latencies, Bucket{
+			Start: time.Duration(b.Start * float64(time.Second)),
+			End:   time.Duration(b.End * float64(time.Second)),
+			Count: b.Count,
+		})
+	}
+	return result, nil
+}
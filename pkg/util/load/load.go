@@ -0,0 +1,189 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package load generates HTTP and gRPC load, either from the test runner or with the fortio app in the mesh,
+// and asserts on the status codes and latencies of the responses, e.g. in circuit breaker, timeout, retry
+// and rate limit tests.
+package load
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// Generator sends the requests described by the options and returns the result
+type Generator func(t test.TestHelper, options LoadOptions) *Result
+
+// Result is the outcome of a load run
+type Result struct {
+	// Codes is the number of responses by code: the HTTP status code (e.g. "503") or, for gRPC, the serving
+	// status (e.g. "SERVING") or the error that fortio reports
+	Codes map[string]int
+	// ConnectionErrors is the number of requests that failed without a response
+	ConnectionErrors int
+	// Duration is how long it took to send all requests
+	Duration time.Duration
+	// Latencies is the histogram of the request durations, including failed requests
+	Latencies []Bucket
+}
+
+// Bucket is a bucket of the latency histogram with the number of requests that took between Start and End
+type Bucket struct {
+	Start time.Duration
+	End   time.Duration
+	Count int
+}
+
+type LoadOptions struct {
+	requests    int
+	connections int
+	qps         float64
+	timeout     time.Duration
+}
+
+var defaultOptions = LoadOptions{
+	requests:    100,
+	connections: 1,
+}
+
+func Options() LoadOptions {
+	return defaultOptions
+}
+
+// Requests sets the total number of requests (100 by default)
+func (o LoadOptions) Requests(requests int) LoadOptions {
+	o.requests = requests
+	return o
+}
+
+// Connections sets the number of connections that send requests concurrently (1 by default)
+func (o LoadOptions) Connections(connections int) LoadOptions {
+	o.connections = connections
+	return o
+}
+
+// QPS limits the rate of all connections together to the number of requests per second (unlimited by default)
+func (o LoadOptions) QPS(qps float64) LoadOptions {
+	o.qps = qps
+	return o
+}
+
+// Timeout sets the timeout of each request, after which it counts as a connection error
+func (o LoadOptions) Timeout(timeout time.Duration) LoadOptions {
+	o.timeout = timeout
+	return o
+}
+
+// Run generates the load, logs the result and runs the checks on it
+func Run(t test.TestHelper, generate Generator, options LoadOptions, checks ...Check) *Result {
+	t.T().Helper()
+	t.Logf("Send %d requests with %d connections", options.requests, options.connections)
+	result := generate(t, options)
+	t.Logf("Load result: %s", result)
+	for _, check := range checks {
+		check(t, result)
+	}
+	return result
+}
+
+// Requests returns the number of requests, including those that failed without a response
+func (r *Result) Requests() int {
+	requests := r.ConnectionErrors
+	for _, count := range r.Codes {
+		requests += count
+	}
+	return requests
+}
+
+// Ratio returns the share of the requests that received a response with the code
+func (r *Result) Ratio(code string) float64 {
+	if r.Requests() == 0 {
+		return 0
+	}
+	return float64(r.Codes[code]) / float64(r.Requests())
+}
+
+// Percentile returns the latency below which the percentage of requests (e.g. 99) completed. Within a bucket of
+// the histogram, the latencies are assumed to be distributed uniformly.
+func (r *Result) Percentile(percentile float64) time.Duration {
+	total := 0
+	for _, b := range r.Latencies {
+		total += b.Count
+	}
+	if total == 0 {
+		return 0
+	}
+	target := percentile / 100 * float64(total)
+	cumulative := 0.0
+	for _, b := range r.Latencies {
+		if b.Count > 0 && cumulative+float64(b.Count) >= target {
+			fraction := (target - cumulative) / float64(b.Count)
+			return b.Start + time.Duration(fraction*float64(b.End-b.Start))
+		}
+		cumulative += float64(b.Count)
+	}
+	return r.Latencies[len(r.Latencies)-1].End
+}
+
+// QPS returns the actual number of requests per second
+func (r *Result) QPS() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Requests()) / r.Duration.Seconds()
+}
+
+func (r *Result) String() string {
+	var codes []string
+	for code := range r.Codes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	var summary []string
+	for _, code := range codes {
+		summary = append(summary, fmt.Sprintf("%s: %d (%.1f%%)", code, r.Codes[code], 100*r.Ratio(code)))
+	}
+	if r.ConnectionErrors > 0 {
+		summary = append(summary, fmt.Sprintf("connection errors: %d", r.ConnectionErrors))
+	}
+	return fmt.Sprintf("%d requests in %v (%.1f qps), codes %s, latency p50 %v, p90 %v, p99 %v",
+		r.Requests(), r.Duration.Round(time.Millisecond), r.QPS(), strings.Join(summary, ", "),
+		roundLatency(r.Percentile(50)), roundLatency(r.Percentile(90)), roundLatency(r.Percentile(99)))
+}
+
+func roundLatency(d time.Duration) time.Duration {
+	if d > time.Second {
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(10 * time.Microsecond)
+}
+
+// histogramOf returns the latencies as a histogram with a bucket for each distinct latency
+func histogramOf(latencies []time.Duration) []Bucket {
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var buckets []Bucket
+	for _, latency := range sorted {
+		if n := len(buckets); n > 0 && buckets[n-1].Start == latency {
+			buckets[n-1].Count++
+			continue
+		}
+		buckets = append(buckets, Bucket{Start: latency, End: latency, Count: 1})
+	}
+	return buckets
+}
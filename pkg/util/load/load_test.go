@@ -0,0 +1,181 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// fortioOutput is the output of "fortio load -json - -loglevel Error -c 2 -n 50 -qps 0 http://httpbin:8000/get"
+// against a tripped circuit breaker, shortened to the fields that the package reads, with a log line before the result
+const fortioOutput = `Fortio 1.60.3 running at 0 queries per second, 8->8 procs, for 50 calls: http://httpbin:8000/get
+{
+  "RunType": "HTTP",
+  "Labels": "",
+  "RequestedQPS": "max",
+  "RequestedDuration": "exactly 50 calls",
+  "ActualQPS": 250.1,
+  "ActualDuration": 199920000,
+  "NumThreads": 2,
+  "DurationHistogram": {
+    "Count": 50,
+    "Min": 0.001,
+    "Max": 0.05,
+    "Data": [
+      {"Start": 0.001, "End": 0.002, "Percent": 40, "Count": 20},
+      {"Start": 0.002, "End": 0.01, "Percent": 90, "Count": 25},
+      {"Start": 0.01, "End": 0.05, "Percent": 100, "Count": 5}
+    ],
+    "Percentiles": [
+      {"Percentile": 50, "Value": 0.0036},
+      {"Percentile": 99, "Value": 0.046}
+    ]
+  },
+  "Exactly": 50,
+  "RetCodes": {"200": 40, "503": 8, "-1": 2}
+}
+`
+
+func TestFromFortio(t *testing.T) {
+	th := test.NewTestHelper(t)
+	fake := shell.NewFakeExecutor()
+	defer shell.SetExecutor(fake)()
+	fake.OnPattern(`-- /usr/bin/fortio load -json - -loglevel Error -c 2 -n 50 -qps 0 -resolve 10\.0\.0\.1 http://httpbin:8000/get$`).
+		Return(fortioOutput)
+
+	client := func(t test.TestHelper, _ *oc.OC) oc.NamespacedName {
+		return oc.NewNamespacedName("foo", "fortio-1")
+	}
+	result := FromFortio(client, "fortio", "http://httpbin:8000/get", "-resolve", "10.0.0.1")(th, Options().Requests(50).Connections(2))
+
+	if !reflect.DeepEqual(result.Codes, map[string]int{"200": 40, "503": 8}) || result.ConnectionErrors != 2 {
+		t.Errorf("unexpected codes %v and connection errors %d", result.Codes, result.ConnectionErrors)
+	}
+	if result.Requests() != 50 || result.Duration != 199920*time.Microsecond {
+		t.Errorf("unexpected number of requests %d or duration %v", result.Requests(), result.Duration)
+	}
+	if len(result.Latencies) != 3 || result.Latencies[1] != (Bucket{Start: 2 * time.Millisecond, End: 10 * time.Millisecond, Count: 25}) {
+		t.Errorf("unexpected latencies %v", result.Latencies)
+	}
+	// the median is the 5th of the 25 requests in the second bucket
+	if p50 := result.Percentile(50); p50 != 3600*time.Microsecond {
+		t.Errorf("expected p50 of 3.6ms, got %v", p50)
+	}
+}
+
+func TestFortioCommand(t *testing.T) {
+	command := fortioCommand(Options().Requests(10).QPS(2.5).Timeout(500*time.Millisecond), "fortio:8079", []string{"-grpc", "-ping"})
+	expected := "/usr/bin/fortio load -json - -loglevel Error -c 1 -n 10 -qps 2.5 -timeout 500ms -grpc -ping fortio:8079"
+	if command != expected {
+		t.Errorf("expected command %q, got %q", expected, command)
+	}
+
+	if _, err := parseFortioResult("Error: unable to connect"); err == nil {
+		t.Error("expected an error for output without a JSON result")
+	}
+}
+
+func TestFromRunner(t *testing.T) {
+	th := test.NewTestHelper(t)
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1)%4 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		time.Sleep(2 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	result := FromRunner(server.URL, nil)(th, Options().Requests(40).Connections(4))
+	if !reflect.DeepEqual(result.Codes, map[string]int{"200": 30, "503": 10}) || result.ConnectionErrors != 0 {
+		t.Errorf("unexpected codes %v and connection errors %d", result.Codes, result.ConnectionErrors)
+	}
+	if p90 := result.Percentile(90); p90 < 2*time.Millisecond {
+		t.Errorf("expected p90 of at least 2ms, got %v", p90)
+	}
+
+	start := time.Now()
+	result = FromRunner(server.URL, nil)(th, Options().Requests(5).Connections(5).QPS(50))
+	if elapsed := time.Since(start); result.Requests() != 5 || elapsed < 80*time.Millisecond {
+		t.Errorf("expected 5 requests at 50 qps to take at least 80ms, got %d requests in %v", result.Requests(), elapsed)
+	}
+
+	server.Close()
+	result = FromRunner(server.URL, nil)(th, Options().Requests(3))
+	if result.ConnectionErrors != 3 || len(result.Codes) != 0 {
+		t.Errorf("expected 3 connection errors, got %s", result)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	ms := time.Millisecond
+	result := &Result{Latencies: histogramOf([]time.Duration{5 * ms, 1 * ms, 2 * ms, 2 * ms, 100 * ms})}
+	if !reflect.DeepEqual(result.Latencies, []Bucket{{ms, ms, 1}, {2 * ms, 2 * ms, 2}, {5 * ms, 5 * ms, 1}, {100 * ms, 100 * ms, 1}}) {
+		t.Errorf("unexpected histogram %v", result.Latencies)
+	}
+	for percentile, expected := range map[float64]time.Duration{0: ms, 20: ms, 50: 2 * ms, 80: 5 * ms, 99: 100 * ms, 100: 100 * ms} {
+		if actual := result.Percentile(percentile); actual != expected {
+			t.Errorf("expected p%v to be %v, got %v", percentile, expected, actual)
+		}
+	}
+	if p := (&Result{}).Percentile(99); p != 0 {
+		t.Errorf("expected p99 of an empty result to be 0, got %v", p)
+	}
+}
+
+func TestChecks(t *testing.T) {
+	result := &Result{
+		Codes:            map[string]int{"200": 90, "503": 8},
+		ConnectionErrors: 2,
+		Latencies:        []Bucket{{Start: 0, End: 10 * time.Millisecond, Count: 98}, {Start: time.Second, End: time.Second, Count: 2}},
+	}
+	testCases := []struct {
+		name   string
+		check  Check
+		passes bool
+	}{
+		{"503 ratio at least 5%", CodeRatioAtLeast(503, 0.05), true},
+		{"503 ratio at least 10%", CodeRatioAtLeast(503, 0.1), false},
+		{"503 ratio at most 10%", CodeRatioAtMost(503, 0.1), true},
+		{"200 ratio at most 50%", CodeRatioAtMost(200, 0.5), false},
+		{"all 200", AllCodes(200), false},
+		{"connection errors", NoConnectionErrors(), false},
+		{"p50 below 10ms", P50Below(10 * time.Millisecond), true},
+		{"p99 below 100ms", P99Below(100 * time.Millisecond), false},
+		{"p99 above 100ms", PercentileAbove(99, 100*time.Millisecond), true},
+		{"p90 below 1s", PercentileBelow(90, time.Second), true},
+		{"serving", GRPCStatusRatioAtLeast("SERVING", 0.5), false},
+	}
+	th := test.NewTestHelper(t)
+	for _, tc := range testCases {
+		attempt := retry.Attempt(th, func(t test.TestHelper) {
+			tc.check(t, result)
+		})
+		if passed := !attempt.Failed(); passed != tc.passes {
+			t.Errorf("%s: got pass=%v, want %v", tc.name, passed, tc.passes)
+		}
+	}
+}
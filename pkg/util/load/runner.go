@@ -0,0 +1,125 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/curl"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// FromRunner sends HTTP GET requests from the test runner, e.g. to the ingress gateway. Each connection is a
+// separate client, so that the number of connections to the destination matches the option.
+func FromRunner(url string, requestOption curl.RequestOption) Generator {
+	if requestOption == nil {
+		requestOption = curl.NilRequestOption{}
+	}
+	return func(t test.TestHelper, options LoadOptions) *Result {
+		t.T().Helper()
+		if _, err := newRequest(url, requestOption); err != nil {
+			t.Fatalf("failed to create HTTP request: %v", err)
+		}
+		var clients []*http.Client
+		for i := 0; i < max(options.connections, 1); i++ {
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			transport.MaxIdleConnsPerHost = 1
+			client := &http.Client{Transport: transport, Timeout: options.timeout}
+			if err := requestOption.ApplyToClient(client); err != nil {
+				t.Fatalf("failed to modify client: %v", err)
+			}
+			clients = append(clients, client)
+		}
+
+		requests := make(chan struct{})
+		go func() {
+			defer close(requests)
+			var tick <-chan time.Time
+			if options.qps > 0 {
+				ticker := time.NewTicker(time.Duration(float64(time.Second) / options.qps))
+				defer ticker.Stop()
+				tick = ticker.C
+			}
+			for i := 0; i < options.requests; i++ {
+				if tick != nil {
+					<-tick
+				}
+				requests <- struct{}{}
+			}
+		}()
+
+		var mu sync.Mutex
+		result := &Result{Codes: map[string]int{}}
+		var latencies []time.Duration
+
+		start := time.Now()
+		var wg sync.WaitGroup
+		for _, client := range clients {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range requests {
+					code, latency, err := send(client, url, requestOption)
+					mu.Lock()
+					if err != nil {
+						result.ConnectionErrors++
+					} else {
+						result.Codes[strconv.Itoa(code)]++
+					}
+					latencies = append(latencies, latency)
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		result.Duration = time.Since(start)
+		for _, client := range clients {
+			client.CloseIdleConnections()
+		}
+		result.Latencies = histogramOf(latencies)
+		return result
+	}
+}
+
+func newRequest(url string, requestOption curl.RequestOption) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return req, requestOption.ApplyToRequest(req)
+}
+
+// send sends a request and reads the whole response, returning the status code and the duration of the request
+func send(client *http.Client, url string, requestOption curl.RequestOption) (int, time.Duration, error) {
+	req, err := newRequest(url, requestOption)
+	if err != nil {
+		return 0, 0, err
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, time.Since(start), err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	if err != nil {
+		return 0, time.Since(start), err
+	}
+	return resp.StatusCode, time.Since(start), nil
+}
@@ -0,0 +1,318 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/env"
+	"github.com/maistra/maistra-test-tool/pkg/util/ossm3"
+	"github.com/maistra/maistra-test-tool/pkg/util/template"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// templateValues contains a representative value for every variable that the templates of the tests use. The
+// templates are rendered once with all boolean variables set to true and once with all of them set to false.
+var templateValues = map[string]any{
+	"Address":                       "1.2.3.4",
+	"AppLabel":                      "httpbin",
+	"CARootCert":                    "-----BEGIN CERTIFICATE-----\nMIIC\n-----END CERTIFICATE-----\n",
+	"DiscoveryPort":                 8188,
+	"EastMeshRegion":                "us-east",
+	"EastRootCert":                  "-----BEGIN CERTIFICATE-----\nMIIC\n-----END CERTIFICATE-----",
+	"GatewayClassName":              "istio",
+	"HttpProxy":                     "http://proxy:3128",
+	"HttpsProxy":                    "http://proxy:3128",
+	"IngressServiceType":            "LoadBalancer",
+	"InjectSidecar":                 true,
+	"JwksUri":                       "https://example.com/jwks.json",
+	"KialiVersion":                  "v1.89",
+	"LocalEgressName":               "egress-east-mesh",
+	"LocalIngressName":              "ingress-east-mesh",
+	"Member":                        "bookinfo",
+	"MeshNs":                        "istio-system",
+	"Name":                          "basic",
+	"Namespace":                     "istio-system",
+	"NoProxy":                       "localhost",
+	"Ns":                            "bookinfo",
+	"OtelNamespace":                 "opentelemetrycollector",
+	"PeerEgressSA":                  "west-mesh-egress-service-account",
+	"PeerTrustDomain":               "west-mesh.local",
+	"Region":                        "us-east",
+	"ReviewV2Podname":               "reviews-v2-0",
+	"Revision":                      "basic",
+	"Revisions":                     `"basic"`,
+	"ServicePort":                   8188,
+	"SmcpNamespace":                 "istio-system",
+	"TracingNamespace":              "tracing-system",
+	"TracingType":                   "None",
+	"Version":                       "v2.6",
+	"WestMeshRegion":                "us-west",
+	"WestRootCert":                  "-----BEGIN CERTIFICATE-----\nMIIC\n-----END CERTIFICATE-----",
+	"Zone":                          "us-east-1a",
+	"host":                          "example.com",
+	"minioRoute":                    "minio.example.com",
+	"namespace":                     "bookinfo",
+	"runAsGroup":                    1000,
+	"runAsUser":                     1000,
+	"serviceName":                   "httpbin",
+	"targetPort":                    8000,
+	"ApplyWasmPluginsToInboundOnly": true,
+	"ClusterWideCp":                 true,
+	"ClusterWideProxy":              true,
+	"Enabled":                       true,
+	"FowardToken":                   true,
+	"NativeSidecarsEnabled":         true,
+	"PortLevelSettings":             true,
+	"Rosa":                          true,
+	"ServerMode":                    true,
+	"Subset":                        true,
+	"Tproxy":                        true,
+	"manageNetworkPolicy":           true,
+	"securityContext":               true,
+	"tlsTermination":                true,
+}
+
+// unvalidatedKinds are the kinds that the manifests of the repository use, but whose schemas aren't embedded in the
+// default validator. A kind that isn't listed fails TestEmbeddedManifests, so that a new kind gets a schema (see crds)
+// or is added here deliberately.
+var unvalidatedKinds = map[string]bool{
+	// OpenShift and OLM APIs
+	"config.openshift.io/v1/ClusterVersion":                   true,
+	"config.openshift.io/v1/Proxy":                            true,
+	"operator.openshift.io/v1alpha1/ImageContentSourcePolicy": true,
+	"operators.coreos.com/v1/OperatorGroup":                   true,
+	"operators.coreos.com/v1alpha1/CatalogSource":             true,
+	"operators.coreos.com/v1alpha1/Subscription":              true,
+	"route.openshift.io/v1/Route":                             true,
+	"security.openshift.io/v1/SecurityContextConstraints":     true,
+
+	// operators that some tests install
+	"cert-manager.io/v1/Certificate":                   true,
+	"cert-manager.io/v1/ClusterIssuer":                 true,
+	"cert-manager.io/v1/Issuer":                        true,
+	"kiali.io/v1alpha1/Kiali":                          true,
+	"metallb.io/v1beta1/IPAddressPool":                 true,
+	"metallb.io/v1beta1/MetalLB":                       true,
+	"monitoring.coreos.com/v1/PodMonitor":              true,
+	"monitoring.coreos.com/v1/ServiceMonitor":          true,
+	"opentelemetry.io/v1alpha1/OpenTelemetryCollector": true,
+	"tempo.grafana.com/v1alpha1/TempoStack":            true,
+
+	// Istio and Maistra APIs that aren't in the embedded CRDs
+	"extensions.istio.io/v1alpha1/WasmPlugin":     true,
+	"federation.maistra.io/v1/ExportedServiceSet": true,
+	"federation.maistra.io/v1/ImportedServiceSet": true,
+	"federation.maistra.io/v1/ServiceMeshPeer":    true,
+	"telemetry.istio.io/v1alpha1/Telemetry":       true,
+
+	// applied by the oc package tests to make an apply fail
+	"example.com/v1/Unknown": true,
+}
+
+var (
+	// apiVersion and kind match the type of a resource, which excludes e.g. the Helm values files and the OpenShift
+	// install config
+	apiVersion = regexp.MustCompile(`(?m)^apiVersion:`)
+	kind       = regexp.MustCompile(`(?m)^kind:`)
+	// yamlStart matches the start of a string that only contains YAML, which excludes e.g. the shell commands
+	// that pipe a manifest to `oc apply`
+	yamlStart = regexp.MustCompile(`^(---|#|[A-Za-z]+:)`)
+	// printfVerb matches the verbs of the manifests that are formatted with fmt.Sprintf
+	printfVerb = regexp.MustCompile(`%[-+# 0-9.]*[sdvq]`)
+)
+
+// TestEmbeddedManifests validates the YAML files and the manifests in the Go sources of the repository against the
+// schemas of the default validator, so that an invalid manifest is found without running the tests on a cluster
+func TestEmbeddedManifests(t *testing.T) {
+	root := env.GetRootDir()
+	sources := map[string]string{}
+	addYAMLFiles(t, root, sources)
+	addGoLiterals(t, root, sources)
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	skipped := map[string]bool{}
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			for _, manifests := range render(t, sources[name]) {
+				errs, skippedKinds := DefaultValidator().Validate(manifests)
+				for _, err := range errs {
+					t.Error(err)
+				}
+				for _, kind := range skippedKinds {
+					skipped[kind] = true
+				}
+			}
+		})
+	}
+
+	var unexpected []string
+	for kind := range skipped {
+		if !unvalidatedKinds[kind] {
+			unexpected = append(unexpected, kind)
+		}
+	}
+	sort.Strings(unexpected)
+	if len(unexpected) > 0 {
+		t.Errorf("no schema for kinds %s; add their CRDs to the crds directory or, if they can't be validated, to unvalidatedKinds",
+			strings.Join(unexpected, ", "))
+	}
+	t.Logf("validated %d files and Go strings", len(names))
+}
+
+func TestBuilderManifests(t *testing.T) {
+	istio := ossm3.DefaultIstio().
+		WithUpdateStrategy(ossm3.RevisionBased).
+		WithValues(map[string]any{"pilot": map[string]any{"env": map[string]any{"PILOT_ENABLE_STATUS": "true"}}})
+	istio.InactiveRevisionDeletionGracePeriod = time.Minute
+
+	for _, manifests := range []string{istio.Manifest(), ossm3.DefaultIstioCNI().Manifest(), ossm3.DefaultZTunnel().Manifest()} {
+		errs, skipped := DefaultValidator().Validate(manifests)
+		for _, err := range errs {
+			t.Error(err)
+		}
+		if len(skipped) > 0 {
+			t.Errorf("unexpected kinds without schema: %v", skipped)
+		}
+	}
+}
+
+// addYAMLFiles adds the YAML files of the pkg and templates directories that contain resources
+func addYAMLFiles(t *testing.T, root string, sources map[string]string) {
+	for _, dir := range []string{"pkg", "templates"} {
+		err := filepath.WalkDir(filepath.Join(root, dir), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path == filepath.Join(root, "pkg", "util", "manifest") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if ext := filepath.Ext(path); ext != ".yaml" && ext != ".yml" {
+				return nil
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if apiVersion.Match(data) && kind.Match(data) {
+				sources[relative(root, path)] = string(data)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// addGoLiterals adds the string literals of the Go sources in the pkg directory that contain a resource
+func addGoLiterals(t *testing.T, root string, sources map[string]string) {
+	err := filepath.WalkDir(filepath.Join(root, "pkg"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && path == filepath.Join(root, "pkg", "util", "manifest") {
+			// the tests of this package contain invalid manifests on purpose
+			return filepath.SkipDir
+		}
+		if d.IsDir() || filepath.Ext(path) != ".go" {
+			return nil
+		}
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			lit, ok := node.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			value, err := strconv.Unquote(lit.Value)
+			value = strings.TrimSpace(value)
+			if err == nil && apiVersion.MatchString(value) && kind.MatchString(value) && yamlStart.MatchString(value) {
+				position := fset.Position(lit.Pos())
+				sources[relative(root, path)+":"+strconv.Itoa(position.Line)] = printfVerb.ReplaceAllStringFunc(value, sampleArgument)
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// render returns the manifests of the source, which is rendered with both values of the boolean variables if it is
+// a template
+func render(t *testing.T, source string) []string {
+	if !strings.Contains(source, "{{") {
+		return []string{source}
+	}
+	var rendered []string
+	for _, enabled := range []bool{true, false} {
+		values := map[string]any{}
+		for name, value := range templateValues {
+			if _, isBool := value.(bool); isBool {
+				value = enabled
+			}
+			values[name] = value
+		}
+		manifests := template.Run(test.NewTestHelper(t), source, values)
+		if strings.Contains(manifests, "<no value>") {
+			t.Fatalf("the template uses a variable that is missing in templateValues:\n%s", manifests)
+		}
+		rendered = append(rendered, manifests)
+	}
+	return rendered
+}
+
+// sampleArgument returns a representative argument for a printf verb, e.g. a namespace name for %s
+func sampleArgument(verb string) string {
+	switch verb[len(verb)-1] {
+	case 'd':
+		return "1"
+	case 'q':
+		return `"sample"`
+	}
+	return "sample"
+}
+
+func relative(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
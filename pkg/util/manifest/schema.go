@@ -0,0 +1,258 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Schema is the subset of a structural OpenAPI v3 schema, as used in the openAPIV3Schema of a CRD, that is needed
+// to find unknown fields and type errors. Formats, patterns and value ranges are not checked.
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+
+	// PreserveUnknownFields allows fields that aren't declared in Properties, e.g. in the Istio values of an SMCP
+	PreserveUnknownFields bool `json:"x-kubernetes-preserve-unknown-fields,omitempty"`
+	// IntOrString allows an integer or a string, e.g. for the target port of a service
+	IntOrString bool `json:"x-kubernetes-int-or-string,omitempty"`
+}
+
+// FieldError is an error at a path of a resource, e.g. "spec.http[0].route[0].weight"
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Validate returns the unknown fields, type errors, invalid enum values and missing required fields of the value,
+// which must be the result of unmarshalling YAML or JSON (e.g. with sigs.k8s.io/yaml). Null values are valid
+// everywhere, because the API server drops them.
+func (s *Schema) Validate(value any) []error {
+	var errs []error
+	s.validate("", value, &errs)
+	return errs
+}
+
+func (s *Schema) validate(path string, value any, errs *[]error) {
+	if s == nil || value == nil {
+		return
+	}
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, &FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.IntOrString {
+		if _, ok := value.(string); !ok && !isInteger(value) {
+			fail("expected integer or string, got %s", typeOf(value))
+		}
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			fail("expected object, got %s", typeOf(value))
+			return
+		}
+		s.validateObject(path, obj, errs)
+		return
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			fail("expected array, got %s", typeOf(value))
+			return
+		}
+		for i, item := range arr {
+			s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+		}
+		return
+	case "string":
+		if _, ok := value.(string); !ok {
+			fail("expected string, got %s", typeOf(value))
+			return
+		}
+	case "integer":
+		if !isInteger(value) {
+			fail("expected integer, got %s", typeOf(value))
+			return
+		}
+	case "number":
+		if _, ok := toFloat(value); !ok {
+			fail("expected number, got %s", typeOf(value))
+			return
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("expected boolean, got %s", typeOf(value))
+			return
+		}
+	case "":
+		// an untyped schema (e.g. for a field of type interface{}) accepts any value, but an object may still
+		// declare properties
+		if obj, ok := value.(map[string]any); ok && len(s.Properties) > 0 {
+			s.validateObject(path, obj, errs)
+		}
+		return
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return equal(e, value) }) {
+		fail("unsupported value %v, expected one of %s", value, enumString(s.Enum))
+	}
+}
+
+func (s *Schema) validateObject(path string, obj map[string]any, errs *[]error) {
+	for _, name := range s.Required {
+		if _, found := obj[name]; !found {
+			*errs = append(*errs, &FieldError{Path: join(path, name), Message: "required field is missing"})
+		}
+	}
+
+	// sort the fields, so that the errors are reported in a stable order
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fieldPath := join(path, name)
+		if property, found := s.Properties[name]; found {
+			property.validate(fieldPath, obj[name], errs)
+		} else if s.AdditionalProperties != nil {
+			s.AdditionalProperties.validate(fieldPath, obj[name], errs)
+		} else if !s.PreserveUnknownFields {
+			*errs = append(*errs, &FieldError{Path: fieldPath, Message: "unknown field" + s.suggestion(name)})
+		}
+	}
+}
+
+// suggestion returns a hint for a misspelled field, e.g. `, did you mean "subset"?` for "subnet". The closest
+// property within an edit distance of two is suggested, ignoring the case.
+func (s *Schema) suggestion(name string) string {
+	best, bestDistance := "", 3
+	for property := range s.Properties {
+		d := distance(strings.ToLower(property), strings.ToLower(name))
+		if d < bestDistance || (d == bestDistance && property < best) {
+			best, bestDistance = property, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %q?", best)
+}
+
+// distance returns the Levenshtein distance of the strings
+func distance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func isInteger(value any) bool {
+	f, ok := toFloat(value)
+	return ok && f == math.Trunc(f)
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func equal(a, b any) bool {
+	fa, aIsNumber := toFloat(a)
+	fb, bIsNumber := toFloat(b)
+	if aIsNumber && bIsNumber {
+		return fa == fb
+	}
+	return a == b
+}
+
+func typeOf(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+	if isInteger(value) {
+		return "integer"
+	}
+	if _, ok := toFloat(value); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func enumString(enum []any) string {
+	values := make([]string, len(enum))
+	for i, e := range enum {
+		values[i] = fmt.Sprint(e)
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// openAPITypes is implemented by the Kubernetes types that have a custom JSON encoding, e.g. metav1.Time
+// and intstr.IntOrString
+type openAPITypes interface {
+	OpenAPISchemaType() []string
+	OpenAPISchemaFormat() string
+}
+
+// openAPIOneOfTypes is implemented by the Kubernetes types that accept several JSON types, e.g. resource.Quantity
+type openAPIOneOfTypes interface {
+	OpenAPIV3OneOfTypes() []string
+}
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	openAPIType     = reflect.TypeOf((*openAPITypes)(nil)).Elem()
+	oneOfType       = reflect.TypeOf((*openAPIOneOfTypes)(nil)).Elem()
+)
+
+// SchemaForType returns the schema of the JSON encoding of the Go type, e.g. of corev1.Service, which is used to
+// validate the resources of the Kubernetes API without bundling their OpenAPI schemas
+func SchemaForType(t reflect.Type) *Schema {
+	return schemaForType(t, map[reflect.Type]*Schema{})
+}
+
+func schemaForType(t reflect.Type, seen map[reflect.Type]*Schema) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if s, found := seen[t]; found {
+		return s
+	}
+
+	if s, custom := schemaForCustomType(t); custom {
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		// register the schema before resolving the fields, so that recursive types end up in a cycle
+		seen[t] = s
+		addFields(s, t, seen)
+		return s
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaForType(t.Elem(), seen)}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte is encoded in base64
+			return &Schema{Type: "string"}
+		}
+		return &Schema{Type: "array", Items: schemaForType(t.Elem(), seen)}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	}
+	// interface{} and other types accept any value
+	return &Schema{}
+}
+
+// schemaForCustomType returns the schema of a type that implements json.Unmarshaler, using its OpenAPI types if it
+// declares them, or a schema that accepts any value otherwise
+func schemaForCustomType(t reflect.Type) (*Schema, bool) {
+	ptr := reflect.PointerTo(t)
+	if !t.Implements(unmarshalerType) && !ptr.Implements(unmarshalerType) {
+		return nil, false
+	}
+
+	var types []string
+	if t.Implements(openAPIType) || ptr.Implements(openAPIType) {
+		v := reflect.New(t).Interface().(openAPITypes)
+		if v.OpenAPISchemaFormat() == "int-or-string" {
+			return &Schema{IntOrString: true}, true
+		}
+		types = v.OpenAPISchemaType()
+	}
+	if t.Implements(oneOfType) || ptr.Implements(oneOfType) {
+		if oneOf := reflect.New(t).Interface().(openAPIOneOfTypes).OpenAPIV3OneOfTypes(); len(oneOf) > 1 {
+			return &Schema{}, true
+		}
+	}
+	if len(types) == 1 {
+		return &Schema{Type: types[0]}, true
+	}
+	return &Schema{PreserveUnknownFields: true}, true
+}
+
+func addFields(s *Schema, t reflect.Type, seen map[reflect.Type]*Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+
+		inline := strings.Contains(options, "inline") || (field.Anonymous && name == "")
+		if inline {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addFields(s, embedded, seen)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		s.Properties[name] = schemaForType(field.Type, seen)
+	}
+}
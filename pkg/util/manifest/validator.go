@@ -0,0 +1,243 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package manifest validates YAML manifests without a cluster, so that typos and misplaced fields in the manifests
+// of the tests are found before `oc apply` fails. The Kubernetes kinds are validated against the schema of their Go
+// types and the custom resources (SMCP/SMMR/SMM, Istio networking and security, Gateway API and the Sail operator)
+// against the OpenAPI schemas of the CRDs in the crds directory. E.g.
+//
+//	errs, skipped := manifest.DefaultValidator().Validate(yaml)
+package manifest
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// crds contains trimmed copies of the CRDs of the custom resources that the tests create. They only contain the
+// served versions and the fields of the specs, and the status of each resource accepts any fields.
+//
+//go:embed crds/*.yaml
+var crds embed.FS
+
+// Validator validates manifests against the schemas of the kinds it knows
+type Validator struct {
+	schemas map[schema.GroupVersionKind]*Schema
+}
+
+// NewValidator returns a validator without schemas
+func NewValidator() *Validator {
+	return &Validator{schemas: map[schema.GroupVersionKind]*Schema{}}
+}
+
+var defaultValidator = sync.OnceValue(func() *Validator {
+	v := NewValidator()
+	for gvk, t := range scheme.Scheme.AllKnownTypes() {
+		if gvk.Version != "__internal" {
+			v.AddType(gvk, t)
+		}
+	}
+	files, err := fs.Glob(crds, "crds/*.yaml")
+	if err != nil {
+		panic(err)
+	}
+	for _, file := range files {
+		data, err := crds.ReadFile(file)
+		if err == nil {
+			err = v.AddCRDs(string(data))
+		}
+		if err != nil {
+			panic(fmt.Sprintf("could not load %s: %v", file, err))
+		}
+	}
+	return v
+})
+
+// DefaultValidator returns a validator for the Kubernetes kinds of client-go and the bundled CRDs
+func DefaultValidator() *Validator {
+	return defaultValidator()
+}
+
+// AddType adds the schema of the Go type (e.g. appsv1.Deployment) for the kind
+func (v *Validator) AddType(gvk schema.GroupVersionKind, t reflect.Type) {
+	v.schemas[gvk] = SchemaForType(t)
+}
+
+// AddSchema adds the OpenAPI schema of a custom resource for the kind. The metadata is validated as ObjectMeta.
+func (v *Validator) AddSchema(gvk schema.GroupVersionKind, s *Schema) {
+	root := *s
+	root.Type = "object"
+	root.Properties = map[string]*Schema{
+		"apiVersion": {Type: "string"},
+		"kind":       {Type: "string"},
+		"metadata":   SchemaForType(reflect.TypeOf(metav1.ObjectMeta{})),
+	}
+	for name, property := range s.Properties {
+		if name != "metadata" {
+			root.Properties[name] = property
+		}
+	}
+	v.schemas[gvk] = &root
+}
+
+// customResourceDefinition contains the fields of a CRD that the bundled CRDs use, so that they can be decoded
+// strictly and a typo in a schema isn't ignored
+type customResourceDefinition struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Group string `json:"group"`
+		Names struct {
+			Kind       string   `json:"kind"`
+			ListKind   string   `json:"listKind"`
+			Plural     string   `json:"plural"`
+			Singular   string   `json:"singular"`
+			ShortNames []string `json:"shortNames"`
+		} `json:"names"`
+		Scope    string `json:"scope"`
+		Versions []struct {
+			Name         string         `json:"name"`
+			Served       bool           `json:"served"`
+			Storage      bool           `json:"storage"`
+			Subresources map[string]any `json:"subresources"`
+			Schema       struct {
+				OpenAPIV3Schema *Schema `json:"openAPIV3Schema"`
+			} `json:"schema"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+// AddCRDs adds the schemas of all versions of the CustomResourceDefinitions in the YAML stream
+func (v *Validator) AddCRDs(manifests string) error {
+	docs, err := splitDocuments(manifests)
+	if err != nil {
+		return err
+	}
+	for _, doc := range docs {
+		var crd customResourceDefinition
+		if err := yaml.UnmarshalStrict([]byte(doc), &crd); err != nil {
+			return err
+		}
+		if crd.Kind == "" && crd.Spec.Group == "" {
+			// a document that only contains comments
+			continue
+		}
+		if crd.Kind != "CustomResourceDefinition" {
+			return fmt.Errorf("expected a CustomResourceDefinition, got %q", crd.Kind)
+		}
+		for _, version := range crd.Spec.Versions {
+			if version.Schema.OpenAPIV3Schema == nil {
+				return fmt.Errorf("version %s of %s has no schema", version.Name, crd.Metadata.Name)
+			}
+			gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind}
+			v.AddSchema(gvk, version.Schema.OpenAPIV3Schema)
+		}
+	}
+	return nil
+}
+
+// Knows returns whether the validator has a schema for the kind
+func (v *Validator) Knows(gvk schema.GroupVersionKind) bool {
+	_, found := v.schemas[gvk]
+	return found
+}
+
+// Validate validates all resources in the YAML stream and returns the errors, which are prefixed with the kind and
+// name of the resource. The kinds without schema (e.g. the resources of other operators) are returned as skipped,
+// so that the caller can decide whether that's an error.
+func (v *Validator) Validate(manifests string) (errs []error, skipped []string) {
+	docs, err := splitDocuments(manifests)
+	if err != nil {
+		return []error{err}, nil
+	}
+	for i, doc := range docs {
+		var obj map[string]any
+		if err := yaml.UnmarshalStrict([]byte(doc), &obj); err != nil {
+			errs = append(errs, fmt.Errorf("document %d: %v", i, err))
+			continue
+		}
+		if len(obj) == 0 {
+			continue
+		}
+
+		apiVersion, _ := obj["apiVersion"].(string)
+		kind, _ := obj["kind"].(string)
+		if apiVersion == "" || kind == "" {
+			errs = append(errs, fmt.Errorf("document %d: apiVersion and kind are required", i))
+			continue
+		}
+		gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+		s, found := v.schemas[gvk]
+		if !found {
+			skipped = append(skipped, apiVersion+"/"+kind)
+			continue
+		}
+		for _, err := range s.Validate(obj) {
+			errs = append(errs, fmt.Errorf("%s %s: %w", kind, name(obj), err))
+		}
+	}
+	return errs, skipped
+}
+
+// Kinds returns the kinds that the validator knows, sorted by apiVersion and kind
+func (v *Validator) Kinds() []schema.GroupVersionKind {
+	var kinds []schema.GroupVersionKind
+	for gvk := range v.schemas {
+		kinds = append(kinds, gvk)
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		return kinds[i].GroupVersion().String()+"/"+kinds[i].Kind < kinds[j].GroupVersion().String()+"/"+kinds[j].Kind
+	})
+	return kinds
+}
+
+func name(obj map[string]any) string {
+	metadata, _ := obj["metadata"].(map[string]any)
+	if n, ok := metadata["name"].(string); ok {
+		return n
+	}
+	return "<unnamed>"
+}
+
+func splitDocuments(manifests string) ([]string, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(strings.NewReader(manifests)))
+	var docs []string
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, string(doc))
+	}
+}
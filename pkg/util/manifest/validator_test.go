@@ -0,0 +1,291 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		manifests string
+		errs      []string
+	}{
+		{
+			name: "valid Kubernetes resources",
+			manifests: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: httpbin
+  labels:
+    app: httpbin
+spec:
+  ports:
+  - name: http
+    port: 8000
+    targetPort: 80
+  - name: https
+    port: 8443
+    targetPort: https
+  selector:
+    app: httpbin
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: httpbin
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: httpbin
+  template:
+    metadata:
+      annotations:
+        sidecar.istio.io/inject: "true"
+      labels:
+        app: httpbin
+    spec:
+      containers:
+      - name: httpbin
+        image: quay.io/maistra/httpbin:0.0
+        resources:
+          limits:
+            cpu: 100m
+            memory: 1Gi
+        env:
+        - name: PORT
+          value: "80"`,
+		},
+		{
+			name: "misspelled and misplaced fields",
+			manifests: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: httpbin
+spec:
+  replica: 1
+  selector:
+    matchLabels:
+      app: httpbin
+  template:
+    spec:
+      containers:
+      - name: httpbin
+        image: httpbin
+        port: 80`,
+			errs: []string{
+				`Deployment httpbin: spec.replica: unknown field, did you mean "replicas"?`,
+				`Deployment httpbin: spec.template.spec.containers[0].port: unknown field, did you mean "ports"?`,
+			},
+		},
+		{
+			name: "type errors",
+			manifests: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: httpbin
+  labels:
+    version: 1
+spec:
+  ports:
+  - port: "8000"
+    targetPort: true
+  selector: httpbin`,
+			errs: []string{
+				"Service httpbin: metadata.labels.version: expected string, got integer",
+				"Service httpbin: spec.ports[0].port: expected integer, got string",
+				"Service httpbin: spec.ports[0].targetPort: expected integer or string, got boolean",
+				"Service httpbin: spec.selector: expected object, got string",
+			},
+		},
+		{
+			name: "custom resources",
+			manifests: `
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: reviews
+spec:
+  hosts:
+  - reviews
+  http:
+  - route:
+    - destination:
+        host: reviews
+        subnet: v1
+      weight: "50"
+---
+apiVersion: security.istio.io/v1
+kind: PeerAuthentication
+metadata:
+  name: default
+spec:
+  mtls:
+    mode: STICT
+---
+apiVersion: maistra.io/v1
+kind: ServiceMeshMember
+metadata:
+  name: default
+  namespace: bookinfo
+spec: {}`,
+			errs: []string{
+				`VirtualService reviews: spec.http[0].route[0].destination.subnet: unknown field, did you mean "subset"?`,
+				"VirtualService reviews: spec.http[0].route[0].weight: expected integer, got string",
+				"PeerAuthentication default: spec.mtls.mode: unsupported value STICT, expected one of [UNSET, DISABLE, PERMISSIVE, STRICT]",
+				"ServiceMeshMember default: spec.controlPlaneRef: required field is missing",
+			},
+		},
+		{
+			name: "preserved unknown fields",
+			manifests: `
+apiVersion: maistra.io/v2
+kind: ServiceMeshControlPlane
+metadata:
+  name: basic
+spec:
+  version: v2.6
+  techPreview:
+    anything:
+      goes: true
+  gateways:
+    ingress:
+      service:
+        externalTrafficPolicy: Local
+        ports:
+        - name: http
+          port: 80
+          targetPort: 8080
+  adons:
+    kiali:
+      enabled: false`,
+			errs: []string{
+				`ServiceMeshControlPlane basic: spec.adons: unknown field, did you mean "addons"?`,
+			},
+		},
+		{
+			name: "missing kind",
+			manifests: `
+apiVersion: v1
+metadata:
+  name: test`,
+			errs: []string{"document 0: apiVersion and kind are required"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs, skipped := DefaultValidator().Validate(tc.manifests)
+			var messages []string
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			if !reflect.DeepEqual(messages, tc.errs) {
+				t.Errorf("unexpected errors:\n got: %q\nwant: %q", messages, tc.errs)
+			}
+			if len(skipped) > 0 {
+				t.Errorf("unexpected skipped kinds: %v", skipped)
+			}
+		})
+	}
+}
+
+func TestValidateSkipsUnknownKinds(t *testing.T) {
+	errs, skipped := DefaultValidator().Validate(`
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: istio-ca
+spec:
+  isCA: true`)
+	if len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if !reflect.DeepEqual(skipped, []string{"cert-manager.io/v1/Certificate"}) {
+		t.Errorf("unexpected skipped kinds: %v", skipped)
+	}
+}
+
+func TestAddCRDs(t *testing.T) {
+	v := NewValidator()
+	err := v.AddCRDs(`
+# only a comment
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: examples.test.io
+spec:
+  group: test.io
+  names:
+    kind: Example
+    listKind: ExampleList
+    plural: examples
+    singular: example
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              size:
+                type: integer`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gvk := schema.GroupVersionKind{Group: "test.io", Version: "v1", Kind: "Example"}
+	if !reflect.DeepEqual(v.Kinds(), []schema.GroupVersionKind{gvk}) {
+		t.Fatalf("unexpected kinds: %v", v.Kinds())
+	}
+	errs, _ := v.Validate(`
+apiVersion: test.io/v1
+kind: Example
+metadata:
+  name: example
+  lables:
+    app: test
+spec:
+  size: 1.5`)
+	want := []string{
+		`Example example: metadata.lables: unknown field, did you mean "labels"?`,
+		"Example example: spec.size: expected integer, got number",
+	}
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("unexpected errors:\n got: %q\nwant: %q", messages, want)
+	}
+
+	if err := v.AddCRDs("apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nspec:\n  gruop: test.io"); err == nil {
+		t.Error("expected an error for an unknown field in the CRD")
+	}
+}
@@ -22,3 +22,14 @@ import (
 func NewNonce() string {
 	return fmt.Sprintf("nonce-%d", rand.Int())
 }
+
+// NewShortNonce returns a random string of lowercase letters and digits that is short enough
+// to be used as a suffix in resource names (e.g. namespaces), which are limited to 63 characters.
+func NewShortNonce() string {
+	const chars = "abcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, 5)
+	for i := range b {
+		b[i] = chars[rand.Intn(len(chars))]
+	}
+	return string(b)
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/maistra/maistra-test-tool/pkg/util"
+	"github.com/maistra/maistra-test-tool/pkg/util/env"
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// maxNameLength is the maximum length of a namespace name (RFC 1123 label)
+const maxNameLength = 63
+
+// Unique creates a namespace named after the given prefix with a random suffix (e.g. "foo-x7k2p"),
+// adds it to the default control plane by creating a ServiceMeshMember in it, and deletes it when
+// the test completes. Tests that only use unique namespaces don't interfere with each other and
+// can therefore call t.Parallel().
+// The control plane must already be deployed, since Unique waits for the namespace to become a member.
+func Unique(t test.TestHelper, prefix string) string {
+	t.T().Helper()
+	name := UniqueOutsideMesh(t, prefix)
+	oc.ApplyString(t, name, fmt.Sprintf(`
+apiVersion: maistra.io/v1
+kind: ServiceMeshMember
+metadata:
+  name: default
+spec:
+  controlPlaneRef:
+    name: %s
+    namespace: %s
+`, env.GetDefaultSMCPName(), env.GetDefaultMeshNamespace()))
+	oc.WaitCondition(t, name, "smm", "default", "Ready")
+	return name
+}
+
+// UniqueOutsideMesh is like Unique, but doesn't add the namespace to the mesh.
+// Use it for namespaces that host workloads outside the mesh (e.g. mesh-external services).
+func UniqueOutsideMesh(t test.TestHelper, prefix string) string {
+	t.T().Helper()
+	name := uniqueName(prefix)
+	oc.CreateNamespace(t, name)
+	t.Cleanup(func() {
+		oc.DeleteNamespace(t, name)
+	})
+	return name
+}
+
+func uniqueName(prefix string) string {
+	suffix := "-" + util.NewShortNonce()
+	prefix = strings.TrimRight(prefix, "-")
+	if len(prefix)+len(suffix) > maxNameLength {
+		prefix = strings.TrimRight(prefix[:maxNameLength-len(suffix)], "-")
+	}
+	return prefix + suffix
+}
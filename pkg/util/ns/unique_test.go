@@ -0,0 +1,59 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ns
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+var dnsLabel = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+func TestUniqueName(t *testing.T) {
+	for _, prefix := range []string{Foo, "foo-", strings.Repeat("a", 70), strings.Repeat("a", 57) + "-b"} {
+		name := uniqueName(prefix)
+		if len(name) > maxNameLength || !dnsLabel.MatchString(name) {
+			t.Errorf("invalid namespace name %q for prefix %q", name, prefix)
+		}
+	}
+	if uniqueName(Foo) == uniqueName(Foo) {
+		t.Error("expected names to be unique")
+	}
+}
+
+func TestUniqueOutsideMeshDeletesNamespaceOnCleanup(t *testing.T) {
+	th := test.NewTestHelper(t)
+	cluster := oc.NewFakeCluster()
+	cluster.UseAsDefault(th)
+
+	var name string
+	t.Run("test", func(t *testing.T) {
+		name = UniqueOutsideMesh(test.NewTestHelper(t), Foo)
+		if cluster.Get(th, "", "namespace", name) == nil {
+			t.Fatalf("expected namespace %s to be created", name)
+		}
+	})
+
+	if !strings.HasPrefix(name, "foo-") {
+		t.Fatalf("expected namespace name to start with foo-, but got %q", name)
+	}
+	if cluster.Get(th, "", "namespace", name) != nil {
+		t.Fatalf("expected namespace %s to be deleted", name)
+	}
+}
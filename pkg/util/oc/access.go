@@ -0,0 +1,118 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// GetToken returns a bearer token for APIs that authenticate users with their OpenShift credentials (e.g. Kiali):
+// the OAuth token of the logged-in user or, if the kubeconfig doesn't contain one (e.g. because it uses a client
+// certificate), a short-lived token of the service account
+func (o OC) GetToken(t test.TestHelper, ns, serviceAccount string) string {
+	t.T().Helper()
+	if o.native != nil {
+		return o.native.token(t, ns, serviceAccount)
+	}
+	var result shell.Result
+	o.withKubeconfig(t, func() {
+		t.T().Helper()
+		result = shell.Run(t, "oc whoami -t")
+	})
+	if token := strings.TrimSpace(result.Stdout); result.Succeeded() && token != "" {
+		return token
+	}
+	return strings.TrimSpace(o.Invokef(t, "oc create token %s -n %s", serviceAccount, ns))
+}
+
+func (c *nativeClient) token(t test.TestHelper, ns, serviceAccount string) string {
+	t.T().Helper()
+	c.clients(t)
+	if c.config != nil && c.config.BearerToken != "" {
+		return c.config.BearerToken
+	}
+	request, err := c.kube.CoreV1().ServiceAccounts(ns).CreateToken(c.ctx(t), serviceAccount, &authenticationv1.TokenRequest{}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("could not create a token for service account %s/%s: %v", ns, serviceAccount, err)
+	}
+	return request.Status.Token
+}
+
+var forwardingFromRegexp = regexp.MustCompile(`Forwarding from 127\.0\.0\.1:(\d+) ->`)
+
+// PortForward forwards a random local port to the port of the target (e.g. "svc/kiali") until the test ends
+// and returns the local port
+func (o OC) PortForward(t test.TestHelper, ns, target string, remotePort int) int {
+	t.T().Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.T().Cleanup(cancel)
+
+	cmd := exec.CommandContext(ctx, "oc", "port-forward", "-n", ns, target, fmt.Sprintf(":%d", remotePort))
+	if o.native != nil {
+		cmd.Env = o.native.commandEnv()
+	} else if o.kubeconfig != "" {
+		cmd.Env = append(os.Environ(), "KUBECONFIG="+o.kubeconfig)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("could not start port-forward to %s/%s: %v", ns, target, err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("could not start port-forward to %s/%s: %v", ns, target, err)
+	}
+
+	ports := make(chan int, 1)
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			if match := forwardingFromRegexp.FindStringSubmatch(scanner.Text()); match != nil {
+				port, _ := strconv.Atoi(match[1])
+				select {
+				case ports <- port:
+				default:
+				}
+			}
+		}
+		_ = cmd.Wait()
+		close(ports)
+	}()
+
+	select {
+	case port, ok := <-ports:
+		if !ok {
+			t.Fatalf("port-forward to %s/%s exited before forwarding a port", ns, target)
+		}
+		t.Logf("Forwarding localhost:%d to %s/%s:%d", port, ns, target, remotePort)
+		return port
+	case <-time.After(30 * time.Second):
+		cancel()
+		t.Fatalf("timed out waiting for port-forward to %s/%s", ns, target)
+		return 0
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+func TestGetToken(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc := NewOC("")
+
+	fake := shell.NewFakeExecutor()
+	defer shell.SetExecutor(fake)()
+	fake.On("oc whoami -t").Return("sha256~user-token\n")
+	if token := oc.GetToken(th, "istio-system", "kiali-service-account"); token != "sha256~user-token" {
+		t.Errorf("expected the token of the logged-in user, got %q", token)
+	}
+
+	fake = shell.NewFakeExecutor()
+	defer shell.SetExecutor(fake)()
+	fake.On("oc whoami -t").Fail("error: no token is currently in use for this session")
+	fake.On("oc create token kiali-service-account -n istio-system").Return("service-account-token\n")
+	if token := oc.GetToken(th, "istio-system", "kiali-service-account"); token != "service-account-token" {
+		t.Errorf("expected the token of the service account, got %q", token)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// splitCommand splits a command line into arguments the same way that `sh` would,
+// honouring single quotes, double quotes and backslash escapes.
+//
+// Commands passed to OC.Exec are appended to `kubectl exec ... --`, so any unquoted
+// shell operator (pipes, redirects, `||`, etc.) is evaluated by the local shell and
+// not in the container. Since such a command can't be represented as a plain argument
+// list, splitCommand returns an error when it encounters one.
+func splitCommand(cmd string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+
+	for i := 0; i < len(cmd); i++ {
+		ch := cmd[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+
+		case ch == '\'':
+			end := strings.IndexByte(cmd[i+1:], '\'')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated single quote in command: %s", cmd)
+			}
+			current.WriteString(cmd[i+1 : i+1+end])
+			i += end + 1
+			inArg = true
+
+		case ch == '"':
+			i++
+			for ; i < len(cmd) && cmd[i] != '"'; i++ {
+				if cmd[i] == '\\' && i+1 < len(cmd) && strings.IndexByte("\"\\$`\n", cmd[i+1]) >= 0 {
+					i++
+				} else if cmd[i] == '$' || cmd[i] == '`' {
+					return nil, fmt.Errorf("command contains shell expansion: %s", cmd)
+				}
+				current.WriteByte(cmd[i])
+			}
+			if i == len(cmd) {
+				return nil, fmt.Errorf("unterminated double quote in command: %s", cmd)
+			}
+			inArg = true
+
+		case ch == '\\':
+			if i+1 < len(cmd) {
+				i++
+				current.WriteByte(cmd[i])
+			}
+			inArg = true
+
+		case strings.IndexByte("|&;<>()$`", ch) >= 0:
+			return nil, fmt.Errorf("command contains shell operator %q: %s", ch, cmd)
+
+		default:
+			current.WriteByte(ch)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitCommand(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input:    "curl -sS http://httpbin:8000/headers",
+			expected: []string{"curl", "-sS", "http://httpbin:8000/headers"},
+		},
+		{
+			input:    `curl -H 'x-user: jason' "http://productpage:9080/productpage?u=normal"`,
+			expected: []string{"curl", "-H", "x-user: jason", "http://productpage:9080/productpage?u=normal"},
+		},
+		{
+			input:    `sh -c "echo \"hello world\""`,
+			expected: []string{"sh", "-c", `echo "hello world"`},
+		},
+		{
+			input:    `echo a\ b ''`,
+			expected: []string{"echo", "a b", ""},
+		},
+	}
+	for _, c := range cases {
+		args, err := splitCommand(c.input)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", c.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(args, c.expected) {
+			t.Errorf("expected %q to be split into %q, but got %q", c.input, c.expected, args)
+		}
+	}
+}
+
+func TestSplitCommandRejectsShellSyntax(t *testing.T) {
+	for _, input := range []string{
+		"curl http://foo | grep bar",
+		"curl http://foo > /dev/null",
+		"curl http://foo || true",
+		`echo "$HOME"`,
+		"echo 'unterminated",
+	} {
+		if _, err := splitCommand(input); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+}
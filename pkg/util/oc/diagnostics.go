@@ -0,0 +1,225 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/env"
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// activity records the namespaces and pods that a top-level test (including its subtests) used,
+// so that the diagnostics of a failed test can be limited to them
+type activity struct {
+	mu         sync.Mutex
+	namespaces map[touchedNamespace]bool
+	pods       map[touchedPod]bool
+}
+
+type touchedNamespace struct {
+	oc   OC
+	name string
+}
+
+type touchedPod struct {
+	oc  OC
+	pod NamespacedName
+}
+
+// activities contains the activities of the running top-level tests, by test name
+var activities sync.Map
+
+func init() {
+	test.RegisterTestHook(startActivity)
+	test.RegisterDiagnosticsCollector(collectDiagnostics)
+}
+
+func startActivity(t test.TestHelper) {
+	name := t.T().Name()
+	activities.Store(name, &activity{namespaces: map[touchedNamespace]bool{}, pods: map[touchedPod]bool{}})
+	t.T().Cleanup(func() {
+		activities.Delete(name)
+	})
+}
+
+// activityOf returns the activity of the test, or nil if the test wasn't created with test.NewTest
+func activityOf(t test.TestHelper) *activity {
+	if a := lookupTopLevel(&activities, t); a != nil {
+		return a.(*activity)
+	}
+	return nil
+}
+
+// lookupTopLevel returns the value stored under the name of the top-level test of the (sub)test
+func lookupTopLevel(m *sync.Map, t test.TestHelper) interface{} {
+	for name := t.T().Name(); name != ""; {
+		if value, found := m.Load(name); found {
+			return value
+		}
+		i := strings.LastIndex(name, "/")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return nil
+}
+
+func (o OC) recordNamespaces(t test.TestHelper, namespaces ...string) {
+	if a := activityOf(t); a != nil {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		for _, ns := range namespaces {
+			if ns != "" {
+				a.namespaces[touchedNamespace{oc: o, name: ns}] = true
+			}
+		}
+	}
+}
+
+func (o OC) recordPod(t test.TestHelper, pod NamespacedName) {
+	if a := activityOf(t); a != nil {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		a.namespaces[touchedNamespace{oc: o, name: pod.Namespace}] = true
+		a.pods[touchedPod{oc: o, pod: pod}] = true
+	}
+}
+
+// recordManifestNamespaces records the namespaces into which the manifests are applied
+func (o OC) recordManifestNamespaces(t test.TestHelper, ns string, manifests string) {
+	if activityOf(t) == nil {
+		return
+	}
+	o.recordNamespaces(t, ns)
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		return
+	}
+	for _, obj := range objects {
+		if obj.GetKind() == "Namespace" {
+			o.recordNamespaces(t, obj.GetName())
+		} else {
+			o.recordNamespaces(t, obj.GetNamespace())
+		}
+	}
+}
+
+// touched returns the namespaces and pods recorded in the activity, sorted by name
+func (a *activity) touched() ([]touchedNamespace, []touchedPod) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var namespaces []touchedNamespace
+	for ns := range a.namespaces {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].name < namespaces[j].name
+	})
+	var pods []touchedPod
+	for pod := range a.pods {
+		pods = append(pods, pod)
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].pod.Namespace+"/"+pods[i].pod.Name < pods[j].pod.Namespace+"/"+pods[j].pod.Name
+	})
+	return namespaces, pods
+}
+
+// collectDiagnostics collects the events, pods and pod logs of the namespaces used by the test, the
+// config_dump and clusters of the istio-proxy of the pods the test exec'd into, the istiod logs since
+// the test started and the status of the control plane
+func collectDiagnostics(t test.TestHelper, d *test.Diagnostics) {
+	t.T().Helper()
+	if a := activityOf(t); a != nil {
+		namespaces, pods := a.touched()
+		for _, ns := range namespaces {
+			dir := "namespaces/" + ns.name
+			ns.oc.collect(t, d, dir+"/events.txt", "events in namespace "+ns.name,
+				fmt.Sprintf("oc get events -n %s --sort-by=.lastTimestamp", ns.name))
+			ns.oc.collect(t, d, dir+"/pods.txt", "pods in namespace "+ns.name,
+				fmt.Sprintf("oc get pods -n %s -o wide", ns.name))
+			for _, pod := range ns.oc.podNames(t, ns.name) {
+				ns.oc.collect(t, d, fmt.Sprintf("%s/logs/%s.log", dir, pod), fmt.Sprintf("logs of pod %s/%s", ns.name, pod),
+					fmt.Sprintf("oc logs -n %s %s --all-containers --prefix", ns.name, pod))
+			}
+		}
+		for _, p := range pods {
+			dir := fmt.Sprintf("proxies/%s/%s", p.pod.Namespace, p.pod.Name)
+			p.oc.collect(t, d, dir+"/config_dump.json", fmt.Sprintf("istio-proxy config dump of pod %s/%s", p.pod.Namespace, p.pod.Name),
+				fmt.Sprintf("oc exec -n %s %s -c istio-proxy -- pilot-agent request GET config_dump", p.pod.Namespace, p.pod.Name))
+			p.oc.collect(t, d, dir+"/clusters.txt", fmt.Sprintf("istio-proxy clusters of pod %s/%s", p.pod.Namespace, p.pod.Name),
+				fmt.Sprintf("oc exec -n %s %s -c istio-proxy -- pilot-agent request GET clusters", p.pod.Namespace, p.pod.Name))
+		}
+	}
+
+	o := *DefaultOC
+	for _, ns := range meshNamespaces() {
+		dir := "control-plane/" + ns
+		o.collect(t, d, dir+"/istiod.log", "istiod logs since the test started",
+			fmt.Sprintf("oc logs -n %s -l app=istiod --all-containers --prefix --tail=-1 --since-time=%s", ns, d.Start.UTC().Format(time.RFC3339)))
+		o.collect(t, d, dir+"/events.txt", "events in namespace "+ns,
+			fmt.Sprintf("oc get events -n %s --sort-by=.lastTimestamp", ns))
+	}
+	o.collect(t, d, "control-plane/smcp-smmr.yaml", "ServiceMeshControlPlanes and ServiceMeshMemberRolls",
+		"oc get smcp,smmr --all-namespaces -o yaml")
+	o.collect(t, d, "control-plane/istio.yaml", "Istio and IstioCNI resources",
+		"oc get istios.sailoperator.io,istiocnis.sailoperator.io -o yaml")
+}
+
+// collect writes the output of the command into the diagnostics file. The command may fail
+// (e.g. if the pod has no istio-proxy container), in which case the failure is recorded in the index.
+func (o OC) collect(t test.TestHelper, d *test.Diagnostics, path, description, cmd string) {
+	t.T().Helper()
+	result := o.run(t, cmd)
+	var err error
+	if !result.Succeeded() {
+		err = fmt.Errorf("command %q failed: %s", cmd, strings.TrimSpace(result.Stderr))
+	}
+	d.WriteFile(path, description, result.Stdout, err)
+}
+
+// podNames returns the names of the pods in the namespace, or nil if they can't be listed
+func (o OC) podNames(t test.TestHelper, ns string) []string {
+	t.T().Helper()
+	result := o.run(t, fmt.Sprintf("oc get pods -n %s -o jsonpath='{.items[*].metadata.name}'", ns))
+	if !result.Succeeded() {
+		return nil
+	}
+	return strings.Fields(result.Stdout)
+}
+
+// run executes the command against the cluster of this OC like Invoke, but returns the result instead of
+// failing the test if the command fails
+func (o OC) run(t test.TestHelper, command string) shell.Result {
+	t.T().Helper()
+	t, cancel := shell.WithTimeout(t, env.GetCommandTimeout())
+	defer cancel()
+	if o.native != nil {
+		return shell.RunWithEnvAndInput(t, o.native.commandEnv(), command, "")
+	}
+	var result shell.Result
+	o.withKubeconfig(t, func() {
+		t.T().Helper()
+		result = shell.Run(t, command)
+	})
+	return result
+}
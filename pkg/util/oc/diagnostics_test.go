@@ -0,0 +1,105 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+func TestCollectDiagnostics(t *testing.T) {
+	t.Setenv("TEST_GROUP", string(test.Full))
+	t.Setenv("OCP_ARCH", "x86")
+	t.Setenv("SMCP_NAMESPACE", "istio-system")
+	t.Setenv("ISTIO_NAMESPACE", "istio-system")
+	th := test.NewTestHelper(t)
+	oc, cluster := NewFakeOC(th)
+	cluster.UseAsDefault(th)
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	d := &test.Diagnostics{Dir: t.TempDir(), Start: start}
+
+	t.Run("test", func(t *testing.T) {
+		test.NewTest(t).Groups(test.Full).Run(func(t test.TestHelper) {
+			oc.ApplyString(t, "foo", configMapYaml)
+			t.NewSubTest("subtest").Run(func(t test.TestHelper) {
+				oc.recordPod(t, NewNamespacedName("bar", "sleep-1"))
+
+				fake := shell.NewFakeExecutor()
+				defer shell.SetExecutor(fake)()
+				fake.On("oc get pods -n bar -o jsonpath='{.items[*].metadata.name}'").Return("sleep-1")
+				fake.On("oc get pods -n foo -o jsonpath='{.items[*].metadata.name}'").Return("")
+				fake.OnPattern(`-c istio-proxy -- pilot-agent request GET clusters`).Fail("container istio-proxy not found")
+				fake.OnPattern(`^oc `).Return("output")
+				collectDiagnostics(t, d)
+			})
+		})
+	})
+
+	var paths []string
+	for _, file := range d.Files {
+		paths = append(paths, file.Path)
+	}
+	expected := []string{
+		"namespaces/bar/events.txt",
+		"namespaces/bar/pods.txt",
+		"namespaces/bar/logs/sleep-1.log",
+		"namespaces/foo/events.txt",
+		"namespaces/foo/pods.txt",
+		"proxies/bar/sleep-1/config_dump.json",
+		"proxies/bar/sleep-1/clusters.txt",
+		"control-plane/istio-system/istiod.log",
+		"control-plane/istio-system/events.txt",
+		"control-plane/smcp-smmr.yaml",
+		"control-plane/istio.yaml",
+	}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("expected files %v, got %v", expected, paths)
+	}
+	if d.Files[6].Error == "" {
+		t.Error("expected the failed command to be recorded in the index")
+	}
+	if content, err := os.ReadFile(filepath.Join(d.Dir, "namespaces/bar/logs/sleep-1.log")); err != nil || string(content) != "output" {
+		t.Errorf("expected the pod logs to be written, got %q (%v)", content, err)
+	}
+}
+
+func TestCollectUsesKubeconfigOfNativeOC(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc := OC{native: newNativeClient("/tmp/kubeconfig2")}
+
+	var kubeconfig string
+	defer shell.SetExecutor(shell.ExecutorFunc(func(cmd string, env []string, input string) (string, error) {
+		for _, v := range env {
+			if strings.HasPrefix(v, "KUBECONFIG=") {
+				kubeconfig = strings.TrimPrefix(v, "KUBECONFIG=")
+			}
+		}
+		return "sleep-1", nil
+	}))()
+
+	if names := oc.podNames(th, "foo"); !reflect.DeepEqual(names, []string{"sleep-1"}) {
+		t.Errorf("expected pod names [sleep-1], got %v", names)
+	}
+	if kubeconfig != "/tmp/kubeconfig2" {
+		t.Errorf("expected the command to use the kubeconfig of the OC, got %q", kubeconfig)
+	}
+}
@@ -0,0 +1,400 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// FakeCluster is an in-memory Kubernetes API server for unit tests of the framework itself.
+// It supports create, get, list, update, patch, server-side apply and delete of any registered kind
+// (see AddKind), but has no controllers, except that pods and deployments become ready immediately
+// unless their status is set explicitly.
+//
+// Exec and logs aren't supported by the fake cluster, so the OC falls back to executing
+// kubectl through the shell package, where the commands can be faked with shell.FakeExecutor.
+type FakeCluster struct {
+	store  *fakeStore
+	mapper *fakeRESTMapper
+	oc     *OC
+}
+
+// fakeClusterObjects are the objects that exist in every fake cluster
+const fakeClusterObjects = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: default
+---
+apiVersion: config.openshift.io/v1
+kind: ClusterVersion
+metadata:
+  name: version
+status:
+  desired:
+    version: 4.16.0
+---
+apiVersion: config.openshift.io/v1
+kind: Proxy
+metadata:
+  name: cluster
+`
+
+// NewFakeCluster returns a fake cluster that contains only the "default" namespace and the
+// cluster-scoped OpenShift config objects that the framework reads (clusterversion/version and proxy/cluster).
+// Use FakeCluster.OC() to obtain an OC that talks to it.
+func NewFakeCluster() *FakeCluster {
+	mapper := newFakeRESTMapper()
+	store := newFakeStore(mapper)
+
+	kube := &kubefake.Clientset{}
+	kube.AddReactor("*", "*", typedReactor(store))
+
+	native := newNativeClient("")
+	native.once.Do(func() {
+		native.namespace = "default"
+		native.kube = kube
+		native.dynamic = &fakeDynamicClient{store: store}
+		native.mapper = mapper
+		native.expander = mapper
+	})
+	c := &FakeCluster{
+		store:  store,
+		mapper: mapper,
+		oc:     &OC{native: native},
+	}
+	objects, err := decodeManifests(fakeClusterObjects)
+	if err != nil {
+		panic(err)
+	}
+	for _, obj := range objects {
+		mapping, err := mapper.RESTMapping(obj.GroupVersionKind().GroupKind())
+		if err != nil {
+			panic(err)
+		}
+		if _, err := store.create(mapping.Resource, "", obj); err != nil {
+			panic(err)
+		}
+	}
+	return c
+}
+
+// NewFakeOC returns an OC backed by a new fake cluster that contains the specified objects (YAML manifests)
+func NewFakeOC(t test.TestHelper, manifests ...string) (*OC, *FakeCluster) {
+	t.T().Helper()
+	cluster := NewFakeCluster()
+	cluster.Add(t, manifests...)
+	return cluster.OC(), cluster
+}
+
+// OC returns an OC that talks to this fake cluster
+func (c *FakeCluster) OC() *OC {
+	return c.oc
+}
+
+// UseAsDefault makes the package-level functions (e.g. oc.ApplyString) use this fake cluster until the test ends
+func (c *FakeCluster) UseAsDefault(t test.TestHelper) {
+	previous := DefaultOC
+	DefaultOC = c.oc
+	t.Cleanup(func() {
+		DefaultOC = previous
+	})
+}
+
+// Add creates the objects in the specified YAML manifests. Objects without a namespace are created in "default".
+func (c *FakeCluster) Add(t test.TestHelper, manifests ...string) {
+	t.T().Helper()
+	objects, err := decodeManifests(concatenateYamls(manifests...))
+	if err != nil {
+		t.Fatalf("fake cluster: %v", err)
+	}
+	for _, obj := range objects {
+		c.AddObject(t, obj)
+	}
+}
+
+// AddObject creates the specified typed or unstructured object
+func (c *FakeCluster) AddObject(t test.TestHelper, obj runtime.Object) {
+	t.T().Helper()
+	u, err := toUnstructured(obj)
+	if err != nil {
+		t.Fatalf("fake cluster: %v", err)
+	}
+	mapping, err := c.mapper.RESTMapping(u.GroupVersionKind().GroupKind(), u.GroupVersionKind().Version)
+	if err != nil {
+		t.Fatalf("fake cluster: %v", err)
+	}
+	if _, err := c.store.create(mapping.Resource, namespaceOf(u, "default"), u); err != nil {
+		t.Fatalf("fake cluster: %v", err)
+	}
+}
+
+// Get returns the object or nil if it doesn't exist. The kind is specified the same way as in `oc get` (e.g. "deploy", "smcp").
+func (c *FakeCluster) Get(t test.TestHelper, ns, kind, name string) *unstructured.Unstructured {
+	t.T().Helper()
+	mapping := c.oc.native.mustMapping(t, kind)
+	obj, err := c.store.get(mapping.Resource, ns, name)
+	if err != nil {
+		return nil
+	}
+	return obj
+}
+
+// AddKind registers a custom resource kind (in addition to the built-in Kubernetes, OpenShift, Maistra and Istio kinds)
+func (c *FakeCluster) AddKind(gvk schema.GroupVersionKind, namespaced bool, shortNames ...string) {
+	c.mapper.addKind(gvk, namespaced, shortNames...)
+}
+
+// fakeRESTMapper is a static RESTMapper that also resolves short names (e.g. "smcp") and
+// maps all versions of a group to the registered version
+type fakeRESTMapper struct {
+	*meta.DefaultRESTMapper
+	shortNames map[string]schema.GroupVersionResource
+	versions   map[schema.GroupKind]string
+}
+
+var _ meta.ResettableRESTMapper = &fakeRESTMapper{}
+
+func newFakeRESTMapper() *fakeRESTMapper {
+	m := &fakeRESTMapper{
+		DefaultRESTMapper: meta.NewDefaultRESTMapper(nil),
+		shortNames:        map[string]schema.GroupVersionResource{},
+		versions:          map[schema.GroupKind]string{},
+	}
+	for _, k := range fakeKinds {
+		m.addKind(k.gvk, k.namespaced, k.shortNames...)
+	}
+	return m
+}
+
+type fakeKind struct {
+	gvk        schema.GroupVersionKind
+	namespaced bool
+	shortNames []string
+}
+
+func fakeKindOf(group, version, kind string, namespaced bool, shortNames ...string) fakeKind {
+	return fakeKind{schema.GroupVersionKind{Group: group, Version: version, Kind: kind}, namespaced, shortNames}
+}
+
+// fakeKinds are the kinds known to every fake cluster
+var fakeKinds = []fakeKind{
+	fakeKindOf("", "v1", "Namespace", false, "ns"),
+	fakeKindOf("", "v1", "Node", false, "no"),
+	fakeKindOf("", "v1", "Pod", true, "po"),
+	fakeKindOf("", "v1", "Service", true, "svc"),
+	fakeKindOf("", "v1", "ConfigMap", true, "cm"),
+	fakeKindOf("", "v1", "Secret", true),
+	fakeKindOf("", "v1", "ServiceAccount", true, "sa"),
+	fakeKindOf("", "v1", "Event", true, "ev"),
+	fakeKindOf("", "v1", "PersistentVolumeClaim", true, "pvc"),
+	fakeKindOf("apps", "v1", "Deployment", true, "deploy"),
+	fakeKindOf("apps", "v1", "ReplicaSet", true, "rs"),
+	fakeKindOf("apps", "v1", "StatefulSet", true, "sts"),
+	fakeKindOf("apps", "v1", "DaemonSet", true, "ds"),
+	fakeKindOf("batch", "v1", "Job", true),
+	fakeKindOf("coordination.k8s.io", "v1", "Lease", true),
+	fakeKindOf("rbac.authorization.k8s.io", "v1", "ClusterRole", false),
+	fakeKindOf("rbac.authorization.k8s.io", "v1", "ClusterRoleBinding", false),
+	fakeKindOf("admissionregistration.k8s.io", "v1", "MutatingWebhookConfiguration", false),
+	fakeKindOf("admissionregistration.k8s.io", "v1", "ValidatingWebhookConfiguration", false),
+	fakeKindOf("certificates.k8s.io", "v1", "CertificateSigningRequest", false, "csr"),
+	fakeKindOf("route.openshift.io", "v1", "Route", true),
+	fakeKindOf("config.openshift.io", "v1", "ClusterVersion", false),
+	fakeKindOf("config.openshift.io", "v1", "Proxy", false),
+	fakeKindOf("maistra.io", "v2", "ServiceMeshControlPlane", true, "smcp"),
+	fakeKindOf("maistra.io", "v1", "ServiceMeshMemberRoll", true, "smmr"),
+	fakeKindOf("maistra.io", "v1", "ServiceMeshMember", true, "smm"),
+	fakeKindOf("sailoperator.io", "v1", "Istio", false),
+	fakeKindOf("sailoperator.io", "v1", "IstioRevision", false),
+	fakeKindOf("sailoperator.io", "v1", "IstioCNI", false),
+	fakeKindOf("sailoperator.io", "v1alpha1", "ZTunnel", false),
+	fakeKindOf("networking.istio.io", "v1beta1", "VirtualService", true, "vs"),
+	fakeKindOf("networking.istio.io", "v1beta1", "DestinationRule", true, "dr"),
+	fakeKindOf("networking.istio.io", "v1beta1", "Gateway", true, "gw"),
+	fakeKindOf("networking.istio.io", "v1beta1", "ServiceEntry", true, "se"),
+	fakeKindOf("networking.istio.io", "v1beta1", "Sidecar", true),
+	fakeKindOf("networking.istio.io", "v1beta1", "WorkloadEntry", true, "we"),
+	fakeKindOf("networking.istio.io", "v1alpha3", "EnvoyFilter", true),
+	fakeKindOf("security.istio.io", "v1beta1", "PeerAuthentication", true, "pa"),
+	fakeKindOf("security.istio.io", "v1beta1", "AuthorizationPolicy", true, "ap"),
+	fakeKindOf("security.istio.io", "v1beta1", "RequestAuthentication", true, "ra"),
+}
+
+func (m *fakeRESTMapper) addKind(gvk schema.GroupVersionKind, namespaced bool, shortNames ...string) {
+	scope := meta.RESTScopeRoot
+	if namespaced {
+		scope = meta.RESTScopeNamespace
+	}
+	plural, singular := meta.UnsafeGuessKindToResource(gvk)
+	if strings.HasSuffix(singular.Resource, "ay") || strings.HasSuffix(singular.Resource, "ey") {
+		// UnsafeGuessKindToResource turns "Gateway" into "gatewaies"
+		plural.Resource = singular.Resource + "s"
+	}
+	m.AddSpecific(gvk, plural, singular, scope)
+	m.versions[gvk.GroupKind()] = gvk.Version
+	for _, shortName := range shortNames {
+		m.shortNames[shortName] = plural
+	}
+}
+
+// Reset does nothing, since the mappings are static
+func (m *fakeRESTMapper) Reset() {}
+
+func (m *fakeRESTMapper) expand(resource schema.GroupVersionResource) schema.GroupVersionResource {
+	if resource.Group == "" && resource.Version == "" {
+		if expanded, found := m.shortNames[resource.Resource]; found {
+			return expanded
+		}
+	}
+	return resource
+}
+
+func (m *fakeRESTMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	return m.DefaultRESTMapper.KindFor(m.expand(resource))
+}
+
+func (m *fakeRESTMapper) KindsFor(resource schema.GroupVersionResource) ([]schema.GroupVersionKind, error) {
+	return m.DefaultRESTMapper.KindsFor(m.expand(resource))
+}
+
+func (m *fakeRESTMapper) ResourceFor(resource schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	return m.DefaultRESTMapper.ResourceFor(m.expand(resource))
+}
+
+func (m *fakeRESTMapper) ResourcesFor(resource schema.GroupVersionResource) ([]schema.GroupVersionResource, error) {
+	return m.DefaultRESTMapper.ResourcesFor(m.expand(resource))
+}
+
+// RESTMapping returns the registered version of the kind if the requested version isn't registered
+// (e.g. networking.istio.io/v1alpha3 VirtualService is mapped to v1beta1)
+func (m *fakeRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	mapping, err := m.DefaultRESTMapper.RESTMapping(gk, versions...)
+	if meta.IsNoMatchError(err) {
+		if version, found := m.versions[gk]; found {
+			return m.DefaultRESTMapper.RESTMapping(gk, version)
+		}
+	}
+	return mapping, err
+}
+
+// typedReactor makes the typed fake clientset read and write the objects in the store,
+// so that the typed and the dynamic client see the same objects
+func typedReactor(store *fakeStore) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gvr := action.GetResource()
+		ns := action.GetNamespace()
+		switch a := action.(type) {
+		case k8stesting.GetActionImpl:
+			obj, err := store.get(gvr, ns, a.GetName())
+			if err != nil {
+				return true, nil, err
+			}
+			typed, err := toTyped(obj)
+			return true, typed, err
+
+		case k8stesting.ListActionImpl:
+			list := store.list(gvr, ns, a.GetListRestrictions().Labels)
+			typed, err := toTyped(list)
+			return true, typed, err
+
+		case k8stesting.CreateActionImpl:
+			u, err := toUnstructured(a.GetObject())
+			if err != nil {
+				return true, nil, err
+			}
+			obj, err := store.create(gvr, ns, u)
+			if err != nil {
+				return true, nil, err
+			}
+			typed, err := toTyped(obj)
+			return true, typed, err
+
+		case k8stesting.UpdateActionImpl:
+			u, err := toUnstructured(a.GetObject())
+			if err != nil {
+				return true, nil, err
+			}
+			obj, err := store.update(gvr, ns, u)
+			if err != nil {
+				return true, nil, err
+			}
+			typed, err := toTyped(obj)
+			return true, typed, err
+
+		case k8stesting.PatchActionImpl:
+			obj, err := (&fakeResourceClient{store: store, gvr: gvr, ns: ns}).Patch(context.Background(), a.GetName(), a.GetPatchType(), a.GetPatch(), a.PatchOptions)
+			if err != nil {
+				return true, nil, err
+			}
+			typed, err := toTyped(obj)
+			return true, typed, err
+
+		case k8stesting.DeleteActionImpl:
+			return true, nil, store.delete(gvr, ns, a.GetName())
+
+		case k8stesting.DeleteCollectionActionImpl:
+			return true, nil, store.deleteCollection(gvr, ns, a.GetListRestrictions().Labels)
+		}
+		return true, nil, fmt.Errorf("fake cluster doesn't support action %s %s", action.GetVerb(), strings.Join([]string{gvr.Resource, action.GetSubresource()}, "/"))
+	}
+}
+
+// toTyped converts an unstructured object or list to the corresponding Go type in the client-go scheme
+func toTyped(obj runtime.Unstructured) (runtime.Object, error) {
+	gvk := obj.(runtime.Object).GetObjectKind().GroupVersionKind()
+	typed, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		return nil, fmt.Errorf("fake cluster: no Go type for %s: %v", gvk, err)
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), typed); err != nil {
+		return nil, err
+	}
+	typed.GetObjectKind().SetGroupVersionKind(gvk)
+	return typed, nil
+}
+
+// toUnstructured converts a typed object to an unstructured object. Typed objects created by
+// client-go often don't have their apiVersion and kind set, so they are looked up in the scheme.
+func toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u.DeepCopy(), nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	if u.GetKind() == "" {
+		gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+		if err != nil || len(gvks) == 0 {
+			return nil, fmt.Errorf("fake cluster: unknown kind of object %T", obj)
+		}
+		u.SetGroupVersionKind(gvks[0])
+	}
+	return u, nil
+}
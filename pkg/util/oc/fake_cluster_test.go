@@ -0,0 +1,256 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+const httpbinYaml = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: httpbin
+  labels:
+    app: httpbin
+spec:
+  ports:
+  - name: http
+    port: 8000
+  selector:
+    app: httpbin
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: httpbin
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: httpbin
+  template:
+    metadata:
+      labels:
+        app: httpbin
+    spec:
+      containers:
+      - name: httpbin
+        image: httpbin
+`
+
+const smcpYaml = `
+apiVersion: maistra.io/v2
+kind: ServiceMeshControlPlane
+metadata:
+  name: basic
+  namespace: istio-system
+spec:
+  version: v2.6
+status:
+  conditions:
+  - type: Ready
+    status: "True"
+`
+
+func TestApplyGetAndDelete(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, _ := NewFakeOC(th)
+
+	oc.CreateNamespace(th, "foo")
+	oc.ApplyString(th, "foo", httpbinYaml)
+
+	if !oc.ResourceExists(th, "foo", "deploy", "httpbin") {
+		t.Fatal("expected deployment httpbin to exist")
+	}
+	if port := oc.GetJson(th, "foo", "svc", "httpbin", "{.spec.ports[0].port}"); port != "8000" {
+		t.Fatalf("expected port 8000, but got %q", port)
+	}
+	if names := oc.GetAllResourcesNames(th, "foo", "services", "app=httpbin"); len(names) != 1 || names[0] != "httpbin" {
+		t.Fatalf("unexpected services: %v", names)
+	}
+	if table := oc.Get(th, "foo", "deployments", ""); !strings.Contains(table, "httpbin") {
+		t.Fatalf("expected table to contain httpbin, but got:\n%s", table)
+	}
+
+	oc.DeleteFromString(th, "foo", httpbinYaml)
+	if oc.AnyResourceExist(th, "foo", "deployments") {
+		t.Fatal("expected deployment to be deleted")
+	}
+}
+
+func TestExposeSvc(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, cluster := NewFakeOC(th)
+	oc.ApplyString(th, "foo", httpbinYaml)
+
+	oc.ExposeSvc(th, "foo", "httpbin", "8000", "by-number")
+	oc.ExposeSvc(th, "foo", "httpbin", "http", "by-name")
+	for route, expected := range map[string]interface{}{"by-number": int64(8000), "by-name": "http"} {
+		port := cluster.Get(th, "foo", "route", route).Object["spec"].(map[string]interface{})["port"].(map[string]interface{})
+		if port["targetPort"] != expected {
+			t.Errorf("route %s: expected target port %#v, got %#v", route, expected, port["targetPort"])
+		}
+	}
+}
+
+func TestApplyUpdatesExistingObject(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, cluster := NewFakeOC(th, httpbinYaml)
+
+	oc.ApplyString(th, "default", strings.Replace(httpbinYaml, "image: httpbin", "image: httpbin:v2", 1))
+
+	deployment := cluster.Get(th, "default", "deployment", "httpbin")
+	if deployment.GetGeneration() != 2 {
+		t.Fatalf("expected generation 2, but got %d", deployment.GetGeneration())
+	}
+	if image := oc.GetJson(th, "default", "deployment", "httpbin", "{.spec.template.spec.containers[0].image}"); image != "httpbin:v2" {
+		t.Fatalf("unexpected image: %q", image)
+	}
+}
+
+func TestPatchAndLabel(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, _ := NewFakeOC(th, httpbinYaml)
+
+	oc.Patch(th, "default", "deployment", "httpbin", "merge", `{"spec":{"replicas":2}}`)
+	oc.Label(th, "default", "deployment", "httpbin", "foo=bar version=v1")
+	oc.RemoveLabel(th, "default", "deployment", "httpbin", "version")
+
+	if replicas := oc.GetJson(th, "default", "deployment", "httpbin", "{.spec.replicas}"); replicas != "2" {
+		t.Fatalf("expected 2 replicas, but got %q", replicas)
+	}
+	if !oc.ResourceByLabelExists(th, "default", "deployment", "foo=bar") {
+		t.Fatal("expected label foo=bar to be set")
+	}
+	if oc.ResourceByLabelExists(th, "default", "deployment", "version=v1") {
+		t.Fatal("expected label version to be removed")
+	}
+}
+
+func TestWaitSMCPReady(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, _ := NewFakeOC(th, smcpYaml)
+
+	oc.WaitSMCPReady(th, "istio-system", "basic")
+}
+
+func TestWaitForFailsWhenConditionIsNotMet(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, _ := NewFakeOC(th, strings.Replace(smcpYaml, `"True"`, `"False"`, 1))
+
+	attempt := retry.Attempt(th, func(t test.TestHelper) {
+		if _, err := oc.native.waitFor(t, "istio-system", "smcp", "basic", "condition=Ready", 0); err == nil {
+			t.Fatal("condition should not be met")
+		}
+	})
+	if attempt.Failed() {
+		t.Fatal("expected waitFor to return an error instead of failing the test")
+	}
+}
+
+func TestScaleDeploymentAndWait(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, _ := NewFakeOC(th, httpbinYaml)
+
+	oc.ScaleDeploymentAndWait(th, "default", "httpbin", 3)
+
+	if available := oc.GetJson(th, "default", "deployment", "httpbin", "{.status.availableReplicas}"); available != "3" {
+		t.Fatalf("expected 3 available replicas, but got %q", available)
+	}
+}
+
+func TestRestartAllPodsAndWaitReady(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, _ := NewFakeOC(th, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: httpbin-1
+  namespace: foo
+  labels:
+    app: httpbin
+spec:
+  containers:
+  - name: httpbin
+    image: httpbin
+`)
+
+	oc.WaitPodReadyWithOptions(th, retry.Options().MaxAttempts(1), podNamed("foo", "httpbin-1"))
+	oc.RestartAllPodsAndWaitReady(th, "foo")
+
+	if oc.AnyResourceExist(th, "foo", "pods") {
+		t.Fatal("expected all pods to be deleted")
+	}
+}
+
+func TestDeleteNamespaceDeletesContents(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, cluster := NewFakeOC(th)
+
+	oc.CreateNamespace(th, "foo")
+	oc.ApplyString(th, "foo", httpbinYaml)
+	oc.DeleteTestBoundNamespaces(th)
+
+	if cluster.Get(th, "", "namespace", "foo") != nil {
+		t.Fatal("expected namespace foo to be deleted")
+	}
+	if cluster.Get(th, "foo", "deployment", "httpbin") != nil {
+		t.Fatal("expected deployment in namespace foo to be deleted")
+	}
+}
+
+func TestExecAndInvokeUseShellExecutor(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, _ := NewFakeOC(th)
+
+	fake := shell.NewFakeExecutor()
+	fake.On("kubectl exec -n foo sleep-1 -c sleep -- curl http://httpbin:8000/ip").Return(`{"origin": "127.0.0.1"}`)
+	fake.On("oc get pods -n foo").Return("NAME READY")
+	defer shell.SetExecutor(fake)()
+
+	output := oc.Exec(th, podNamed("foo", "sleep-1"), "sleep", "curl http://httpbin:8000/ip")
+	if !strings.Contains(output, "127.0.0.1") {
+		t.Fatalf("unexpected output: %q", output)
+	}
+	oc.Invoke(th, "oc get pods -n foo")
+
+	if len(fake.Calls()) != 2 {
+		t.Fatalf("expected 2 commands, but got %v", fake.Commands())
+	}
+}
+
+func TestGetOCPVersionAndProxy(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, _ := NewFakeOC(th)
+
+	if v := oc.GetOCPVersion(th); v != "4.16.0" {
+		t.Fatalf("unexpected OCP version: %q", v)
+	}
+	if proxy := oc.GetProxy(th); proxy.HTTPProxy != "" {
+		t.Fatalf("unexpected proxy: %v", proxy)
+	}
+}
+
+func podNamed(ns, name string) PodLocatorFunc {
+	return func(t test.TestHelper, oc *OC) NamespacedName {
+		return NewNamespacedName(ns, name)
+	}
+}
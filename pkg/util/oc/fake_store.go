@@ -0,0 +1,409 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// fakeStore keeps the objects of a FakeCluster. Objects are stored by group and resource (the version
+// is ignored), so the same object can be read through any version of the API, just like on a real cluster.
+type fakeStore struct {
+	mu              sync.Mutex
+	mapper          meta.RESTMapper
+	objects         map[schema.GroupResource]map[types.NamespacedName]*unstructured.Unstructured
+	resourceVersion int64
+}
+
+func newFakeStore(mapper meta.RESTMapper) *fakeStore {
+	return &fakeStore{
+		mapper:  mapper,
+		objects: map[schema.GroupResource]map[types.NamespacedName]*unstructured.Unstructured{},
+	}
+}
+
+func (s *fakeStore) namespaced(gvr schema.GroupVersionResource) bool {
+	gvk, err := s.mapper.KindFor(gvr)
+	if err != nil {
+		return true
+	}
+	mapping, err := s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return true
+	}
+	return mapping.Scope.Name() == meta.RESTScopeNameNamespace
+}
+
+func (s *fakeStore) key(gvr schema.GroupVersionResource, ns, name string) types.NamespacedName {
+	if !s.namespaced(gvr) {
+		ns = ""
+	}
+	return types.NamespacedName{Namespace: ns, Name: name}
+}
+
+func (s *fakeStore) nextResourceVersion() string {
+	s.resourceVersion++
+	return strconv.FormatInt(s.resourceVersion, 10)
+}
+
+func (s *fakeStore) get(gvr schema.GroupVersionResource, ns, name string) (*unstructured.Unstructured, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, found := s.objects[gvr.GroupResource()][s.key(gvr, ns, name)]
+	if !found {
+		return nil, apierrors.NewNotFound(gvr.GroupResource(), name)
+	}
+	return obj.DeepCopy(), nil
+}
+
+func (s *fakeStore) list(gvr schema.GroupVersionResource, ns string, selector labels.Selector) *unstructured.UnstructuredList {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+	if gvk, err := s.mapper.KindFor(gvr); err == nil {
+		list.SetAPIVersion(gvk.GroupVersion().String())
+		list.SetKind(gvk.Kind + "List")
+	}
+	list.SetResourceVersion(strconv.FormatInt(s.resourceVersion, 10))
+	for key, obj := range s.objects[gvr.GroupResource()] {
+		if ns != "" && s.namespaced(gvr) && key.Namespace != ns {
+			continue
+		}
+		if selector != nil && !selector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		list.Items = append(list.Items, *obj.DeepCopy())
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		if list.Items[i].GetNamespace() != list.Items[j].GetNamespace() {
+			return list.Items[i].GetNamespace() < list.Items[j].GetNamespace()
+		}
+		return list.Items[i].GetName() < list.Items[j].GetName()
+	})
+	return list
+}
+
+func (s *fakeStore) create(gvr schema.GroupVersionResource, ns string, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj = obj.DeepCopy()
+	if obj.GetName() == "" {
+		if obj.GetGenerateName() == "" {
+			return nil, apierrors.NewBadRequest("name or generateName is required")
+		}
+		obj.SetName(obj.GetGenerateName() + strconv.FormatInt(rand.Int63n(1<<20), 36))
+	}
+	key := s.key(gvr, namespaceOf(obj, ns), obj.GetName())
+	if _, found := s.objects[gvr.GroupResource()][key]; found {
+		return nil, apierrors.NewAlreadyExists(gvr.GroupResource(), obj.GetName())
+	}
+	s.setTypeMeta(gvr, obj)
+	obj.SetNamespace(key.Namespace)
+	obj.SetUID(types.UID(fmt.Sprintf("%08x-0000-0000-0000-%012x", rand.Uint32(), s.resourceVersion+1)))
+	obj.SetCreationTimestamp(metav1.NewTime(time.Now()))
+	obj.SetGeneration(1)
+	obj.SetResourceVersion(s.nextResourceVersion())
+	simulateStatus(obj, hasStatus(obj))
+	s.put(gvr, key, obj)
+	return obj.DeepCopy(), nil
+}
+
+// update replaces the object. If the new object doesn't contain a status, the status of the existing object is kept
+func (s *fakeStore) update(gvr schema.GroupVersionResource, ns string, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj = obj.DeepCopy()
+	key := s.key(gvr, namespaceOf(obj, ns), obj.GetName())
+	existing, found := s.objects[gvr.GroupResource()][key]
+	if !found {
+		return nil, apierrors.NewNotFound(gvr.GroupResource(), obj.GetName())
+	}
+	explicitStatus := hasStatus(obj)
+	if !explicitStatus {
+		if status, found := existing.Object["status"]; found {
+			obj.Object["status"] = status
+		}
+	}
+	s.store(gvr, key, existing, obj, explicitStatus)
+	return obj.DeepCopy(), nil
+}
+
+func (s *fakeStore) patch(gvr schema.GroupVersionResource, ns, name string, pt types.PatchType, data []byte) (*unstructured.Unstructured, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := s.key(gvr, ns, name)
+	existing, found := s.objects[gvr.GroupResource()][key]
+	if !found {
+		return nil, apierrors.NewNotFound(gvr.GroupResource(), name)
+	}
+	original, err := json.Marshal(existing.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched []byte
+	switch pt {
+	case types.JSONPatchType:
+		var p jsonpatch.Patch
+		if p, err = jsonpatch.DecodePatch(data); err == nil {
+			patched, err = p.Apply(original)
+		}
+	case types.MergePatchType:
+		patched, err = jsonpatch.MergePatch(original, data)
+	case types.StrategicMergePatchType:
+		if typed, typeErr := scheme.Scheme.New(existing.GroupVersionKind()); typeErr == nil {
+			patched, err = strategicpatch.StrategicMergePatch(original, data, typed)
+		} else {
+			// custom resources don't support strategic merge patches, so the API server treats them as merge patches
+			patched, err = jsonpatch.MergePatch(original, data)
+		}
+	default:
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("patch type %s is not supported", pt))
+	}
+	if err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("could not apply patch: %v", err))
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(patched); err != nil {
+		return nil, err
+	}
+	var patchDoc map[string]interface{}
+	_ = json.Unmarshal(data, &patchDoc)
+	_, explicitStatus := patchDoc["status"]
+	s.store(gvr, key, existing, obj, explicitStatus)
+	return obj.DeepCopy(), nil
+}
+
+// apply emulates server-side apply by merging the applied configuration into the existing object
+func (s *fakeStore) apply(gvr schema.GroupVersionResource, ns, name string, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if _, err := s.get(gvr, namespaceOf(obj, ns), name); apierrors.IsNotFound(err) {
+		obj = obj.DeepCopy()
+		obj.SetName(name)
+		return s.create(gvr, ns, obj)
+	}
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return nil, err
+	}
+	return s.patch(gvr, namespaceOf(obj, ns), name, types.MergePatchType, data)
+}
+
+func (s *fakeStore) delete(gvr schema.GroupVersionResource, ns, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := s.key(gvr, ns, name)
+	if _, found := s.objects[gvr.GroupResource()][key]; !found {
+		return apierrors.NewNotFound(gvr.GroupResource(), name)
+	}
+	delete(s.objects[gvr.GroupResource()], key)
+	if gvr.GroupResource() == (schema.GroupResource{Resource: "namespaces"}) {
+		// deleting a namespace deletes everything in it
+		for _, objects := range s.objects {
+			for k := range objects {
+				if k.Namespace == name {
+					delete(objects, k)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *fakeStore) deleteCollection(gvr schema.GroupVersionResource, ns string, selector labels.Selector) error {
+	for _, obj := range s.list(gvr, ns, selector).Items {
+		if err := s.delete(gvr, obj.GetNamespace(), obj.GetName()); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// store saves the modified version of an existing object, bumping the generation if the spec changed
+func (s *fakeStore) store(gvr schema.GroupVersionResource, key types.NamespacedName, existing, obj *unstructured.Unstructured, explicitStatus bool) {
+	s.setTypeMeta(gvr, obj)
+	obj.SetNamespace(key.Namespace)
+	obj.SetName(key.Name)
+	obj.SetUID(existing.GetUID())
+	obj.SetCreationTimestamp(existing.GetCreationTimestamp())
+	generation := existing.GetGeneration()
+	if !equalJSON(existing.Object["spec"], obj.Object["spec"]) {
+		generation++
+	}
+	obj.SetGeneration(generation)
+	obj.SetResourceVersion(s.nextResourceVersion())
+	simulateStatus(obj, explicitStatus)
+	s.put(gvr, key, obj)
+}
+
+func (s *fakeStore) put(gvr schema.GroupVersionResource, key types.NamespacedName, obj *unstructured.Unstructured) {
+	if s.objects[gvr.GroupResource()] == nil {
+		s.objects[gvr.GroupResource()] = map[types.NamespacedName]*unstructured.Unstructured{}
+	}
+	s.objects[gvr.GroupResource()][key] = obj
+}
+
+func (s *fakeStore) setTypeMeta(gvr schema.GroupVersionResource, obj *unstructured.Unstructured) {
+	if obj.GetKind() != "" && obj.GetAPIVersion() != "" {
+		return
+	}
+	if gvk, err := s.mapper.KindFor(gvr); err == nil {
+		obj.SetGroupVersionKind(gvk)
+	}
+}
+
+func hasStatus(obj *unstructured.Unstructured) bool {
+	status, found := obj.Object["status"]
+	if !found {
+		return false
+	}
+	m, ok := status.(map[string]interface{})
+	return !ok || len(m) > 0
+}
+
+// simulateStatus makes pods and deployments immediately ready, since there are no controllers
+// in the fake cluster. It does nothing if the status was explicitly set by the client.
+func simulateStatus(obj *unstructured.Unstructured, explicitStatus bool) {
+	if explicitStatus {
+		return
+	}
+	switch obj.GroupVersionKind().GroupKind() {
+	case schema.GroupKind{Kind: "Pod"}:
+		_ = unstructured.SetNestedField(obj.Object, "Running", "status", "phase")
+		_ = unstructured.SetNestedSlice(obj.Object, []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True"},
+		}, "status", "conditions")
+	case schema.GroupKind{Group: "apps", Kind: "Deployment"}:
+		replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		if !found {
+			replicas = 1
+		}
+		_ = unstructured.SetNestedMap(obj.Object, map[string]interface{}{
+			"observedGeneration": obj.GetGeneration(),
+			"replicas":           replicas,
+			"updatedReplicas":    replicas,
+			"readyReplicas":      replicas,
+			"availableReplicas":  replicas,
+		}, "status")
+	}
+}
+
+func equalJSON(a, b interface{}) bool {
+	aJSON, _ := json.Marshal(a)
+	bJSON, _ := json.Marshal(b)
+	return string(aJSON) == string(bJSON)
+}
+
+// fakeDynamicClient is a dynamic.Interface backed by a fakeStore
+type fakeDynamicClient struct {
+	store *fakeStore
+}
+
+var _ dynamic.Interface = &fakeDynamicClient{}
+
+func (d *fakeDynamicClient) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &fakeResourceClient{store: d.store, gvr: gvr}
+}
+
+type fakeResourceClient struct {
+	store *fakeStore
+	gvr   schema.GroupVersionResource
+	ns    string
+}
+
+var _ dynamic.NamespaceableResourceInterface = &fakeResourceClient{}
+
+func (r *fakeResourceClient) Namespace(ns string) dynamic.ResourceInterface {
+	return &fakeResourceClient{store: r.store, gvr: r.gvr, ns: ns}
+}
+
+func (r *fakeResourceClient) Create(_ context.Context, obj *unstructured.Unstructured, _ metav1.CreateOptions, _ ...string) (*unstructured.Unstructured, error) {
+	return r.store.create(r.gvr, r.ns, obj)
+}
+
+func (r *fakeResourceClient) Update(_ context.Context, obj *unstructured.Unstructured, _ metav1.UpdateOptions, _ ...string) (*unstructured.Unstructured, error) {
+	return r.store.update(r.gvr, r.ns, obj)
+}
+
+func (r *fakeResourceClient) UpdateStatus(_ context.Context, obj *unstructured.Unstructured, _ metav1.UpdateOptions) (*unstructured.Unstructured, error) {
+	return r.store.update(r.gvr, r.ns, obj)
+}
+
+func (r *fakeResourceClient) Delete(_ context.Context, name string, _ metav1.DeleteOptions, _ ...string) error {
+	return r.store.delete(r.gvr, r.ns, name)
+}
+
+func (r *fakeResourceClient) DeleteCollection(_ context.Context, _ metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	selector, err := labels.Parse(listOptions.LabelSelector)
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	return r.store.deleteCollection(r.gvr, r.ns, selector)
+}
+
+func (r *fakeResourceClient) Get(_ context.Context, name string, _ metav1.GetOptions, _ ...string) (*unstructured.Unstructured, error) {
+	return r.store.get(r.gvr, r.ns, name)
+}
+
+func (r *fakeResourceClient) List(_ context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	return r.store.list(r.gvr, r.ns, selector), nil
+}
+
+func (r *fakeResourceClient) Watch(_ context.Context, _ metav1.ListOptions) (watch.Interface, error) {
+	return nil, apierrors.NewMethodNotSupported(r.gvr.GroupResource(), "watch")
+}
+
+func (r *fakeResourceClient) Patch(_ context.Context, name string, pt types.PatchType, data []byte, _ metav1.PatchOptions, _ ...string) (*unstructured.Unstructured, error) {
+	if pt == types.ApplyPatchType {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(data); err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+		return r.store.apply(r.gvr, r.ns, name, obj)
+	}
+	return r.store.patch(r.gvr, r.ns, name, pt, data)
+}
+
+func (r *fakeResourceClient) Apply(_ context.Context, name string, obj *unstructured.Unstructured, _ metav1.ApplyOptions, _ ...string) (*unstructured.Unstructured, error) {
+	return r.store.apply(r.gvr, r.ns, name, obj)
+}
+
+func (r *fakeResourceClient) ApplyStatus(_ context.Context, name string, obj *unstructured.Unstructured, _ metav1.ApplyOptions) (*unstructured.Unstructured, error) {
+	return r.store.apply(r.gvr, r.ns, name, obj)
+}
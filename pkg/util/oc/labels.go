@@ -18,6 +18,10 @@ const (
 	// MaistraTestLabel is the label added to resources that are created by the maistra test tool.
 	// These resources are labeled so they can easily be purged at the beginning or end of test runs.
 	MaistraTestLabel = "maistra.io/maistra-test-tool"
+	// TestLabel is the label added to objects applied by a test. Its value is the name of the top-level test.
+	TestLabel = "maistra.io/test"
+	// TestRunLabel is the label added to objects applied by a test. Its value is the ID of the test run (see env.GetRunId).
+	TestRunLabel = "maistra.io/test-run"
 	// A test bound namespace is one that is expected to be deleted after each test run.
 	// Probably all namespaces fall into this category.
 	testBoundNSLabelValue = "test-bound-ns"
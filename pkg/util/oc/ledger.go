@@ -0,0 +1,391 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/env"
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// A ledger records the objects that a top-level test creates with ApplyString, ApplyTemplate and ApplyFile.
+// While the test (or any of its subtests) runs, the applied objects are stamped with the TestLabel,
+// TestRunLabel and MaistraTestLabel labels. Objects that didn't exist before are deleted in reverse order
+// after all cleanup functions of the test have been executed, unless they no longer carry the labels
+// (e.g. because a cleanup function recreated them) or AUTO_CLEANUP=false. Objects created by an apply
+// that failed halfway are recorded as well.
+//
+// A test that calls Parallel() runs concurrently with other parallel tests, so its leak check only considers
+// the objects stamped with its own labels.
+//
+// Objects applied by cleanup functions aren't tracked, since they usually restore the state that the
+// test found, and neither are objects applied during the suite setup.
+type ledger struct {
+	test string
+
+	mu      sync.Mutex
+	objects []trackedObject
+	// before contains the leak candidates that existed when the test started
+	before map[objectRef]bool
+}
+
+// trackedObject is an object that a test created
+type trackedObject struct {
+	oc OC
+	// kind is the kind in the form printed by oc apply (e.g. "deployment.apps")
+	kind      string
+	namespace string
+	name      string
+}
+
+// sharedKinds are the kinds of objects that tests share with subsequent tests (e.g. the control plane
+// deployed by ossm.DeployControlPlane), so they are stamped, but never deleted automatically
+var sharedKinds = map[string]bool{
+	"namespace":                          true,
+	"servicemeshcontrolplane.maistra.io": true,
+	"servicemeshmemberroll.maistra.io":   true,
+	"istio.sailoperator.io":              true,
+	"istiocni.sailoperator.io":           true,
+}
+
+// ledgers contains the ledgers of the running top-level tests, by test name
+var ledgers sync.Map
+
+func init() {
+	// the cleanup functions registered by hooks run in reverse order, so the drift check
+	// sees the cluster after the ledger has deleted the objects created by the test
+	test.RegisterTestHook(startDriftCheck)
+	test.RegisterTestHook(startLedger)
+}
+
+func startLedger(t test.TestHelper) {
+	t.T().Helper()
+	l := &ledger{test: t.Name()}
+	o := *DefaultOC
+	checkLeaks := env.GetLeakCheck() != "off"
+	if checkLeaks {
+		l.before = snapshotLeakCandidates(t, o, "")
+	}
+	ledgers.Store(l.test, l)
+	t.T().Cleanup(func() {
+		t.T().Helper()
+		ledgers.Delete(l.test)
+		if env.IsAutoCleanupEnabled() {
+			l.deleteObjects(t)
+		}
+		if checkLeaks {
+			l.checkLeaks(t, o)
+		}
+	})
+}
+
+// ledgerOf returns the ledger of the test, or nil if the objects applied by the test aren't tracked
+func ledgerOf(t test.TestHelper) *ledger {
+	if test.IsCleaningUp(t) {
+		return nil
+	}
+	// subtests share the ledger of their top-level test
+	if l := lookupTopLevel(&ledgers, t); l != nil {
+		return l.(*ledger)
+	}
+	return nil
+}
+
+// labels returns the labels with which the objects applied by the test are stamped
+func (l *ledger) labels() map[string]string {
+	return map[string]string{
+		TestLabel:    labelValue(l.test),
+		TestRunLabel: labelValue(env.GetRunId()),
+	}
+}
+
+func (l *ledger) selector() string {
+	return metav1.FormatLabelSelector(metav1.SetAsLabelSelector(l.labels()))
+}
+
+// stamp adds the test labels to all objects in the manifests
+func (l *ledger) stamp(t test.TestHelper, manifests string) string {
+	t.T().Helper()
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	var docs []string
+	for _, obj := range objects {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		for k, v := range l.labels() {
+			labels[k] = v
+		}
+		if _, found := labels[MaistraTestLabel]; !found {
+			labels[MaistraTestLabel] = ""
+		}
+		obj.SetLabels(labels)
+		doc, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			t.Fatalf("could not marshal %s %s to YAML: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		docs = append(docs, string(doc))
+	}
+	return concatenateYamls(docs...)
+}
+
+func (l *ledger) record(o OC, objects ...trackedObject) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, obj := range objects {
+		if sharedKinds[obj.kind] {
+			continue
+		}
+		obj.oc = o
+		l.objects = append(l.objects, obj)
+	}
+}
+
+// deleteObjects deletes the objects created by the test in the reverse order of their creation.
+// Consecutive objects of the same kind in the same namespace are deleted with a single command.
+func (l *ledger) deleteObjects(t test.TestHelper) {
+	t.T().Helper()
+	l.mu.Lock()
+	objects := append([]trackedObject(nil), l.objects...)
+	l.mu.Unlock()
+	if len(objects) == 0 {
+		return
+	}
+
+	t.T().Log()
+	t.T().Logf("Deleting the objects created by %s (set AUTO_CLEANUP=false to keep them)", l.test)
+	selector := l.selector()
+	for end := len(objects); end > 0; {
+		last := objects[end-1]
+		start := end - 1
+		for start > 0 && objects[start-1].sameKindAndNamespace(last) {
+			start--
+		}
+		stamped := map[string]bool{}
+		for _, name := range last.oc.listNames(t, last.namespace, last.kind, selector) {
+			stamped[name] = true
+		}
+		var names []string
+		for i := end - 1; i >= start; i-- {
+			if name := objects[i].name; stamped[name] {
+				names = append(names, name)
+				delete(stamped, name) // the object may have been created more than once
+			}
+		}
+		if len(names) > 0 {
+			last.oc.DeleteResource(t, last.namespace, last.kind, names...)
+		}
+		end = start
+	}
+}
+
+func (o trackedObject) sameKindAndNamespace(other trackedObject) bool {
+	return o.oc == other.oc && o.kind == other.kind && o.namespace == other.namespace
+}
+
+// checkLeaks reports the objects that outlive the namespaces of the test and didn't exist when the test started
+func (l *ledger) checkLeaks(t test.TestHelper, o OC) {
+	t.T().Helper()
+	selector := ""
+	if test.IsParallel(t) {
+		// objects created by the tests running concurrently must not be attributed to this test
+		selector = l.selector()
+	}
+	leaks := findLeaks(l.before, snapshotLeakCandidates(t, o, selector), o.listNames(t, "", "namespaces", testBoundNamespacesSelector))
+	if len(leaks) == 0 {
+		return
+	}
+	var refs []string
+	for _, ref := range leaks {
+		refs = append(refs, ref.String())
+	}
+	msg := fmt.Sprintf("%s left behind objects that outlive its namespaces and may affect subsequent tests:\n  %s",
+		l.test, strings.Join(refs, "\n  "))
+	if env.GetLeakCheck() == "fail" {
+		t.Error(msg)
+	} else {
+		t.T().Log("WARNING: " + msg)
+	}
+}
+
+// parseCreatedObjects returns the objects that oc apply reports as created (e.g. "deployment.apps/foo created").
+// The namespaces are taken from the applied manifests, since oc doesn't print them.
+func parseCreatedObjects(t test.TestHelper, output string, ns string, manifests string) []trackedObject {
+	t.T().Helper()
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	namespaces := map[string]string{}
+	for _, obj := range objects {
+		namespaces[kindOf(obj)+"/"+obj.GetName()] = namespaceOf(obj, ns)
+	}
+
+	var created []trackedObject
+	for _, line := range strings.Split(output, "\n") {
+		ref, found := strings.CutSuffix(strings.TrimSpace(line), " created")
+		if !found {
+			continue
+		}
+		kind, name, found := strings.Cut(ref, "/")
+		if !found || strings.Contains(kind, " ") {
+			continue
+		}
+		namespace, found := namespaces[ref]
+		if !found {
+			namespace = ns
+		}
+		created = append(created, trackedObject{kind: kind, namespace: namespace, name: name})
+	}
+	return created
+}
+
+// listNames returns the names of the objects of the specified kind that match the selector. Unlike
+// GetAllResourcesNames, it doesn't fail if there are no such objects or if the kind doesn't exist.
+func (o OC) listNames(t test.TestHelper, ns, kind, selector string) []string {
+	t.T().Helper()
+	if o.native != nil {
+		if _, err := o.native.clients(t).resourceMapping(kind); err != nil {
+			return nil
+		}
+		return o.native.names(t, ns, kind, selector)
+	}
+	var names []string
+	o.withKubeconfig(t, func() {
+		t.T().Helper()
+		result := shell.Run(t, fmt.Sprintf("oc %s get %s -l '%s' -o jsonpath='{.items[*].metadata.name}'", nsFlag(ns), kind, selector))
+		if result.Succeeded() {
+			names = strings.Fields(result.Stdout)
+		}
+	})
+	return names
+}
+
+var invalidLabelValueChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// labelValue converts the string into a valid label value (at most 63 alphanumeric characters, '-', '_' or '.',
+// starting and ending with an alphanumeric character)
+func labelValue(str string) string {
+	value := invalidLabelValueChars.ReplaceAllString(str, "_")
+	if len(value) > 63 {
+		value = value[:63]
+	}
+	return strings.Trim(value, "._-")
+}
+
+// leakCheckKinds are the kinds of objects that aren't deleted together with the namespaces of a test.
+// The sharedKinds are left out, because tests leave them behind on purpose.
+// Each group is listed with a single command; groups whose CRDs aren't installed are ignored.
+var leakCheckKinds = []string{
+	"clusterroles.rbac.authorization.k8s.io,clusterrolebindings.rbac.authorization.k8s.io," +
+		"mutatingwebhookconfigurations.admissionregistration.k8s.io,validatingwebhookconfigurations.admissionregistration.k8s.io," +
+		"certificatesigningrequests.certificates.k8s.io",
+	"virtualservices.networking.istio.io,destinationrules.networking.istio.io,gateways.networking.istio.io," +
+		"serviceentries.networking.istio.io,sidecars.networking.istio.io,envoyfilters.networking.istio.io," +
+		"peerauthentications.security.istio.io,authorizationpolicies.security.istio.io,requestauthentications.security.istio.io",
+}
+
+// leakCandidateTemplate prints the fields that decide whether an object counts as a leak
+const leakCandidateTemplate = `{range .items[*]}{.kind}{"\t"}{.metadata.namespace}{"\t"}{.metadata.name}{"\t"}` +
+	`{.metadata.ownerReferences[*].kind}{.metadata.labels.app\.kubernetes\.io/managed-by}{.metadata.labels.maistra\.io/owner}{"\t"}` +
+	`{.spec.signerName}{"\n"}{end}`
+
+// objectRef identifies an object in the leak check
+type objectRef struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (r objectRef) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s %s", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+// snapshotLeakCandidates returns the objects of the leakCheckKinds that match the selector (if not empty)
+// and could be leaked by a test. Objects that are owned by another object or managed by an operator are removed together with
+// their owner, and kubelets create CSRs all the time, so these objects are ignored.
+func snapshotLeakCandidates(t test.TestHelper, o OC, selector string) map[objectRef]bool {
+	t.T().Helper()
+	candidates := map[objectRef]bool{}
+	for _, kinds := range leakCheckKinds {
+		output, ok := o.listAllNamespaces(t, kinds, selector, leakCandidateTemplate)
+		if !ok {
+			continue
+		}
+		for _, line := range strings.Split(output, "\n") {
+			fields := strings.Split(line, "\t")
+			if len(fields) != 5 || fields[3] != "" || strings.HasPrefix(fields[4], "kubernetes.io/kube") {
+				continue
+			}
+			candidates[objectRef{Kind: fields[0], Namespace: fields[1], Name: fields[2]}] = true
+		}
+	}
+	return candidates
+}
+
+// findLeaks returns the objects that didn't exist before the test, except those in test-bound namespaces,
+// which are deleted (or recreated) by the next test that uses them
+func findLeaks(before, after map[objectRef]bool, testBoundNamespaces []string) []objectRef {
+	ignored := map[string]bool{}
+	for _, ns := range testBoundNamespaces {
+		ignored[ns] = true
+	}
+	var leaks []objectRef
+	for ref := range after {
+		if !before[ref] && !ignored[ref.Namespace] {
+			leaks = append(leaks, ref)
+		}
+	}
+	sort.Slice(leaks, func(i, j int) bool {
+		return leaks[i].String() < leaks[j].String()
+	})
+	return leaks
+}
+
+// listAllNamespaces lists the objects of the comma-separated kinds that match the selector (if not empty)
+// in all namespaces and formats them with the JSONPath template. It returns false if any of the kinds doesn't exist.
+func (o OC) listAllNamespaces(t test.TestHelper, kinds string, selector string, template string) (string, bool) {
+	t.T().Helper()
+	if o.native != nil {
+		return o.native.listAllNamespaces(t, kinds, selector, template)
+	}
+	selectorFlag := ""
+	if selector != "" {
+		selectorFlag = fmt.Sprintf(" -l '%s'", selector)
+	}
+	var output string
+	var ok bool
+	o.withKubeconfig(t, func() {
+		t.T().Helper()
+		result := shell.Run(t, fmt.Sprintf("oc get %s --all-namespaces%s -o jsonpath='%s'", kinds, selectorFlag, template))
+		output, ok = result.Stdout, result.Succeeded()
+	})
+	return output, ok
+}
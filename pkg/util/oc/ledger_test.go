@@ -0,0 +1,278 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+const sharedConfigMapYaml = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: shared
+  namespace: foo
+data:
+  key: before
+`
+
+const configMapYaml = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: created-by-subtest
+data:
+  key: value
+`
+
+const istioYaml = `
+apiVersion: sailoperator.io/v1
+kind: Istio
+metadata:
+  name: default
+spec:
+  namespace: istio-system
+---
+apiVersion: sailoperator.io/v1
+kind: IstioCNI
+metadata:
+  name: default
+spec:
+  namespace: istio-cni
+`
+
+const restoredConfigMapYaml = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: restored-by-cleanup
+data:
+  key: value
+`
+
+func TestLedgerDeletesObjectsCreatedByTest(t *testing.T) {
+	t.Setenv("TEST_GROUP", string(test.Full))
+	t.Setenv("OCP_ARCH", "x86")
+	t.Setenv("RUN_ID", "run-1")
+	t.Setenv("LEAK_CHECK", "warn")
+	th := test.NewTestHelper(t)
+	oc, cluster := NewFakeOC(th, sharedConfigMapYaml)
+	cluster.UseAsDefault(th)
+
+	t.Run("test", func(t *testing.T) {
+		test.NewTest(t).Groups(test.Full).Run(func(t test.TestHelper) {
+			t.Cleanup(func() {
+				oc.ApplyString(t, "foo", restoredConfigMapYaml)
+			})
+			oc.ApplyString(t, "foo", httpbinYaml)
+			oc.ApplyString(t, "foo", sharedConfigMapYaml)
+			oc.ApplyString(t, "istio-system", smcpYaml)
+			oc.ApplyString(t, "", istioYaml)
+			t.NewSubTest("subtest").Run(func(t test.TestHelper) {
+				oc.ApplyString(t, "foo", configMapYaml)
+			})
+
+			labels := cluster.Get(t, "foo", "svc", "httpbin").GetLabels()
+			expected := map[string]string{
+				"app":            "httpbin",
+				TestLabel:        "TestLedgerDeletesObjectsCreatedByTest_test",
+				TestRunLabel:     "run-1",
+				MaistraTestLabel: "",
+			}
+			if !reflect.DeepEqual(labels, expected) {
+				t.Errorf("expected labels %v, got %v", expected, labels)
+			}
+		})
+	})
+
+	for _, deleted := range []struct{ kind, name string }{{"svc", "httpbin"}, {"deploy", "httpbin"}, {"cm", "created-by-subtest"}} {
+		if cluster.Get(th, "foo", deleted.kind, deleted.name) != nil {
+			t.Errorf("expected %s %s to be deleted at the end of the test", deleted.kind, deleted.name)
+		}
+	}
+	if cluster.Get(th, "foo", "cm", "shared") == nil {
+		t.Error("the configmap that existed before the test must not be deleted")
+	}
+	if cluster.Get(th, "istio-system", "smcp", "basic") == nil {
+		t.Error("the SMCP is shared with other tests and must not be deleted")
+	}
+	for _, kind := range []string{"istio", "istiocni"} {
+		if cluster.Get(th, "", kind, "default") == nil {
+			t.Errorf("the %s is shared with other tests and must not be deleted", kind)
+		}
+	}
+	restored := cluster.Get(th, "foo", "cm", "restored-by-cleanup")
+	if restored == nil {
+		t.Fatal("the configmap applied by the cleanup function must not be deleted")
+	}
+	if _, found := restored.GetLabels()[TestLabel]; found {
+		t.Error("objects applied by cleanup functions must not be stamped")
+	}
+}
+
+func TestApplyOutsideOfTestIsNotStamped(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, cluster := NewFakeOC(th)
+	oc.ApplyString(th, "foo", configMapYaml)
+	if labels := cluster.Get(th, "foo", "cm", "created-by-subtest").GetLabels(); len(labels) != 0 {
+		t.Errorf("expected no labels, got %v", labels)
+	}
+}
+
+func TestSnapshotLeakCandidates(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, _ := NewFakeOC(th, `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: created-by-test
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: applied-by-test
+  labels:
+    maistra.io/test: TestFoo
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: owned
+  ownerReferences:
+  - apiVersion: maistra.io/v2
+    kind: ServiceMeshControlPlane
+    name: basic
+    uid: "1234"
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: istiod-basic
+  labels:
+    maistra.io/owner: istio-system
+---
+apiVersion: certificates.k8s.io/v1
+kind: CertificateSigningRequest
+metadata:
+  name: csr-kubelet
+spec:
+  signerName: kubernetes.io/kubelet-serving
+---
+apiVersion: certificates.k8s.io/v1
+kind: CertificateSigningRequest
+metadata:
+  name: csr-istio
+spec:
+  signerName: example.com/istio
+---
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: reviews
+  namespace: istio-system
+`, istioYaml)
+
+	candidates := snapshotLeakCandidates(th, *oc, "")
+	expected := map[objectRef]bool{
+		{Kind: "ClusterRole", Name: "created-by-test"}:                       true,
+		{Kind: "ClusterRole", Name: "applied-by-test"}:                       true,
+		{Kind: "CertificateSigningRequest", Name: "csr-istio"}:               true,
+		{Kind: "VirtualService", Namespace: "istio-system", Name: "reviews"}: true,
+	}
+	if !reflect.DeepEqual(candidates, expected) {
+		t.Errorf("expected %v, got %v", expected, candidates)
+	}
+
+	candidates = snapshotLeakCandidates(th, *oc, TestLabel+"=TestFoo")
+	expected = map[objectRef]bool{{Kind: "ClusterRole", Name: "applied-by-test"}: true}
+	if !reflect.DeepEqual(candidates, expected) {
+		t.Errorf("expected %v, got %v", expected, candidates)
+	}
+}
+
+func TestFindLeaks(t *testing.T) {
+	existing := objectRef{Kind: "ClusterRole", Name: "existing"}
+	leaked := objectRef{Kind: "ClusterRole", Name: "leaked"}
+	meshConfig := objectRef{Kind: "PeerAuthentication", Namespace: "istio-system", Name: "default"}
+	testConfig := objectRef{Kind: "VirtualService", Namespace: "bookinfo", Name: "reviews"}
+
+	before := map[objectRef]bool{existing: true}
+	after := map[objectRef]bool{existing: true, leaked: true, meshConfig: true, testConfig: true}
+	leaks := findLeaks(before, after, []string{"bookinfo"})
+	expected := []objectRef{leaked, meshConfig}
+	if !reflect.DeepEqual(leaks, expected) {
+		t.Errorf("expected %v, got %v", expected, leaks)
+	}
+}
+
+func TestParseCreatedObjects(t *testing.T) {
+	th := test.NewTestHelper(t)
+	output := `service/httpbin created
+deployment.apps/httpbin unchanged
+gateway.networking.istio.io/httpbin-gateway created
+Warning: resource configmaps/foo is missing the kubectl.kubernetes.io/last-applied-configuration annotation
+`
+	manifests := httpbinYaml + `---
+apiVersion: networking.istio.io/v1beta1
+kind: Gateway
+metadata:
+  name: httpbin-gateway
+  namespace: istio-system
+`
+	created := parseCreatedObjects(th, output, "foo", manifests)
+	expected := []trackedObject{
+		{kind: "service", namespace: "foo", name: "httpbin"},
+		{kind: "gateway.networking.istio.io", namespace: "istio-system", name: "httpbin-gateway"},
+	}
+	if !reflect.DeepEqual(created, expected) {
+		t.Errorf("expected %+v, got %+v", expected, created)
+	}
+}
+
+func TestApplyReturnsObjectsCreatedBeforeFailure(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, _ := NewFakeOC(th)
+	created, err := oc.native.apply(th, "foo", configMapYaml+`---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: unknown
+`)
+	if err == nil {
+		t.Error("expected an error for the unknown kind")
+	}
+	expected := []trackedObject{{kind: "configmap", namespace: "foo", name: "created-by-subtest"}}
+	if !reflect.DeepEqual(created, expected) {
+		t.Errorf("expected %+v, got %+v", expected, created)
+	}
+}
+
+func TestLabelValue(t *testing.T) {
+	cases := map[string]string{
+		"TestFoo":                        "TestFoo",
+		"TestFoo/sub test":               "TestFoo_sub_test",
+		"_leading-and-trailing_":         "leading-and-trailing",
+		"Test" + strings.Repeat("x", 70): "Test" + strings.Repeat("x", 59),
+	}
+	for input, expected := range cases {
+		if actual := labelValue(input); actual != expected {
+			t.Errorf("labelValue(%q): expected %q, got %q", input, expected, actual)
+		}
+	}
+}
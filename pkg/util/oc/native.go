@@ -0,0 +1,209 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// fieldManager is the field manager used for server-side apply and all other writes done by the native client
+const fieldManager = "maistra-test-tool"
+
+// nativeClient talks to the cluster through client-go instead of shelling out to the oc binary.
+// The clients are created lazily on first use, so that creating an OC (e.g. DefaultOC) never
+// requires a reachable cluster.
+type nativeClient struct {
+	kubeconfig string
+
+	once      sync.Once
+	initErr   error
+	config    *rest.Config
+	namespace string
+	kube      kubernetes.Interface
+	dynamic   dynamic.Interface
+	discovery discovery.CachedDiscoveryInterface
+	mapper    meta.ResettableRESTMapper
+	expander  meta.RESTMapper
+}
+
+func newNativeClient(kubeconfig string) *nativeClient {
+	return &nativeClient{kubeconfig: kubeconfig}
+}
+
+func (c *nativeClient) init() error {
+	c.once.Do(func() {
+		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+		if c.kubeconfig != "" {
+			loadingRules.ExplicitPath = c.kubeconfig
+		}
+		clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+
+		config, err := clientConfig.ClientConfig()
+		if err != nil {
+			c.initErr = fmt.Errorf("could not load kubeconfig %q: %v", c.kubeconfig, err)
+			return
+		}
+		// the default limits are too low for tests that create lots of objects in a short time
+		config.QPS = 50
+		config.Burst = 100
+
+		namespace, _, err := clientConfig.Namespace()
+		if err != nil {
+			c.initErr = fmt.Errorf("could not determine default namespace: %v", err)
+			return
+		}
+
+		kube, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			c.initErr = fmt.Errorf("could not create kubernetes client: %v", err)
+			return
+		}
+		dyn, err := dynamic.NewForConfig(config)
+		if err != nil {
+			c.initErr = fmt.Errorf("could not create dynamic client: %v", err)
+			return
+		}
+
+		c.config = config
+		c.namespace = namespace
+		c.kube = kube
+		c.dynamic = dyn
+		c.discovery = memory.NewMemCacheClient(kube.Discovery())
+		c.mapper = restmapper.NewDeferredDiscoveryRESTMapper(c.discovery)
+		c.expander = restmapper.NewShortcutExpander(c.mapper, c.discovery, nil)
+	})
+	return c.initErr
+}
+
+// clients ensures the clients are initialized and fails the test if they can't be
+func (c *nativeClient) clients(t test.TestHelper) *nativeClient {
+	t.T().Helper()
+	if err := c.init(); err != nil {
+		t.Fatalf("native client: %v", err)
+	}
+	return c
+}
+
+// supportsStreaming returns false if the client can't exec into pods or stream logs,
+// because it isn't connected to a real API server (see NewFakeOC)
+func (c *nativeClient) supportsStreaming() bool {
+	return c.config != nil
+}
+
+// ctx returns the context of API requests, which are aborted when the test's context is canceled
+// (e.g. when a retry attempt times out)
+func (c *nativeClient) ctx(t test.TestHelper) context.Context {
+	return t.Context()
+}
+
+// commandEnv returns the environment for commands that still need to be executed
+// in a shell (see OC.Invoke). Unlike OC.withKubeconfig, it doesn't modify the
+// environment of the current process.
+func (c *nativeClient) commandEnv() []string {
+	if c.kubeconfig == "" {
+		return nil
+	}
+	return append(os.Environ(), "KUBECONFIG="+c.kubeconfig)
+}
+
+// resourceMapping resolves the specified kind (e.g. "pods", "deploy", "smcp", "Istio",
+// "gateways.networking.istio.io") the same way that `oc get` does.
+func (c *nativeClient) resourceMapping(kind string) (*meta.RESTMapping, error) {
+	mapping, err := c.lookupMapping(kind)
+	if meta.IsNoMatchError(err) {
+		// the resource type may have been registered after the discovery information was cached (e.g. a new CRD)
+		c.mapper.Reset()
+		mapping, err = c.lookupMapping(kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("the server doesn't have a resource type %q: %v", kind, err)
+	}
+	return mapping, nil
+}
+
+func (c *nativeClient) lookupMapping(kind string) (*meta.RESTMapping, error) {
+	// "a.b.c" can be either resource.version.group or resource.group, so we try both (just like kubectl)
+	fullySpecified, gr := schema.ParseResourceArg(strings.ToLower(kind))
+	var gvr schema.GroupVersionResource
+	var err error
+	if fullySpecified != nil {
+		gvr, err = c.expander.ResourceFor(*fullySpecified)
+	}
+	if fullySpecified == nil || err != nil {
+		gvr, err = c.expander.ResourceFor(gr.WithVersion(""))
+	}
+	if err != nil {
+		return nil, err
+	}
+	gvk, err := c.mapper.KindFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	return c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+}
+
+// mappingForKind returns the REST mapping for the given group, version and kind (as found in a manifest)
+func (c *nativeClient) mappingForKind(gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		c.mapper.Reset()
+		mapping, err = c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resource mapping not found for %s: %v", gvk, err)
+	}
+	return mapping, nil
+}
+
+// resourceClient returns the dynamic client for the given mapping. The namespace is ignored for cluster-scoped resources.
+func (c *nativeClient) resourceClient(mapping *meta.RESTMapping, ns string) dynamic.ResourceInterface {
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if ns == "" {
+			ns = c.namespace
+		}
+		return c.dynamic.Resource(mapping.Resource).Namespace(ns)
+	}
+	return c.dynamic.Resource(mapping.Resource)
+}
+
+// resourceRef returns a kubectl-like reference to an object (e.g. "deployment.apps/istiod")
+func resourceRef(mapping *meta.RESTMapping, name string) string {
+	return kindRef(mapping) + "/" + name
+}
+
+// kindRef returns the kind in the form that kubectl prints (e.g. "deployment.apps")
+func kindRef(mapping *meta.RESTMapping) string {
+	kind := strings.ToLower(mapping.GroupVersionKind.Kind)
+	if mapping.GroupVersionKind.Group != "" {
+		kind += "." + mapping.GroupVersionKind.Group
+	}
+	return kind
+}
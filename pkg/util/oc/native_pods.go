@@ -0,0 +1,400 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// syncBuffer is a bytes.Buffer that can be written to concurrently (remotecommand writes stdout and stderr from different goroutines)
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// exec runs the command in the container using the SPDY exec subresource and returns
+// the combined stdout and stderr, just like `kubectl exec` does.
+func (c *nativeClient) exec(t test.TestHelper, pod NamespacedName, container string, command []string) (string, error) {
+	t.T().Helper()
+	c.clients(t)
+	if container == "" {
+		var err error
+		container, err = c.defaultContainer(t, pod)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	req := c.kube.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(c.config, "POST", req.URL())
+	if err != nil {
+		return "", fmt.Errorf("could not create executor: %v", err)
+	}
+
+	var output syncBuffer
+	err = executor.StreamWithContext(c.ctx(t), remotecommand.StreamOptions{
+		Stdout: &output,
+		Stderr: &output,
+	})
+	return output.String(), err
+}
+
+// defaultContainer returns the container that kubectl would choose when no container is specified
+func (c *nativeClient) defaultContainer(t test.TestHelper, pod NamespacedName) (string, error) {
+	p, err := c.kube.CoreV1().Pods(pod.Namespace).Get(c.ctx(t), pod.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if name := p.Annotations["kubectl.kubernetes.io/default-container"]; name != "" {
+		return name, nil
+	}
+	if len(p.Spec.Containers) == 0 {
+		return "", fmt.Errorf("pod %s/%s has no containers", pod.Namespace, pod.Name)
+	}
+	return p.Spec.Containers[0].Name, nil
+}
+
+// logs returns the logs of the container. If since is non-zero, only the logs newer than since are returned.
+func (c *nativeClient) logs(t test.TestHelper, pod NamespacedName, container string, since time.Duration) string {
+	t.T().Helper()
+	c.clients(t)
+	opts := &corev1.PodLogOptions{Container: container}
+	if since > 0 {
+		seconds := int64(since.Round(time.Second).Seconds())
+		if seconds < 1 {
+			seconds = 1
+		}
+		opts.SinceSeconds = &seconds
+	}
+	out, err := c.streamLogs(t, pod, opts)
+	if err != nil {
+		t.Fatalf("could not get logs of %s/%s (container %s): %v", pod.Namespace, pod.Name, container, err)
+	}
+	return out
+}
+
+// logsFromPods returns the logs from all containers in all pods that match the selector
+func (c *nativeClient) logsFromPods(t test.TestHelper, ns, selector string) string {
+	t.T().Helper()
+	c.clients(t)
+	pods, err := c.kube.CoreV1().Pods(ns).List(c.ctx(t), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		t.Fatalf("could not list pods in namespace %s: %v", ns, err)
+	}
+	var out strings.Builder
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			logs, err := c.streamLogs(t, NewNamespacedName(ns, pod.Name), &corev1.PodLogOptions{Container: container.Name})
+			if err != nil {
+				t.Fatalf("could not get logs of %s/%s (container %s): %v", ns, pod.Name, container.Name, err)
+			}
+			out.WriteString(logs)
+		}
+	}
+	return out.String()
+}
+
+func (c *nativeClient) streamLogs(t test.TestHelper, pod NamespacedName, opts *corev1.PodLogOptions) (string, error) {
+	stream, err := c.kube.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).Stream(c.ctx(t))
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+	out, err := io.ReadAll(stream)
+	return string(out), err
+}
+
+// waitFor waits until the object reaches the specified condition, which uses the `oc wait --for` syntax:
+// "condition=Ready", "condition=Ready=False", "jsonpath={.status.phase}=Running" or "delete".
+// It returns the same message that `oc wait` prints when the condition is met.
+func (c *nativeClient) waitFor(t test.TestHelper, ns, kind, name, forCondition string, timeout time.Duration) (string, error) {
+	t.T().Helper()
+	mapping := c.mustMapping(t, kind)
+	client := c.resourceClient(mapping, ns)
+	ref := resourceRef(mapping, name)
+
+	check, err := parseWaitCondition(forCondition)
+	if err != nil {
+		return "", err
+	}
+
+	var lastErr error
+	err = wait.PollUntilContextTimeout(c.ctx(t), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		obj, err := client.Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			lastErr = err
+			return forCondition == "delete", nil
+		}
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+		met, err := check(obj.Object)
+		lastErr = err
+		return met, nil
+	})
+	if err != nil {
+		if lastErr != nil {
+			return "", fmt.Errorf("timed out waiting for the condition on %s: %v", ref, lastErr)
+		}
+		return "", fmt.Errorf("timed out waiting for the condition on %s", ref)
+	}
+	if forCondition == "delete" {
+		return ref + " deleted\n", nil
+	}
+	return ref + " condition met\n", nil
+}
+
+type waitConditionFunc func(obj map[string]interface{}) (bool, error)
+
+func parseWaitCondition(forCondition string) (waitConditionFunc, error) {
+	if forCondition == "delete" {
+		return func(obj map[string]interface{}) (bool, error) {
+			return false, nil
+		}, nil
+	}
+
+	kind, expr, found := strings.Cut(forCondition, "=")
+	if !found {
+		return nil, fmt.Errorf("unrecognized condition: %q", forCondition)
+	}
+	switch strings.ToLower(kind) {
+	case "condition":
+		conditionType, value, found := strings.Cut(expr, "=")
+		if !found {
+			value = "true"
+		}
+		return func(obj map[string]interface{}) (bool, error) {
+			status, _ := obj["status"].(map[string]interface{})
+			conditions, _ := status["conditions"].([]interface{})
+			for _, c := range conditions {
+				condition, _ := c.(map[string]interface{})
+				if strings.EqualFold(fmt.Sprint(condition["type"]), conditionType) {
+					return strings.EqualFold(fmt.Sprint(condition["status"]), value), nil
+				}
+			}
+			return false, nil
+		}, nil
+
+	case "jsonpath":
+		// the jsonpath expression is usually quoted for the shell, e.g. jsonpath='{.status.phase}'=Running
+		expr = strings.ReplaceAll(expr, "'", "")
+		end := strings.LastIndex(expr, "}")
+		if !strings.HasPrefix(expr, "{") || end == -1 {
+			return nil, fmt.Errorf("unrecognized jsonpath condition: %q", forCondition)
+		}
+		path, value := expr[:end+1], strings.TrimPrefix(expr[end+1:], "=")
+		return func(obj map[string]interface{}) (bool, error) {
+			actual, err := evalJsonPath(obj, path)
+			if err != nil {
+				return false, err
+			}
+			if value == "" {
+				return actual != "", nil
+			}
+			return actual == value, nil
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unrecognized condition: %q", forCondition)
+	}
+}
+
+// waitAllPodsReady waits until all pods in the namespace are ready
+func (c *nativeClient) waitAllPodsReady(t test.TestHelper, ns string, timeout time.Duration) {
+	t.T().Helper()
+	c.clients(t)
+	var notReady []string
+	err := wait.PollUntilContextTimeout(c.ctx(t), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		pods, err := c.kube.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, nil
+		}
+		notReady = nil
+		for _, pod := range pods.Items {
+			if !isPodReady(&pod) {
+				notReady = append(notReady, pod.Name)
+			}
+		}
+		return len(notReady) == 0, nil
+	})
+	if err != nil {
+		t.Fatalf("timed out waiting for pods in namespace %s to be ready; pods not ready: %v", ns, notReady)
+	}
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// rolloutStatus waits until the deployment's rollout completes, just like `kubectl rollout status`
+func (c *nativeClient) rolloutStatus(t test.TestHelper, ns, name string, timeout time.Duration) {
+	t.T().Helper()
+	c.clients(t)
+	var status string
+	err := wait.PollUntilContextTimeout(c.ctx(t), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		d, err := c.kube.AppsV1().Deployments(ns).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			status = err.Error()
+			return false, nil
+		}
+		var done bool
+		done, status = deploymentRolloutStatus(d)
+		return done, nil
+	})
+	if err != nil {
+		t.Fatalf("deployment %s/%s rollout did not complete in %v: %s", ns, name, timeout, status)
+	}
+}
+
+// deploymentRolloutStatus mirrors the logic of `kubectl rollout status`
+func deploymentRolloutStatus(d *appsv1.Deployment) (bool, string) {
+	if d.Generation > d.Status.ObservedGeneration {
+		return false, "waiting for deployment spec update to be observed"
+	}
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			return false, fmt.Sprintf("deployment %q exceeded its progress deadline", d.Name)
+		}
+	}
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	if d.Status.UpdatedReplicas < replicas {
+		return false, fmt.Sprintf("%d out of %d new replicas have been updated", d.Status.UpdatedReplicas, replicas)
+	}
+	if d.Status.Replicas > d.Status.UpdatedReplicas {
+		return false, fmt.Sprintf("%d old replicas are pending termination", d.Status.Replicas-d.Status.UpdatedReplicas)
+	}
+	if d.Status.AvailableReplicas < d.Status.UpdatedReplicas {
+		return false, fmt.Sprintf("%d of %d updated replicas are available", d.Status.AvailableReplicas, d.Status.UpdatedReplicas)
+	}
+	return true, fmt.Sprintf("deployment %q successfully rolled out", d.Name)
+}
+
+// restartDeployment triggers a rollout of the deployment, just like `kubectl rollout restart`
+func (c *nativeClient) restartDeployment(t test.TestHelper, ns, name string) {
+	t.T().Helper()
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":%q}}}}}`, time.Now().Format(time.RFC3339))
+	c.patch(t, ns, "deployment", name, "strategic", patch)
+}
+
+// deleteAllPods deletes all pods in the namespace without waiting for them to terminate
+func (c *nativeClient) deleteAllPods(t test.TestHelper, ns string) {
+	t.T().Helper()
+	c.clients(t)
+	err := c.kube.CoreV1().Pods(ns).DeleteCollection(c.ctx(t), metav1.DeleteOptions{}, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("could not delete pods in namespace %s: %v", ns, err)
+	}
+}
+
+// undoRollout rolls the deployment back to its previous revision, just like `kubectl rollout undo`
+func (c *nativeClient) undoRollout(t test.TestHelper, ns, name string) {
+	t.T().Helper()
+	c.clients(t)
+	d, err := c.kube.AppsV1().Deployments(ns).Get(c.ctx(t), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("could not get deployment %s/%s: %v", ns, name, err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		t.Fatalf("invalid selector in deployment %s/%s: %v", ns, name, err)
+	}
+	rsList, err := c.kube.AppsV1().ReplicaSets(ns).List(c.ctx(t), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		t.Fatalf("could not list replicasets of deployment %s/%s: %v", ns, name, err)
+	}
+
+	var owned []appsv1.ReplicaSet
+	for _, rs := range rsList.Items {
+		if metav1.IsControlledBy(&rs, d) {
+			owned = append(owned, rs)
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return replicaSetRevision(&owned[i]) > replicaSetRevision(&owned[j])
+	})
+	if len(owned) < 2 {
+		t.Fatalf("no rollout history found for deployment %s/%s", ns, name)
+	}
+
+	template := owned[1].Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	patch, err := json.Marshal([]map[string]interface{}{
+		{"op": "replace", "path": "/spec/template", "value": template},
+	})
+	if err != nil {
+		t.Fatalf("could not marshal rollback patch: %v", err)
+	}
+	_, err = c.kube.AppsV1().Deployments(ns).Patch(c.ctx(t), name, types.JSONPatchType, patch, metav1.PatchOptions{FieldManager: fieldManager})
+	if err != nil {
+		t.Fatalf("could not roll back deployment %s/%s: %v", ns, name, err)
+	}
+}
+
+func replicaSetRevision(rs *appsv1.ReplicaSet) int64 {
+	revision, _ := strconv.ParseInt(rs.Annotations["deployment.kubernetes.io/revision"], 10, 64)
+	return revision
+}
@@ -0,0 +1,732 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// deleteTimeout is how long delete operations wait for the objects to be gone (kubectl waits indefinitely)
+const deleteTimeout = 5 * time.Minute
+
+// decodeManifests splits a multi-document YAML (or JSON) string into objects. Lists are flattened.
+func decodeManifests(manifests string) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	decoder := yamlutil.NewYAMLOrJSONDecoder(strings.NewReader(manifests), 4096)
+	for {
+		obj := map[string]interface{}{}
+		if err := decoder.Decode(&obj); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("could not parse manifest: %v", err)
+		}
+		if len(obj) == 0 {
+			continue // empty document
+		}
+		u := &unstructured.Unstructured{Object: obj}
+		if u.IsList() {
+			list, err := u.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("could not parse list: %v", err)
+			}
+			for i := range list.Items {
+				objects = append(objects, &list.Items[i])
+			}
+			continue
+		}
+		objects = append(objects, u)
+	}
+	return objects, nil
+}
+
+// apply applies the manifests using server-side apply and returns the objects that didn't exist before.
+// If an object can't be applied, the objects created until then are returned along with the error.
+func (c *nativeClient) apply(t test.TestHelper, ns string, manifests string) ([]trackedObject, error) {
+	t.T().Helper()
+	c.clients(t)
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	var created []trackedObject
+	for _, obj := range objects {
+		mapping, err := c.mappingForKind(obj.GroupVersionKind())
+		if err != nil {
+			return created, err
+		}
+		client := c.resourceClient(mapping, namespaceOf(obj, ns))
+		var applied *unstructured.Unstructured
+		existed := false
+		if obj.GetName() == "" && obj.GetGenerateName() != "" {
+			// server-side apply requires a name, so objects with generateName can only be created
+			applied, err = client.Create(c.ctx(t), obj, metav1.CreateOptions{FieldManager: fieldManager})
+		} else {
+			_, getErr := client.Get(c.ctx(t), obj.GetName(), metav1.GetOptions{})
+			existed = !apierrors.IsNotFound(getErr)
+			applied, err = client.Apply(c.ctx(t), obj.GetName(), obj, metav1.ApplyOptions{FieldManager: fieldManager, Force: true})
+		}
+		if err != nil {
+			return created, fmt.Errorf("%s: %w", resourceRef(mapping, obj.GetName()), err)
+		}
+		if !existed {
+			created = append(created, trackedObject{kind: kindRef(mapping), namespace: applied.GetNamespace(), name: applied.GetName()})
+		}
+	}
+	return created, nil
+}
+
+// create creates the objects in the manifests and returns false if any of them already exists
+func (c *nativeClient) create(t test.TestHelper, ns string, manifests string) bool {
+	t.T().Helper()
+	c.clients(t)
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	created := true
+	for _, obj := range objects {
+		mapping, err := c.mappingForKind(obj.GroupVersionKind())
+		if err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		_, err = c.resourceClient(mapping, namespaceOf(obj, ns)).Create(c.ctx(t), obj, metav1.CreateOptions{FieldManager: fieldManager})
+		if apierrors.IsAlreadyExists(err) {
+			created = false
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Create failed for %s: %v", resourceRef(mapping, obj.GetName()), err)
+		}
+	}
+	return created
+}
+
+// deleteManifests deletes the objects in the manifests, ignoring those that don't exist
+func (c *nativeClient) deleteManifests(t test.TestHelper, ns string, manifests string) {
+	t.T().Helper()
+	c.clients(t)
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	for _, obj := range objects {
+		mapping, err := c.mappingForKind(obj.GroupVersionKind())
+		if meta.IsNoMatchError(err) {
+			continue // the CRD doesn't exist, so neither does the object
+		}
+		if err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		c.deleteAndWait(t, mapping, namespaceOf(obj, ns), obj.GetName())
+	}
+}
+
+// deleteResources deletes the named objects of the specified kind, ignoring those that don't exist
+func (c *nativeClient) deleteResources(t test.TestHelper, ns string, kind string, names ...string) string {
+	t.T().Helper()
+	mapping := c.mustMapping(t, kind)
+	var output strings.Builder
+	for _, name := range names {
+		if c.deleteAndWait(t, mapping, ns, name) {
+			fmt.Fprintf(&output, "%s %q deleted\n", strings.ToLower(mapping.GroupVersionKind.Kind), name)
+		}
+	}
+	return output.String()
+}
+
+// deleteNoWait deletes the named object without waiting for it to be gone
+func (c *nativeClient) deleteNoWait(t test.TestHelper, ns string, kind string, name string) string {
+	t.T().Helper()
+	mapping := c.mustMapping(t, kind)
+	err := c.resourceClient(mapping, ns).Delete(c.ctx(t), name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Delete failed for %s: %v", resourceRef(mapping, name), err)
+	}
+	return fmt.Sprintf("%s %q deleted\n", strings.ToLower(mapping.GroupVersionKind.Kind), name)
+}
+
+// deleteByLabel deletes all objects of the specified kind that match the label selector
+func (c *nativeClient) deleteByLabel(t test.TestHelper, ns string, kind string, selector string) {
+	t.T().Helper()
+	mapping := c.mustMapping(t, kind)
+	list, err := c.resourceClient(mapping, ns).List(c.ctx(t), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		t.Fatalf("Delete failed: could not list %s: %v", kind, err)
+	}
+	for _, item := range list.Items {
+		c.deleteAndWait(t, mapping, item.GetNamespace(), item.GetName())
+	}
+}
+
+// deleteAndWait deletes the object and waits until it's gone. Returns false if the object didn't exist.
+func (c *nativeClient) deleteAndWait(t test.TestHelper, mapping *meta.RESTMapping, ns string, name string) bool {
+	t.T().Helper()
+	client := c.resourceClient(mapping, ns)
+	propagation := metav1.DeletePropagationBackground
+	err := client.Delete(c.ctx(t), name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if apierrors.IsNotFound(err) {
+		return false
+	}
+	if err != nil {
+		t.Fatalf("Delete failed for %s: %v", resourceRef(mapping, name), err)
+	}
+
+	err = wait.PollUntilContextTimeout(c.ctx(t), 500*time.Millisecond, deleteTimeout, true, func(ctx context.Context) (bool, error) {
+		_, err := client.Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		t.Fatalf("Timed out waiting for %s to be deleted: %v", resourceRef(mapping, name), err)
+	}
+	return true
+}
+
+func (c *nativeClient) mustMapping(t test.TestHelper, kind string) *meta.RESTMapping {
+	t.T().Helper()
+	c.clients(t)
+	mapping, err := c.resourceMapping(kind)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	return mapping
+}
+
+// getObject returns the named object, or the list of all objects of that kind if name is empty
+func (c *nativeClient) getObject(t test.TestHelper, ns, kind, name string) (interface{}, error) {
+	t.T().Helper()
+	mapping := c.mustMapping(t, kind)
+	client := c.resourceClient(mapping, ns)
+	if name == "" {
+		list, err := client.List(c.ctx(t), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return list.UnstructuredContent(), nil
+	}
+	obj, err := client.Get(c.ctx(t), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return obj.Object, nil
+}
+
+func (c *nativeClient) getYaml(t test.TestHelper, ns, kind, name string) string {
+	t.T().Helper()
+	obj, err := c.getObject(t, ns, kind, name)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		t.Fatalf("could not marshal %s/%s to YAML: %v", kind, name, err)
+	}
+	return string(out)
+}
+
+// getJson returns the object as JSON or, if jsonPath is specified, the result of evaluating
+// the JSONPath template against the object (like `oc get -o jsonpath=...`)
+func (c *nativeClient) getJson(t test.TestHelper, ns, kind, name, jsonPath string) string {
+	t.T().Helper()
+	obj, err := c.getObject(t, ns, kind, name)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if jsonPath == "" {
+		out, err := json.MarshalIndent(obj, "", "    ")
+		if err != nil {
+			t.Fatalf("could not marshal %s/%s to JSON: %v", kind, name, err)
+		}
+		return string(out) + "\n"
+	}
+	out, err := evalJsonPath(obj, jsonPath)
+	if err != nil {
+		t.Fatalf("error executing jsonpath %q: %v", jsonPath, err)
+	}
+	return out
+}
+
+func evalJsonPath(obj interface{}, template string) (string, error) {
+	jp := jsonpath.New("").AllowMissingKeys(true)
+	if err := jp.Parse(template); err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := jp.Execute(&buf, obj); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// exists returns true if the object exists (or, if name is empty, if any object of that kind exists)
+func (c *nativeClient) exists(t test.TestHelper, ns, kind, name, selector string) bool {
+	t.T().Helper()
+	c.clients(t)
+	mapping, err := c.resourceMapping(kind)
+	if err != nil {
+		return false
+	}
+	client := c.resourceClient(mapping, ns)
+	if name != "" {
+		_, err = client.Get(c.ctx(t), name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		if err != nil {
+			t.Fatalf("Get failed for %s: %v", resourceRef(mapping, name), err)
+		}
+		return true
+	}
+	list, err := client.List(c.ctx(t), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		t.Fatalf("List failed for %s: %v", kind, err)
+	}
+	return len(list.Items) > 0
+}
+
+// names returns the names of all objects of the specified kind that match the selector
+func (c *nativeClient) names(t test.TestHelper, ns, kind, selector string) []string {
+	t.T().Helper()
+	mapping := c.mustMapping(t, kind)
+	list, err := c.resourceClient(mapping, ns).List(c.ctx(t), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		t.Fatalf("List failed for %s: %v", kind, err)
+	}
+	var names []string
+	for _, item := range list.Items {
+		names = append(names, item.GetName())
+	}
+	return names
+}
+
+// listAllNamespaces lists the objects of the comma-separated kinds that match the selector (if not empty)
+// in all namespaces and formats them with the JSONPath template. It returns false if any of the kinds doesn't exist.
+func (c *nativeClient) listAllNamespaces(t test.TestHelper, kinds string, selector string, template string) (string, bool) {
+	t.T().Helper()
+	c.clients(t)
+	var items []interface{}
+	for _, kind := range strings.Split(kinds, ",") {
+		mapping, err := c.resourceMapping(kind)
+		if err != nil {
+			return "", false
+		}
+		list, err := c.dynamic.Resource(mapping.Resource).List(c.ctx(t), metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return "", false
+		}
+		for _, item := range list.Items {
+			items = append(items, item.Object)
+		}
+	}
+	out, err := evalJsonPath(map[string]interface{}{"items": items}, template)
+	if err != nil {
+		t.Fatalf("error executing jsonpath %q: %v", template, err)
+	}
+	return out, true
+}
+
+// listObjects returns the objects of the comma-separated kinds in the namespace (or in all namespaces, if ns
+// is empty), ignoring kinds that don't exist
+func (c *nativeClient) listObjects(t test.TestHelper, ns, kinds string) []unstructured.Unstructured {
+	t.T().Helper()
+	c.clients(t)
+	var items []unstructured.Unstructured
+	for _, kind := range strings.Split(kinds, ",") {
+		mapping, err := c.resourceMapping(kind)
+		if err != nil {
+			continue
+		}
+		var resource dynamic.ResourceInterface = c.dynamic.Resource(mapping.Resource)
+		if ns != "" {
+			resource = c.resourceClient(mapping, ns)
+		}
+		list, err := resource.List(c.ctx(t), metav1.ListOptions{})
+		if err != nil {
+			continue
+		}
+		items = append(items, list.Items...)
+	}
+	return items
+}
+
+// table returns the same tabular output that `oc get` prints. The columns are
+// computed by the API server, so they match what kubectl and oc display.
+// Multiple comma-separated kinds are supported (e.g. "smcp,pods,services").
+func (c *nativeClient) table(t test.TestHelper, ns, kinds, name string) string {
+	t.T().Helper()
+	c.clients(t)
+	kindList := strings.Split(kinds, ",")
+	var out bytes.Buffer
+	for _, kind := range kindList {
+		mapping := c.mustMapping(t, kind)
+		table, err := c.getTable(t, mapping, ns, name)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if len(table.Rows) == 0 {
+			continue
+		}
+		if out.Len() > 0 {
+			out.WriteString("\n")
+		}
+		prefix := ""
+		if len(kindList) > 1 {
+			prefix = strings.TrimSuffix(resourceRef(mapping, ""), "/") + "/"
+		}
+		printTable(&out, table, prefix)
+	}
+	if out.Len() == 0 {
+		if ns == "" {
+			return "No resources found\n"
+		}
+		return fmt.Sprintf("No resources found in %s namespace.\n", ns)
+	}
+	return out.String()
+}
+
+func (c *nativeClient) getTable(t test.TestHelper, mapping *meta.RESTMapping, ns, name string) (*metav1.Table, error) {
+	if c.discovery == nil {
+		return c.nameTable(t, mapping, ns, name)
+	}
+	gvr := mapping.Resource
+	path := "/apis/" + gvr.Group + "/" + gvr.Version
+	if gvr.Group == "" {
+		path = "/api/" + gvr.Version
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if ns == "" {
+			ns = c.namespace
+		}
+		path += "/namespaces/" + ns
+	}
+	path += "/" + gvr.Resource
+	if name != "" {
+		path += "/" + name
+	}
+
+	raw, err := c.kube.Discovery().RESTClient().Get().
+		AbsPath(path).
+		SetHeader("Accept", "application/json;as=Table;v=v1;g=meta.k8s.io,application/json").
+		Do(c.ctx(t)).
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+	table := &metav1.Table{}
+	if err := json.Unmarshal(raw, table); err != nil {
+		return nil, fmt.Errorf("could not parse table: %v", err)
+	}
+	return table, nil
+}
+
+// nameTable builds a table with only the NAME column, for clients that aren't connected to
+// a real API server and therefore can't have the server compute the table (see NewFakeOC)
+func (c *nativeClient) nameTable(t test.TestHelper, mapping *meta.RESTMapping, ns, name string) (*metav1.Table, error) {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{{Name: "Name", Type: "string", Format: "name"}},
+	}
+	client := c.resourceClient(mapping, ns)
+	if name != "" {
+		obj, err := client.Get(c.ctx(t), name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		table.Rows = append(table.Rows, metav1.TableRow{Cells: []interface{}{obj.GetName()}})
+		return table, nil
+	}
+	list, err := client.List(c.ctx(t), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range list.Items {
+		table.Rows = append(table.Rows, metav1.TableRow{Cells: []interface{}{item.GetName()}})
+	}
+	return table, nil
+}
+
+func printTable(out io.Writer, table *metav1.Table, namePrefix string) {
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	var columns []int
+	var headers []string
+	for i, column := range table.ColumnDefinitions {
+		if column.Priority == 0 {
+			columns = append(columns, i)
+			headers = append(headers, strings.ToUpper(column.Name))
+		}
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, row := range table.Rows {
+		var cells []string
+		for _, i := range columns {
+			cell := ""
+			if i < len(row.Cells) && row.Cells[i] != nil {
+				cell = fmt.Sprint(row.Cells[i])
+			}
+			if table.ColumnDefinitions[i].Format == "name" {
+				cell = namePrefix + cell
+			}
+			cells = append(cells, cell)
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	_ = w.Flush()
+}
+
+func (c *nativeClient) patch(t test.TestHelper, ns, kind, name, patchType, patch string) {
+	t.T().Helper()
+	mapping := c.mustMapping(t, kind)
+	var pt types.PatchType
+	switch patchType {
+	case "json":
+		pt = types.JSONPatchType
+	case "merge":
+		pt = types.MergePatchType
+	case "strategic", "":
+		pt = types.StrategicMergePatchType
+	default:
+		t.Fatalf("unsupported patch type %q", patchType)
+	}
+	_, err := c.resourceClient(mapping, ns).Patch(c.ctx(t), name, pt, []byte(patch), metav1.PatchOptions{FieldManager: fieldManager})
+	if err != nil {
+		t.Fatalf("Patch failed for %s: %v", resourceRef(mapping, name), err)
+	}
+}
+
+// label adds, changes or removes labels; the labels string uses the `oc label` syntax (e.g. "a=b c-")
+func (c *nativeClient) label(t test.TestHelper, ns, kind, name, labels string) {
+	t.T().Helper()
+	values := map[string]interface{}{}
+	for _, label := range strings.Fields(labels) {
+		if strings.HasSuffix(label, "-") {
+			values[strings.TrimSuffix(label, "-")] = nil
+		} else if key, value, found := strings.Cut(label, "="); found {
+			values[key] = value
+		} else {
+			t.Fatalf("invalid label %q", label)
+		}
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": values},
+	})
+	if err != nil {
+		t.Fatalf("could not marshal label patch: %v", err)
+	}
+	c.patch(t, ns, kind, name, "merge", string(patch))
+}
+
+// createFromFiles creates a Secret or ConfigMap from files specified using the `--from-file` syntax ("path" or "key=path")
+func (c *nativeClient) createFromFiles(t test.TestHelper, ns, kind, name string, files ...string) {
+	t.T().Helper()
+	c.clients(t)
+	data := map[string][]byte{}
+	for _, file := range files {
+		key, path, found := strings.Cut(file, "=")
+		if !found {
+			key, path = filepath.Base(file), file
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("could not read file %s: %v", path, err)
+		}
+		data[key] = content
+	}
+
+	var err error
+	if kind == "configmap" {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name}, Data: map[string]string{}}
+		for k, v := range data {
+			cm.Data[k] = string(v)
+		}
+		_, err = c.kube.CoreV1().ConfigMaps(ns).Create(c.ctx(t), cm, metav1.CreateOptions{FieldManager: fieldManager})
+	} else {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name}, Type: corev1.SecretTypeOpaque, Data: data}
+		_, err = c.kube.CoreV1().Secrets(ns).Create(c.ctx(t), secret, metav1.CreateOptions{FieldManager: fieldManager})
+	}
+	if err != nil {
+		t.Fatalf("could not create %s %s/%s: %v", kind, ns, name, err)
+	}
+}
+
+func (c *nativeClient) createTLSSecret(t test.TestHelper, ns, name, keyFile, certFile string) {
+	t.T().Helper()
+	c.clients(t)
+	key, err := os.ReadFile(keyFile)
+	if err != nil {
+		t.Fatalf("could not read key file %s: %v", keyFile, err)
+	}
+	cert, err := os.ReadFile(certFile)
+	if err != nil {
+		t.Fatalf("could not read cert file %s: %v", certFile, err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       cert,
+			corev1.TLSPrivateKeyKey: key,
+		},
+	}
+	_, err = c.kube.CoreV1().Secrets(ns).Create(c.ctx(t), secret, metav1.CreateOptions{FieldManager: fieldManager})
+	if err != nil {
+		t.Fatalf("could not create secret %s/%s: %v", ns, name, err)
+	}
+}
+
+// exposeService creates a Route for the service, just like `oc expose svc`
+func (c *nativeClient) exposeService(t test.TestHelper, ns, svcName, servicePort, routeName string) {
+	t.T().Helper()
+	c.clients(t)
+	svc, err := c.kube.CoreV1().Services(ns).Get(c.ctx(t), svcName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("could not get service %s/%s: %v", ns, svcName, err)
+	}
+	route := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "route.openshift.io/v1",
+		"kind":       "Route",
+		"metadata": map[string]interface{}{
+			"name":      routeName,
+			"namespace": ns,
+		},
+		"spec": map[string]interface{}{
+			"to": map[string]interface{}{
+				"kind": "Service",
+				"name": svcName,
+			},
+			"port": map[string]interface{}{
+				"targetPort": targetPort(servicePort),
+			},
+		},
+	}}
+	route.SetLabels(svc.Labels)
+	mapping, err := c.mappingForKind(route.GroupVersionKind())
+	if err != nil {
+		t.Fatalf("could not expose service: %v", err)
+	}
+	_, err = c.resourceClient(mapping, ns).Create(c.ctx(t), route, metav1.CreateOptions{FieldManager: fieldManager})
+	if err != nil {
+		t.Fatalf("could not create route %s/%s: %v", ns, routeName, err)
+	}
+}
+
+// targetPort converts the port into the value of a route's spec.port.targetPort. Like oc expose --port, a number
+// is the target port of the service, and any other string is the name of a port.
+func targetPort(port string) interface{} {
+	parsed := intstr.Parse(port)
+	if parsed.Type == intstr.Int {
+		return int64(parsed.IntVal)
+	}
+	return parsed.StrVal
+}
+
+// taintNodes adds or removes taints using the `oc adm taint` syntax (e.g. "key=value:NoSchedule" or "key:NoSchedule-").
+// The node can also be specified as "-l <selector>".
+func (c *nativeClient) taintNodes(t test.TestHelper, node string, taints ...string) {
+	t.T().Helper()
+	c.clients(t)
+	var nodes []corev1.Node
+	if selector, found := strings.CutPrefix(node, "-l "); found {
+		list, err := c.kube.CoreV1().Nodes().List(c.ctx(t), metav1.ListOptions{LabelSelector: strings.TrimSpace(selector)})
+		if err != nil {
+			t.Fatalf("could not list nodes: %v", err)
+		}
+		nodes = list.Items
+	} else {
+		n, err := c.kube.CoreV1().Nodes().Get(c.ctx(t), node, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("could not get node %s: %v", node, err)
+		}
+		nodes = []corev1.Node{*n}
+	}
+
+	for i := range nodes {
+		n := &nodes[i]
+		for _, spec := range taints {
+			if err := applyTaint(n, spec); err != nil {
+				t.Fatalf("invalid taint %q: %v", spec, err)
+			}
+		}
+		if _, err := c.kube.CoreV1().Nodes().Update(c.ctx(t), n, metav1.UpdateOptions{FieldManager: fieldManager}); err != nil {
+			t.Fatalf("could not update taints on node %s: %v", n.Name, err)
+		}
+	}
+}
+
+func applyTaint(node *corev1.Node, spec string) error {
+	remove := strings.HasSuffix(spec, "-")
+	spec = strings.TrimSuffix(spec, "-")
+
+	keyValue, effect, _ := strings.Cut(spec, ":")
+	key, value, _ := strings.Cut(keyValue, "=")
+	if key == "" {
+		return fmt.Errorf("missing key")
+	}
+	if !remove && effect == "" {
+		return fmt.Errorf("missing effect")
+	}
+
+	var taints []corev1.Taint
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == key && (effect == "" || string(taint.Effect) == effect) {
+			continue // removed or replaced below
+		}
+		taints = append(taints, taint)
+	}
+	if !remove {
+		taints = append(taints, corev1.Taint{Key: key, Value: value, Effect: corev1.TaintEffect(effect)})
+	}
+	node.Spec.Taints = taints
+	return nil
+}
+
+// ocpVersion returns the OpenShift version reported by the ClusterVersion object (the same value that `oc version` prints as the server version)
+func (c *nativeClient) ocpVersion(t test.TestHelper) string {
+	t.T().Helper()
+	return c.getJson(t, "", "clusterversion", "version", "{.status.desired.version}")
+}
+
+// namespaceOf returns the namespace of the object or the default namespace if the object doesn't specify one
+func namespaceOf(obj *unstructured.Unstructured, defaultNamespace string) string {
+	if obj.GetNamespace() != "" {
+		return obj.GetNamespace()
+	}
+	return defaultNamespace
+}
@@ -25,6 +25,10 @@ import (
 
 var DefaultOC = NewOC("")
 
+func init() {
+	test.RegisterOCPVersionProvider(GetOCPVersion)
+}
+
 func WithKubeconfig(location string) *OC {
 	return NewOC(location)
 }
@@ -34,6 +38,11 @@ func ApplyString(t test.TestHelper, ns string, yamls ...string) {
 	DefaultOC.ApplyString(t, ns, yamls...)
 }
 
+func CreateString(t test.TestHelper, ns string, yamls ...string) bool {
+	t.T().Helper()
+	return DefaultOC.CreateString(t, ns, yamls...)
+}
+
 func GetOCPVersion(t test.TestHelper) string {
 	t.T().Helper()
 	return DefaultOC.GetOCPVersion(t)
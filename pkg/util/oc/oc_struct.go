@@ -17,7 +17,9 @@ package oc
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"math/rand"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -36,12 +38,33 @@ func init() {
 
 type OC struct {
 	kubeconfig string
+
+	// native is set when the OC talks to the cluster through client-go instead of the oc binary
+	native *nativeClient
 }
 
+// NewOC returns an OC for the cluster in the specified kubeconfig (or the current KUBECONFIG if empty).
+// The OC uses the oc binary, unless NATIVE_CLIENT=true, in which case it's equivalent to NewNativeOC.
 func NewOC(kubeconfig string) *OC {
+	if env.IsNativeClientEnabled() {
+		return NewNativeOC(kubeconfig)
+	}
 	return &OC{kubeconfig: kubeconfig}
 }
 
+// NewNativeOC returns an OC that uses client-go (server-side apply, SPDY exec, log streaming, etc.)
+// instead of executing the oc binary. Unlike an OC returned by NewOC, it never modifies the
+// KUBECONFIG env var of the current process, so multiple instances can be used concurrently.
+// Commands passed to Invoke are still executed in a shell, but with KUBECONFIG set only for that command.
+func NewNativeOC(kubeconfig string) *OC {
+	return &OC{kubeconfig: kubeconfig, native: newNativeClient(kubeconfig)}
+}
+
+// IsNative returns true if this OC uses client-go instead of the oc binary
+func (o OC) IsNative() bool {
+	return o.native != nil
+}
+
 func (o OC) ApplyTemplateString(t test.TestHelper, ns string, tmpl string, input interface{}) {
 	t.T().Helper()
 	o.retryFunction(t, func() {
@@ -52,6 +75,10 @@ func (o OC) ApplyTemplateString(t test.TestHelper, ns string, tmpl string, input
 
 func (o OC) GetOCPVersion(t test.TestHelper) string {
 	t.T().Helper()
+	if o.native != nil {
+		return o.native.ocpVersion(t)
+	}
+
 	output := ""
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
@@ -124,19 +151,78 @@ func (o OC) retryFunction(t test.TestHelper, f func()) {
 }
 
 // ApplyString applies the specified YAMLs using oc apply and retries if the command fails.
+// Within a test, the objects are stamped with the test labels and the objects that didn't
+// exist before are deleted when the test ends (see ledger).
 func (o OC) ApplyString(t test.TestHelper, ns string, yamls ...string) {
 	t.T().Helper()
 	o.retryFunction(t, func() {
 		t.T().Helper()
-		shell.ExecuteWithInput(t, fmt.Sprintf("oc %s apply -f -", nsFlag(ns)), concatenateYamls(yamls...))
+		o.apply(t, ns, concatenateYamls(yamls...))
+	})
+}
+
+// apply applies the manifests without retrying
+func (o OC) apply(t test.TestHelper, ns string, manifests string) {
+	t.T().Helper()
+	o.recordManifestNamespaces(t, ns, manifests)
+	l := ledgerOf(t)
+	if l != nil {
+		manifests = l.stamp(t, manifests)
+	}
+	var created []trackedObject
+	var err error
+	if o.native != nil {
+		created, err = o.native.apply(t, ns, manifests)
+	} else {
+		cmd := fmt.Sprintf("oc %s apply -f -", nsFlag(ns))
+		result := shell.RunWithEnvAndInput(t, nil, cmd, manifests)
+		if l != nil {
+			created = parseCreatedObjects(t, result.Output, ns, manifests)
+		}
+		if !result.Succeeded() {
+			err = fmt.Errorf("command failed: %s\n%s\n%w", cmd, strings.TrimSpace(result.Output), result.Err)
+		}
+	}
+	// the objects created before a failure are recorded too, since the retry reports them as unchanged
+	if l != nil {
+		l.record(o, created...)
+	}
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+}
+
+// CreateString creates the resources in the given yamls using oc create. Unlike ApplyString, it doesn't retry
+// and doesn't modify resources that already exist; it returns false if any of them did, so it can be used to
+// implement mutual exclusion between tests.
+func (o OC) CreateString(t test.TestHelper, ns string, yamls ...string) bool {
+	t.T().Helper()
+	if o.native != nil {
+		return o.native.create(t, ns, concatenateYamls(yamls...))
+	}
+	created := true
+	o.withKubeconfig(t, func() {
+		t.T().Helper()
+		output := shell.ExecuteWithInput(t, fmt.Sprintf("oc %s create -f - 2>&1 || true", nsFlag(ns)), concatenateYamls(yamls...))
+		if strings.Contains(output, "(AlreadyExists)") {
+			created = false
+		} else if strings.Contains(output, "error:") || strings.Contains(output, "Error from server") {
+			t.Fatalf("Create failed: %s", output)
+		}
 	})
+	return created
 }
 
-// ApplyFile applies the specified file using oc apply and retries if the command fails.
+// ApplyFile applies the specified file (or URL) using oc apply and retries if the command fails.
+// Like ApplyString, it stamps and tracks the objects within a test.
 func (o OC) ApplyFile(t test.TestHelper, ns string, file string) {
 	t.T().Helper()
 	o.retryFunction(t, func() {
 		t.T().Helper()
+		if o.native != nil || ledgerOf(t) != nil {
+			o.apply(t, ns, readFile(t, file))
+			return
+		}
 		o.Invokef(t, "oc %s apply -f %s", nsFlag(ns), file)
 	})
 }
@@ -144,6 +230,10 @@ func (o OC) ApplyFile(t test.TestHelper, ns string, file string) {
 func (o OC) DeleteFromString(t test.TestHelper, ns string, yamls ...string) {
 	t.T().Helper()
 	t.Logf("Deleting resources from namespace %s", ns)
+	if o.native != nil {
+		o.native.deleteManifests(t, ns, concatenateYamls(yamls...))
+		return
+	}
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
 		shell.ExecuteWithInput(t, fmt.Sprintf("oc %s delete -f - --ignore-not-found", nsFlag(ns)), concatenateYamls(yamls...))
@@ -153,12 +243,50 @@ func (o OC) DeleteFromString(t test.TestHelper, ns string, yamls ...string) {
 func (o OC) DeleteFile(t test.TestHelper, ns string, file string) {
 	t.T().Helper()
 	t.Logf("Deleting file %s from namespace %s", file, ns)
+	if o.native != nil {
+		o.native.deleteManifests(t, ns, readFile(t, file))
+		return
+	}
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
 		shell.Executef(t, "kubectl delete %s -f %s --ignore-not-found", nsFlag(ns), file)
 	})
 }
 
+// readFile returns the contents of the file or, if file is an http(s) URL, the response body
+func readFile(t test.TestHelper, file string) string {
+	t.T().Helper()
+	if strings.HasPrefix(file, "http://") || strings.HasPrefix(file, "https://") {
+		return download(t, file)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("could not read file %s: %v", file, err)
+	}
+	return string(content)
+}
+
+func download(t test.TestHelper, url string) string {
+	t.T().Helper()
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("could not download %s: %v", url, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("could not download %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("could not download %s: %v", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("could not download %s: %s", url, resp.Status)
+	}
+	return string(body)
+}
+
 func concatenateYamls(yamls ...string) string {
 	return strings.Join(yamls, "\n---\n")
 }
@@ -192,6 +320,10 @@ func (o OC) createSecretOrConfigMapFromFiles(t test.TestHelper, ns string, kind
 			t.T().Helper()
 			o.DeleteResource(t, ns, k, name)
 		})
+		if o.native != nil {
+			o.native.createFromFiles(t, ns, k, name, files...)
+			return
+		}
 		cmd := fmt.Sprintf(`oc create %s %s -n %s `, kind, name, ns)
 		for _, file := range files {
 			cmd += fmt.Sprintf(" --from-file=%s", file)
@@ -203,6 +335,10 @@ func (o OC) createSecretOrConfigMapFromFiles(t test.TestHelper, ns string, kind
 func (o OC) CreateTLSSecret(t test.TestHelper, ns, name string, keyFile, certFile string) {
 	t.T().Helper()
 	o.DeleteSecret(t, ns, name)
+	if o.native != nil {
+		o.native.createTLSSecret(t, ns, name, keyFile, certFile)
+		return
+	}
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
 		shell.Executef(t, "oc %s create secret tls %s --key %s --cert %s", nsFlag(ns), name, keyFile, certFile)
@@ -221,6 +357,10 @@ func (o OC) DeleteConfigMap(t test.TestHelper, ns string, name ...string) {
 
 func (o OC) DeleteResource(t test.TestHelper, ns string, kind string, names ...string) {
 	t.T().Helper()
+	if o.native != nil {
+		o.native.deleteResources(t, ns, kind, names...)
+		return
+	}
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
 		shell.Executef(t, "kubectl %s delete %s %s --ignore-not-found", nsFlag(ns), kind, strings.Join(names, " "))
@@ -236,6 +376,10 @@ func (o OC) DeleteResourcesByLabel(t test.TestHelper, ns string, kind string, la
 			nsMsg = " in namespace " + ns
 		}
 		t.Logf("Deleting %s resources matching selector %s%s", kind, label, nsMsg)
+		if o.native != nil {
+			o.native.deleteByLabel(t, ns, kind, label)
+			return
+		}
 		shell.Executef(t, "kubectl %s delete %s -l %s", nsFlag(ns), kind, label)
 	})
 }
@@ -246,6 +390,10 @@ func (o OC) DeleteNamespace(t test.TestHelper, namespaces ...string) {
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
 		t.Logf("Deleting namespaces: %v", namespaces)
+		if o.native != nil {
+			o.native.deleteResources(t, "", "namespace", namespaces...)
+			return
+		}
 		o.Invokef(t, "kubectl delete ns --ignore-not-found %s", strings.Join(namespaces, " "))
 	})
 }
@@ -257,6 +405,10 @@ func (o OC) DeleteTestBoundNamespaces(t test.TestHelper) {
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
 		t.Logf("Deleting namespaces matching selector: %v", testBoundNamespacesSelector)
+		if o.native != nil {
+			o.native.deleteByLabel(t, "", "namespace", testBoundNamespacesSelector)
+			return
+		}
 		o.Invokef(t, "kubectl delete ns -l %s", testBoundNamespacesSelector)
 	})
 }
@@ -311,10 +463,14 @@ func (o OC) Patch(t test.TestHelper, ns, kind, name string, mergeType string, pa
 	t.T().Helper()
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
+		if o.native != nil {
+			o.native.patch(t, ns, kind, name, mergeType, patch)
+			return
+		}
 		// quote the patch using single quotes, while escaping existing single quotes in the string
 		// for example: "foo'bar" becomes "'foo'\''bar'"
 		quotedPatch := fmt.Sprintf("'%s'", strings.ReplaceAll(patch, `'`, `'\\''`))
-		o.Invokef(t, `oc -n %s patch %s/%s --type %s -p %s`, ns, kind, name, mergeType, quotedPatch)
+		o.Invokef(t, `oc %s patch %s/%s --type %s -p %s`, nsFlag(ns), kind, name, mergeType, quotedPatch)
 	})
 }
 
@@ -322,7 +478,7 @@ func (o OC) GetConfigMapData(t test.TestHelper, ns, name string) map[string]stri
 	data := make(map[string]string)
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
-		manifest := o.Invokef(t, "oc get configmap -n %s %s -o json", ns, name)
+		manifest := o.GetJson(t, ns, "configmap", name, "")
 		m := map[string]interface{}{}
 		err := json.Unmarshal([]byte(manifest), &m)
 		if err != nil {
@@ -348,8 +504,12 @@ func (o OC) ScaleDeploymentAndWait(t test.TestHelper, ns string, deployment stri
 	t.T().Helper()
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
-		o.Invokef(t, "oc -n %s scale deploy/%s --replicas %d", ns, deployment, replicas)
-		WaitDeploymentRolloutComplete(t, ns, deployment)
+		if o.native != nil {
+			o.native.patch(t, ns, "deployment", deployment, "merge", fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+		} else {
+			o.Invokef(t, "oc -n %s scale deploy/%s --replicas %d", ns, deployment, replicas)
+		}
+		o.WaitDeploymentRolloutComplete(t, ns, deployment)
 	})
 }
 
@@ -364,18 +524,17 @@ func (o OC) ExposeSvc(t test.TestHelper, ns string, svcName string, servicePort
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
 		t.Logf("Exposing svc: %v", svcName)
+		if o.native != nil {
+			o.native.exposeService(t, ns, svcName, servicePort, routeName)
+			return
+		}
 		o.Invokef(t, "oc -n %s expose svc %s --port=%s --name=%s", ns, svcName, servicePort, routeName)
 	})
 }
 
 func (o OC) GetRouteURL(t test.TestHelper, ns string, name string) string {
 	t.T().Helper()
-	url := ""
-	o.withKubeconfig(t, func() {
-		t.T().Helper()
-		url = o.Invokef(t, "oc -n %s get route %s -o jsonpath='{.spec.host}'", ns, name)
-	})
-	return url
+	return o.GetJson(t, ns, "route", name, "{.spec.host}")
 }
 
 func (o OC) Invokef(t test.TestHelper, format string, a ...any) string {
@@ -388,8 +547,15 @@ func (o OC) Invokef(t test.TestHelper, format string, a ...any) string {
 	return output
 }
 
+// Invoke executes the command in a shell and fails the test if it doesn't complete within
+// env.GetCommandTimeout(), so that a hung command (e.g. oc exec) doesn't block the whole test run
 func (o OC) Invoke(t test.TestHelper, command string, checks ...common.CheckFunc) string {
 	t.T().Helper()
+	t, cancel := shell.WithTimeout(t, env.GetCommandTimeout())
+	defer cancel()
+	if o.native != nil {
+		return shell.ExecuteWithEnv(t, o.native.commandEnv(), command, checks...)
+	}
 	var output string
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
@@ -410,7 +576,7 @@ func (o OC) Invoke(t test.TestHelper, command string, checks ...common.CheckFunc
 // is used to execute commands (TODO)
 func (o OC) withKubeconfig(t test.TestHelper, f func()) {
 	t.T().Helper()
-	if o.kubeconfig == "" {
+	if o.kubeconfig == "" || o.native != nil {
 		f()
 	} else {
 		oldValue := env.GetKubeconfig()
@@ -421,11 +587,20 @@ func (o OC) withKubeconfig(t test.TestHelper, f func()) {
 }
 
 func (o OC) UndoRollout(t test.TestHelper, ns string, kind, name string) {
-	shell.Executef(t, `kubectl -n %s rollout undo %s %s`, ns, kind, name)
+	t.T().Helper()
+	if o.native != nil && (kind == "deployment" || kind == "deploy" || kind == "deployments") {
+		o.native.undoRollout(t, ns, name)
+		return
+	}
+	o.Invokef(t, `kubectl -n %s rollout undo %s %s`, ns, kind, name)
 }
 
 func (o OC) TaintNode(t test.TestHelper, name string, taints ...string) {
 	t.T().Helper()
+	if o.native != nil {
+		o.native.taintNodes(t, name, taints...)
+		return
+	}
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
 		shell.Executef(t, `oc adm taint nodes %s %s`, name, strings.Join(taints, " "))
@@ -442,6 +617,10 @@ func (o OC) Label(t test.TestHelper, ns string, kind string, name string, labels
 	if ns != "" {
 		nsFlag = fmt.Sprintf("-n %s ", ns)
 	}
+	if o.native != nil {
+		o.native.label(t, ns, kind, name, labels)
+		return
+	}
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
 		shell.Executef(t, "oc %slabel %s %s %s", nsFlag, kind, name, labels)
@@ -455,6 +634,13 @@ func (o OC) Get(t test.TestHelper, ns, kind, name string, checks ...common.Check
 	if name == "" {
 		element = kind
 	}
+	if o.native != nil {
+		val = o.native.table(t, ns, kind, name)
+		for _, check := range checks {
+			check(t, val)
+		}
+		return val
+	}
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
 		val = shell.Execute(t, fmt.Sprintf("oc %s get %s", nsFlag(ns), element), checks...)
@@ -465,6 +651,13 @@ func (o OC) Get(t test.TestHelper, ns, kind, name string, checks ...common.Check
 func (o OC) GetYaml(t test.TestHelper, ns, kind, name string, checks ...common.CheckFunc) string {
 	t.T().Helper()
 	var val string
+	if o.native != nil {
+		val = o.native.getYaml(t, ns, kind, name)
+		for _, check := range checks {
+			check(t, val)
+		}
+		return val
+	}
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
 		val = shell.Execute(t, fmt.Sprintf("oc %s get %s/%s -oyaml", nsFlag(ns), kind, name), checks...)
@@ -475,6 +668,13 @@ func (o OC) GetYaml(t test.TestHelper, ns, kind, name string, checks ...common.C
 func (o OC) GetJson(t test.TestHelper, ns, kind, name, jsonPath string, checks ...common.CheckFunc) string {
 	t.T().Helper()
 	var jsonString string
+	if o.native != nil {
+		jsonString = o.native.getJson(t, ns, kind, name, jsonPath)
+		for _, check := range checks {
+			check(t, jsonString)
+		}
+		return jsonString
+	}
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
 		if jsonPath == "" {
@@ -514,6 +714,9 @@ func (o OC) GetProxy(t test.TestHelper) *Proxy {
 
 func (o OC) ResourceExists(t test.TestHelper, ns, kind, name string) bool {
 	t.T().Helper()
+	if o.native != nil {
+		return o.native.exists(t, ns, kind, name, "")
+	}
 	var exists bool
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
@@ -528,6 +731,13 @@ func (o OC) ResourceExists(t test.TestHelper, ns, kind, name string) bool {
 // When you are looking for a global scoped resource (e.g. nodes), ns can be empty
 func (o OC) GetAllResourcesNames(t test.TestHelper, ns, kind, label string) []string {
 	t.T().Helper()
+	if o.native != nil {
+		values := o.native.names(t, ns, kind, label)
+		if len(values) == 0 {
+			t.Fatalf("Could not find resource %s with label %s in namespace %s", kind, label, ns)
+		}
+		return values
+	}
 	var values []string
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
@@ -542,6 +752,9 @@ func (o OC) GetAllResourcesNames(t test.TestHelper, ns, kind, label string) []st
 
 func (o OC) ResourceByLabelExists(t test.TestHelper, ns, kind, label string) bool {
 	t.T().Helper()
+	if o.native != nil {
+		return o.native.exists(t, ns, kind, "", label)
+	}
 	var exists bool
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
@@ -553,6 +766,9 @@ func (o OC) ResourceByLabelExists(t test.TestHelper, ns, kind, label string) boo
 
 func (o OC) AnyResourceExist(t test.TestHelper, ns string, kind string) bool {
 	t.T().Helper()
+	if o.native != nil {
+		return o.native.exists(t, ns, kind, "", "")
+	}
 	var exists bool
 	o.withKubeconfig(t, func() {
 		t.T().Helper()
@@ -50,6 +50,17 @@ func (o OC) Exec(t test.TestHelper, podLocator PodLocatorFunc, container string,
 	if pod.Name == "" || pod.Namespace == "" {
 		t.Fatal("could not find pod using podLocatorFunc")
 	}
+	o.recordPod(t, pod)
+	if o.native != nil && o.native.supportsStreaming() {
+		// commands that rely on the local shell (pipes, redirects, etc.) can't be executed natively
+		if args, err := splitCommand(cmd); err == nil {
+			output, err := o.native.exec(t, pod, container, args)
+			if err != nil {
+				t.Fatalf("command %q in pod %s/%s failed: %v\n%s", cmd, pod.Namespace, pod.Name, err, output)
+			}
+			return runChecks(t, output, checks...)
+		}
+	}
 	containerFlag := ""
 	if container != "" {
 		containerFlag = "-c " + container
@@ -62,12 +73,16 @@ func (o OC) Exec(t test.TestHelper, podLocator PodLocatorFunc, container string,
 func (o OC) GetPodIP(t test.TestHelper, podLocator PodLocatorFunc) string {
 	t.T().Helper()
 	pod := podLocator(t, &o)
-	return o.Invoke(t, fmt.Sprintf("kubectl get pod -n %s %s -o jsonpath='{.status.podIP}'", pod.Namespace, pod.Name))
+	return o.GetJson(t, pod.Namespace, "pod", pod.Name, "{.status.podIP}")
 }
 
 func (o OC) Logs(t test.TestHelper, podLocator PodLocatorFunc, container string, checks ...common.CheckFunc) {
 	t.T().Helper()
 	pod := podLocator(t, &o)
+	if o.native != nil && o.native.supportsStreaming() {
+		runChecks(t, o.native.logs(t, pod, container, 0), checks...)
+		return
+	}
 	o.Invoke(t,
 		fmt.Sprintf("kubectl logs -n %s %s -c %s", pod.Namespace, pod.Name, container),
 		checks...)
@@ -76,6 +91,10 @@ func (o OC) Logs(t test.TestHelper, podLocator PodLocatorFunc, container string,
 func (o OC) LogsSince(t test.TestHelper, start time.Time, podLocator PodLocatorFunc, container string, checks ...common.CheckFunc) {
 	t.T().Helper()
 	pod := podLocator(t, &o)
+	if o.native != nil && o.native.supportsStreaming() {
+		runChecks(t, o.native.logs(t, pod, container, time.Since(start)), checks...)
+		return
+	}
 	o.Invoke(t,
 		fmt.Sprintf("kubectl logs -n %s %s -c %s --since=%ds", pod.Namespace, pod.Name, container, int(math.Ceil(time.Since(start).Seconds()))),
 		checks...)
@@ -83,6 +102,10 @@ func (o OC) LogsSince(t test.TestHelper, start time.Time, podLocator PodLocatorF
 
 func (o OC) LogsFromPods(t test.TestHelper, ns, selector string, checks ...common.CheckFunc) {
 	t.T().Helper()
+	if o.native != nil && o.native.supportsStreaming() {
+		runChecks(t, o.native.logsFromPods(t, ns, selector), checks...)
+		return
+	}
 	o.Invoke(t,
 		fmt.Sprintf("kubectl -n %s logs -l %s --all-containers --tail=-1", ns, selector),
 		checks...)
@@ -93,16 +116,13 @@ func (o OC) WaitPodRunning(t test.TestHelper, podLocator PodLocatorFunc) {
 	retry.UntilSuccessWithOptions(t, retry.Options().LogAttempts(false), func(t test.TestHelper) {
 		t.T().Helper()
 
-		o.withKubeconfig(t, func() {
-			t.T().Helper()
-			pod := podLocator(t, &o)
-			phase := shell.Executef(t, `kubectl -n %s get pods %s -o jsonpath="{.status.phase}"`, pod.Namespace, pod.Name)
-			if phase == "Running" {
-				t.Logf("Pod %s/%s is running!", pod.Namespace, pod.Name)
-			} else {
-				t.Fatalf("Pod %s/%s is not running: %s", pod.Namespace, pod.Name, phase)
-			}
-		})
+		pod := podLocator(t, &o)
+		phase := o.GetJson(t, pod.Namespace, "pods", pod.Name, "{.status.phase}")
+		if phase == "Running" {
+			t.Logf("Pod %s/%s is running!", pod.Namespace, pod.Name)
+		} else {
+			t.Fatalf("Pod %s/%s is not running: %s", pod.Namespace, pod.Name, phase)
+		}
 	})
 }
 
@@ -112,7 +132,15 @@ func (o OC) WaitPodReadyWithOptions(t test.TestHelper, options retry.RetryOption
 	retry.UntilSuccessWithOptions(t, options, func(t test.TestHelper) {
 		t.T().Helper()
 		pod = podLocator(t, &o)
-		condition := o.Invokef(t, "kubectl -n %s wait --for condition=Ready pod %s --timeout 1s || true", pod.Namespace, pod.Name) // TODO: Change shell execute to do not fail on error
+		var condition string
+		if o.native != nil {
+			var err error
+			if condition, err = o.native.waitFor(t, pod.Namespace, "pod", pod.Name, "condition=Ready", time.Second); err != nil {
+				condition = err.Error()
+			}
+		} else {
+			condition = o.Invokef(t, "kubectl -n %s wait --for condition=Ready pod %s --timeout 1s || true", pod.Namespace, pod.Name) // TODO: Change shell execute to do not fail on error
+		}
 		if strings.Contains(condition, "condition met") {
 			t.Logf("Pod %s in namespace %s is ready!", pod.Name, pod.Namespace)
 		} else {
@@ -123,11 +151,16 @@ func (o OC) WaitPodReadyWithOptions(t test.TestHelper, options retry.RetryOption
 
 func (o OC) WaitDeploymentRolloutComplete(t test.TestHelper, ns string, deploymentNames ...string) {
 	t.T().Helper()
+	o.recordNamespaces(t, ns)
 	timeout := 4 * time.Minute // TODO: make this configurable?
 	start := time.Now()
 	for _, name := range deploymentNames {
 		usedUpTime := time.Now().Sub(start)
 		remainingTime := timeout - usedUpTime
+		if o.native != nil {
+			o.native.rolloutStatus(t, ns, name, remainingTime)
+			continue
+		}
 		o.Invokef(t, "kubectl -n %s rollout status deploy/%s --timeout=%s", ns, name, remainingTime.Round(time.Second))
 	}
 }
@@ -135,6 +168,10 @@ func (o OC) WaitDeploymentRolloutComplete(t test.TestHelper, ns string, deployme
 func (o OC) RestartDeployments(t test.TestHelper, ns string, deploymentNames ...string) {
 	t.T().Helper()
 	for _, name := range deploymentNames {
+		if o.native != nil {
+			o.native.restartDeployment(t, ns, name)
+			continue
+		}
 		o.Invokef(t, "kubectl -n %s rollout restart deployment %s", ns, name)
 	}
 }
@@ -148,6 +185,10 @@ func (o OC) RestartAllPodsAndWaitReady(t test.TestHelper, namespaces ...string)
 func (o OC) RestartAllPods(t test.TestHelper, namespaces ...string) {
 	t.T().Helper()
 	for _, ns := range namespaces {
+		if o.native != nil {
+			o.native.deleteAllPods(t, ns)
+			continue
+		}
 		o.Invokef(t, "oc -n %s delete pod --all", ns)
 	}
 }
@@ -156,7 +197,7 @@ func (o OC) WaitPodsExist(t test.TestHelper, namespaces ...string) {
 	t.T().Helper()
 	for _, ns := range namespaces {
 		retry.UntilSuccess(t, func(t test.TestHelper) {
-			shell.Execute(t, fmt.Sprintf("oc get pods -n %s", ns), assert.OutputDoesNotContain(
+			o.Get(t, ns, "pods", "", assert.OutputDoesNotContain(
 				fmt.Sprintf("No resources found in %s namespace.", ns),
 				fmt.Sprintf("Found pods in %s", ns),
 				fmt.Sprintf("Did not find any pod in %s", ns),
@@ -167,7 +208,12 @@ func (o OC) WaitPodsExist(t test.TestHelper, namespaces ...string) {
 
 func (o OC) WaitAllPodsReady(t test.TestHelper, namespaces ...string) {
 	t.T().Helper()
+	o.recordNamespaces(t, namespaces...)
 	for _, ns := range namespaces {
+		if o.native != nil {
+			o.native.waitAllPodsReady(t, ns, 180*time.Second)
+			continue
+		}
 		o.Invokef(t, `oc -n %s wait --for condition=Ready --all pods --timeout 180s`, ns)
 	}
 }
@@ -175,7 +221,11 @@ func (o OC) WaitAllPodsReady(t test.TestHelper, namespaces ...string) {
 func (o OC) DeletePodNoWait(t test.TestHelper, podLocator PodLocatorFunc) {
 	t.T().Helper()
 	pod := podLocator(t, &o)
-	shell.Executef(t, `oc -n %s delete pod %s --wait=false`, pod.Namespace, pod.Name)
+	if o.native != nil {
+		o.native.deleteNoWait(t, pod.Namespace, "pod", pod.Name)
+		return
+	}
+	o.Invokef(t, `oc -n %s delete pod %s --wait=false`, pod.Namespace, pod.Name)
 }
 
 // WaitFor runs `oc wait` 30 times every 10 seconds. If the resource doesn't
@@ -189,7 +239,15 @@ func (o OC) WaitFor(t test.TestHelper, ns string, kind string, name string, forC
 		t.Logf("Wait for condition %s on %s %s/%s...", forCondition, kind, ns, name)
 		attemptT = retry.Attempt(t, func(t test.TestHelper) {
 			t.T().Helper()
-			shell.Execute(t,
+			if o.native != nil {
+				output, err := o.native.waitFor(t, ns, kind, name, forCondition, 10*time.Second)
+				if err != nil {
+					t.Fatalf("Condition %s not met by %s %s/%s: %v", forCondition, kind, ns, name, err)
+				}
+				t.Log(output)
+				return
+			}
+			o.Invoke(t,
 				fmt.Sprintf(`oc wait %s %s/%s --for %s --timeout %s`, nsFlag(ns), kind, name, forCondition, "10s"),
 				require.OutputContains("condition met",
 					fmt.Sprintf("Condition %s met by %s %s/%s", forCondition, kind, ns, name),
@@ -203,22 +261,33 @@ func (o OC) WaitFor(t test.TestHelper, ns string, kind string, name string, forC
 
 	// the last attempt has failed, so we print the buffered log statements and the output of `oc describe` to facilitate debugging
 	attemptT.FlushLogBuffer()
-	t.Logf("Running oc describe -n %s %s/%s\n%s", ns, kind, name, shell.Executef(t, `oc describe -n %s %s/%s`, ns, kind, name))
+	if o.native != nil {
+		t.Logf("Current state of %s %s/%s:\n%s", kind, ns, name, o.GetYaml(t, ns, kind, name))
+	} else {
+		t.Logf("Running oc describe -n %s %s/%s\n%s", ns, kind, name, o.Invokef(t, `oc describe -n %s %s/%s`, ns, kind, name))
+	}
 	t.FailNow()
 }
 
 func (o OC) WaitSMMRReady(t test.TestHelper, ns string) {
 	t.T().Helper()
 	t.Logf("Wait for smmr/default to be ready in namespace %s", ns)
-	o.withKubeconfig(t, func() {
-		t.T().Helper()
-		shell.Executef(t, `oc -n %s wait --for condition=Ready smmr/default --timeout 300s`, ns)
-	})
+	if o.native != nil {
+		if _, err := o.native.waitFor(t, ns, "smmr", "default", "condition=Ready", 300*time.Second); err != nil {
+			t.Fatalf("smmr/default in namespace %s is not ready: %v", ns, err)
+		}
+		return
+	}
+	o.Invokef(t, `oc -n %s wait --for condition=Ready smmr/default --timeout 300s`, ns)
 }
 
 func (o OC) GetAllResources(t test.TestHelper, ns string, checks ...common.CheckFunc) {
 	t.T().Helper()
-	shell.Execute(t,
+	if o.native != nil {
+		runChecks(t, o.native.table(t, ns, "pods,services,deployments,replicasets", ""), checks...)
+		return
+	}
+	o.Invoke(t,
 		fmt.Sprintf(`oc get all -n %s`, ns),
 		checks...)
 }
@@ -231,7 +300,14 @@ func (o OC) DeletePod(t test.TestHelper, podLocator PodLocatorFunc) {
 	})
 	retry.UntilSuccess(t, func(t test.TestHelper) {
 		t.T().Helper()
-		shell.Execute(t,
+		if o.native != nil {
+			runChecks(t, o.native.deleteResources(t, pod.Namespace, "pod", pod.Name),
+				assert.OutputContains("deleted",
+					fmt.Sprintf("Pod %s is being deleted", pod.Name),
+					fmt.Sprintf("Pod %s deletion return an error", pod.Name)))
+			return
+		}
+		o.Invoke(t,
 			fmt.Sprintf(`oc delete pod %s -n %s`, pod.Name, pod.Namespace),
 			assert.OutputContains("deleted",
 				fmt.Sprintf("Pod %s is being deleted", pod.Name),
@@ -246,6 +322,14 @@ func (o OC) WaitUntilResourceExist(t test.TestHelper, ns string, kind string, na
 		t.T().Helper()
 		retry.UntilSuccessWithOptions(t, retry.Options().DelayBetweenAttempts(5*time.Second), func(t test.TestHelper) {
 			t.T().Helper()
+			if o.native != nil {
+				if o.native.exists(t, ns, kind, name, "") {
+					t.Errorf("%s/%s still exist", kind, name)
+				} else {
+					t.Logf("%s/%s was deleted", kind, name)
+				}
+				return
+			}
 			shell.Execute(t,
 				fmt.Sprintf(`oc -n %s get %s/%s --ignore-not-found`, ns, kind, name),
 				assert.OutputDoesNotContain(name,
@@ -273,3 +357,12 @@ func (o OC) GetPodAnnotations(t test.TestHelper, podLocator PodLocatorFunc) map[
 	annotations := data.Metadata.Annotations
 	return annotations
 }
+
+// runChecks runs the checks against the output, just like shell.Execute does, and returns the output
+func runChecks(t test.TestHelper, output string, checks ...common.CheckFunc) string {
+	t.T().Helper()
+	for _, check := range checks {
+		check(t, output)
+	}
+	return output
+}
@@ -15,14 +15,12 @@
 package oc
 
 import (
-	"fmt"
-
 	"github.com/maistra/maistra-test-tool/pkg/util/test"
 )
 
 func (o OC) GetServiceClusterIP(t test.TestHelper, ns, serviceName string) string {
 	t.T().Helper()
-	return o.Invoke(t, fmt.Sprintf("kubectl get service -n %s %s -o jsonpath='{.spec.clusterIP}'", ns, serviceName))
+	return o.GetJson(t, ns, "service", serviceName, "{.spec.clusterIP}")
 }
 
 // GetLoadBalancerAddress returns the external address of a LoadBalancer service.
@@ -30,10 +28,10 @@ func (o OC) GetServiceClusterIP(t test.TestHelper, ns, serviceName string) strin
 func (o OC) GetLoadBalancerAddress(t test.TestHelper, ns, serviceName string) string {
 	t.T().Helper()
 	// Try IP first
-	addr := o.Invokef(t, `oc -n %s get svc %s -o jsonpath="{.status.loadBalancer.ingress[0].ip}"`, ns, serviceName)
+	addr := o.GetJson(t, ns, "svc", serviceName, "{.status.loadBalancer.ingress[0].ip}")
 	if addr != "" {
 		return addr
 	}
 	// Try hostname
-	return o.Invokef(t, `oc -n %s get svc %s -o jsonpath="{.status.loadBalancer.ingress[0].hostname}"`, ns, serviceName)
+	return o.GetJson(t, ns, "svc", serviceName, "{.status.loadBalancer.ingress[0].hostname}")
 }
@@ -0,0 +1,396 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/env"
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// ClusterSnapshot is the state of the objects that outlive the namespaces of a test, but affect all
+// subsequent tests: the specs of the control planes (SMCP, SMMR, Istio and IstioCNI), the Istio
+// configuration objects in the mesh namespaces (e.g. a mesh-wide PeerAuthentication) and the taints
+// of the nodes.
+type ClusterSnapshot struct {
+	objects map[objectRef]snapshotEntry
+}
+
+type snapshotEntry struct {
+	// kind is the kind in the form accepted by oc (e.g. "servicemeshcontrolplane.maistra.io")
+	kind string
+	obj  *unstructured.Unstructured
+	// state is the part of the object that is compared (the spec, or the taints of a node)
+	state interface{}
+	// shared is true for objects that tests are expected to create and delete (e.g. the SMCP deployed by
+	// ossm.DeployControlPlane and removed by tests that recreate the mesh namespace), so only
+	// modifications of these objects count as drift
+	shared bool
+}
+
+// Drift is a difference between two cluster snapshots
+type Drift struct {
+	Kind      string
+	Namespace string
+	Name      string
+	// Change is DriftAdded, DriftRemoved or DriftModified
+	Change string
+	// Fields lists the modified fields with their old and new values
+	Fields []string
+}
+
+const (
+	DriftAdded    = "added"
+	DriftRemoved  = "removed"
+	DriftModified = "modified"
+)
+
+func (d Drift) String() string {
+	str := fmt.Sprintf("%s %s", d.Change, objectRef{Kind: d.Kind, Namespace: d.Namespace, Name: d.Name})
+	if len(d.Fields) > 0 {
+		str += ": " + strings.Join(d.Fields, ", ")
+	}
+	return str
+}
+
+// controlPlaneKinds are the kinds of the control plane objects. They are listed in all namespaces.
+var controlPlaneKinds = []string{
+	"servicemeshcontrolplanes.maistra.io,servicemeshmemberrolls.maistra.io",
+	"istios.sailoperator.io,istiocnis.sailoperator.io",
+}
+
+// meshConfigKinds are the kinds of the Istio configuration objects that apply to the whole mesh
+// when they are created in the mesh namespace
+const meshConfigKinds = "virtualservices.networking.istio.io,destinationrules.networking.istio.io,gateways.networking.istio.io," +
+	"serviceentries.networking.istio.io,sidecars.networking.istio.io,envoyfilters.networking.istio.io," +
+	"peerauthentications.security.istio.io,authorizationpolicies.security.istio.io,requestauthentications.security.istio.io," +
+	"telemetries.telemetry.istio.io"
+
+// systemTaintPrefixes are the prefixes of the taints that Kubernetes and the cluster autoscaler manage
+var systemTaintPrefixes = []string{
+	"node.kubernetes.io/",
+	"node.cloudprovider.kubernetes.io/",
+	"ToBeDeletedByClusterAutoscaler",
+	"DeletionCandidateOfClusterAutoscaler",
+}
+
+func startDriftCheck(t test.TestHelper) {
+	t.T().Helper()
+	mode := env.GetDriftCheck()
+	if mode == "off" {
+		return
+	}
+	o := *DefaultOC
+	before := o.TakeSnapshot(t)
+	t.T().Cleanup(func() {
+		t.T().Helper()
+		if test.IsParallel(t) {
+			// the tests running concurrently modify the mesh too, so the drift can't be attributed to this test,
+			// and restoring the snapshot would revert their modifications
+			t.T().Logf("Skipping the drift check, since %s ran in parallel with other tests", t.Name())
+			return
+		}
+		drifts := before.Diff(o.TakeSnapshot(t))
+		if len(drifts) == 0 {
+			return
+		}
+		var lines []string
+		for _, drift := range drifts {
+			lines = append(lines, drift.String())
+		}
+		msg := fmt.Sprintf("%s left the mesh modified, which may affect subsequent tests:\n  %s", t.Name(), strings.Join(lines, "\n  "))
+		switch mode {
+		case "fail":
+			t.Error(msg)
+		case "restore":
+			t.T().Log("WARNING: " + msg)
+			t.T().Log("Restoring the state of the mesh (set DRIFT_CHECK=warn to keep the modifications)")
+			o.RestoreSnapshot(t, before)
+		default:
+			t.T().Log("WARNING: " + msg)
+		}
+	})
+}
+
+func TakeSnapshot(t test.TestHelper) *ClusterSnapshot {
+	t.T().Helper()
+	return DefaultOC.TakeSnapshot(t)
+}
+
+func RestoreSnapshot(t test.TestHelper, snapshot *ClusterSnapshot) {
+	t.T().Helper()
+	DefaultOC.RestoreSnapshot(t, snapshot)
+}
+
+// TakeSnapshot captures the state of the cluster objects described in ClusterSnapshot.
+// Kinds whose CRDs aren't installed (e.g. the Sail operator's kinds on OSSM 2) are ignored.
+func (o OC) TakeSnapshot(t test.TestHelper) *ClusterSnapshot {
+	t.T().Helper()
+	s := &ClusterSnapshot{objects: map[objectRef]snapshotEntry{}}
+	for _, kinds := range controlPlaneKinds {
+		objects := o.listObjects(t, "", kinds)
+		for i := range objects {
+			s.add(&objects[i], objects[i].Object["spec"], true)
+		}
+	}
+	for _, ns := range meshNamespaces() {
+		objects := o.listObjects(t, ns, meshConfigKinds)
+		for i := range objects {
+			if !isOperatorManaged(&objects[i]) {
+				s.add(&objects[i], objects[i].Object["spec"], false)
+			}
+		}
+	}
+	nodes := o.listObjects(t, "", "nodes")
+	for i := range nodes {
+		s.add(&nodes[i], userTaints(&nodes[i]), true)
+	}
+	return s
+}
+
+func (s *ClusterSnapshot) add(obj *unstructured.Unstructured, state interface{}, shared bool) {
+	ref := objectRef{Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	s.objects[ref] = snapshotEntry{kind: kindOf(obj), obj: obj, state: state, shared: shared}
+}
+
+// Diff returns the differences between this snapshot and a later one, sorted by object
+func (s *ClusterSnapshot) Diff(after *ClusterSnapshot) []Drift {
+	var drifts []Drift
+	for ref, entry := range s.objects {
+		afterEntry, found := after.objects[ref]
+		if !found {
+			if !entry.shared {
+				drifts = append(drifts, Drift{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name, Change: DriftRemoved})
+			}
+			continue
+		}
+		if !reflect.DeepEqual(entry.state, afterEntry.state) {
+			drifts = append(drifts, Drift{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name, Change: DriftModified,
+				Fields: diffFields(stateField(entry.obj), entry.state, afterEntry.state)})
+		}
+	}
+	for ref, entry := range after.objects {
+		if _, found := s.objects[ref]; !found && !entry.shared {
+			drifts = append(drifts, Drift{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name, Change: DriftAdded})
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].String() < drifts[j].String()
+	})
+	return drifts
+}
+
+// RestoreSnapshot reverts the drift between the snapshot and the current state of the cluster:
+// added objects are deleted, removed objects are recreated and modified specs and taints are
+// reverted. The command waits for a reverted SMCP to become ready again.
+func (o OC) RestoreSnapshot(t test.TestHelper, snapshot *ClusterSnapshot) {
+	t.T().Helper()
+	current := o.TakeSnapshot(t)
+	for _, drift := range snapshot.Diff(current) {
+		ref := objectRef{Kind: drift.Kind, Namespace: drift.Namespace, Name: drift.Name}
+		switch drift.Change {
+		case DriftAdded:
+			o.DeleteResource(t, ref.Namespace, current.objects[ref].kind, ref.Name)
+		case DriftRemoved:
+			o.ApplyString(t, ref.Namespace, manifestOf(t, snapshot.objects[ref].obj))
+		case DriftModified:
+			entry := snapshot.objects[ref]
+			o.Patch(t, ref.Namespace, entry.kind, ref.Name, "json", restorePatch(t, entry, current.objects[ref]))
+			if ref.Kind == "ServiceMeshControlPlane" {
+				o.WaitSMCPReady(t, ref.Namespace, ref.Name)
+			}
+		}
+	}
+}
+
+// restorePatch returns a JSON patch that replaces the spec (or the user taints of a node) with the snapshot
+func restorePatch(t test.TestHelper, snapshot, current snapshotEntry) string {
+	t.T().Helper()
+	path := "/spec"
+	value := snapshot.state
+	if snapshot.obj.GetKind() == "Node" {
+		path = "/spec/taints"
+		taints, _ := snapshot.state.([]interface{})
+		currentTaints, _, _ := unstructured.NestedSlice(current.obj.Object, "spec", "taints")
+		for _, taint := range currentTaints {
+			if isSystemTaint(taint) {
+				taints = append(taints, taint)
+			}
+		}
+		value = taints
+	}
+	if value == nil {
+		value = map[string]interface{}{}
+		if path == "/spec/taints" {
+			value = []interface{}{}
+		}
+	}
+	patch, err := json.Marshal([]map[string]interface{}{{"op": "add", "path": path, "value": value}})
+	if err != nil {
+		t.Fatalf("could not marshal patch: %v", err)
+	}
+	return string(patch)
+}
+
+// manifestOf returns the manifest with which a removed object is recreated
+func manifestOf(t test.TestHelper, obj *unstructured.Unstructured) string {
+	t.T().Helper()
+	obj = obj.DeepCopy()
+	delete(obj.Object, "status")
+	for _, field := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "ownerReferences"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(obj.Object, "metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration")
+	manifest, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		t.Fatalf("could not marshal %s %s: %v", obj.GetKind(), obj.GetName(), err)
+	}
+	return string(manifest)
+}
+
+func meshNamespaces() []string {
+	if env.GetDefaultMeshNamespace() == env.GetIstioNamespace() {
+		return []string{env.GetDefaultMeshNamespace()}
+	}
+	return []string{env.GetDefaultMeshNamespace(), env.GetIstioNamespace()}
+}
+
+// isOperatorManaged returns true if the object is owned by another object or managed by an operator,
+// which restores it on its own
+func isOperatorManaged(obj *unstructured.Unstructured) bool {
+	labels := obj.GetLabels()
+	return len(obj.GetOwnerReferences()) > 0 || labels["app.kubernetes.io/managed-by"] != "" || labels["maistra.io/owner"] != ""
+}
+
+// userTaints returns the taints of the node, except those managed by the system
+func userTaints(node *unstructured.Unstructured) interface{} {
+	taints, _, _ := unstructured.NestedSlice(node.Object, "spec", "taints")
+	var result []interface{}
+	for _, taint := range taints {
+		if !isSystemTaint(taint) {
+			result = append(result, taint)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func isSystemTaint(taint interface{}) bool {
+	key, _, _ := unstructured.NestedString(taint.(map[string]interface{}), "key")
+	for _, prefix := range systemTaintPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func stateField(obj *unstructured.Unstructured) string {
+	if obj.GetKind() == "Node" {
+		return "spec.taints"
+	}
+	return "spec"
+}
+
+// diffFields returns the fields that differ between two values (e.g. "spec.tracing.type: \"None\" -> \"Jaeger\"")
+func diffFields(path string, before, after interface{}) []string {
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if !beforeIsMap || !afterIsMap {
+		return []string{fmt.Sprintf("%s: %s -> %s", path, formatField(before), formatField(after))}
+	}
+	keys := map[string]bool{}
+	for key := range beforeMap {
+		keys[key] = true
+	}
+	for key := range afterMap {
+		keys[key] = true
+	}
+	var sorted []string
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	var fields []string
+	for _, key := range sorted {
+		fields = append(fields, diffFields(path+"."+key, beforeMap[key], afterMap[key])...)
+	}
+	return fields
+}
+
+func formatField(value interface{}) string {
+	if value == nil {
+		return "<none>"
+	}
+	str, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(str)
+}
+
+// kindOf returns the kind of the object in the form printed by oc apply (e.g. "deployment.apps")
+func kindOf(obj *unstructured.Unstructured) string {
+	kind := strings.ToLower(obj.GetKind())
+	if group := obj.GroupVersionKind().Group; group != "" {
+		kind += "." + group
+	}
+	return kind
+}
+
+// listObjects returns the objects of the comma-separated kinds in the namespace (or in all namespaces, if ns
+// is empty). Unlike the other commands, it ignores kinds that don't exist.
+func (o OC) listObjects(t test.TestHelper, ns, kinds string) []unstructured.Unstructured {
+	t.T().Helper()
+	if o.native != nil {
+		return o.native.listObjects(t, ns, kinds)
+	}
+	scope := "--all-namespaces"
+	if ns != "" {
+		scope = "-n " + ns
+	}
+	var result shell.Result
+	o.withKubeconfig(t, func() {
+		t.T().Helper()
+		result = shell.Run(t, fmt.Sprintf("oc get %s %s -o json", kinds, scope))
+	})
+	list := &unstructured.UnstructuredList{}
+	if !result.Succeeded() || list.UnmarshalJSON([]byte(result.Stdout)) != nil {
+		if !strings.Contains(kinds, ",") {
+			return nil
+		}
+		// one of the kinds doesn't exist, so list them one by one
+		var items []unstructured.Unstructured
+		for _, kind := range strings.Split(kinds, ",") {
+			items = append(items, o.listObjects(t, ns, kind)...)
+		}
+		return items
+	}
+	return list.Items
+}
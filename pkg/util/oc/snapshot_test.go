@@ -0,0 +1,144 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+const meshStateYaml = `
+apiVersion: v1
+kind: Node
+metadata:
+  name: worker-1
+spec:
+  taints:
+  - key: node.kubernetes.io/unreachable
+    effect: NoExecute
+---
+apiVersion: security.istio.io/v1beta1
+kind: PeerAuthentication
+metadata:
+  name: default
+  namespace: istio-system
+spec:
+  mtls:
+    mode: PERMISSIVE
+---
+apiVersion: security.istio.io/v1beta1
+kind: AuthorizationPolicy
+metadata:
+  name: managed
+  namespace: istio-system
+  labels:
+    maistra.io/owner: istio-system
+---
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: reviews
+  namespace: bookinfo
+`
+
+const extraPeerAuthenticationYaml = `
+apiVersion: security.istio.io/v1beta1
+kind: PeerAuthentication
+metadata:
+  name: strict
+  namespace: istio-system
+spec:
+  mtls:
+    mode: STRICT
+`
+
+func TestSnapshotDiffAndRestore(t *testing.T) {
+	th := test.NewTestHelper(t)
+	oc, cluster := NewFakeOC(th, meshStateYaml, smcpYaml)
+	before := oc.TakeSnapshot(th)
+
+	oc.Patch(th, "istio-system", "smcp", "basic", "merge", `{"spec":{"tracing":{"type":"None"}}}`)
+	oc.Patch(th, "istio-system", "pa", "default", "merge", `{"spec":{"mtls":{"mode":"STRICT"}}}`)
+	oc.ApplyString(th, "istio-system", extraPeerAuthenticationYaml)
+	oc.DeleteResource(th, "istio-system", "ap", "managed")
+	oc.TaintNode(th, "worker-1", "dedicated=test:NoSchedule")
+	oc.DeleteResource(th, "bookinfo", "vs", "reviews")
+	oc.ApplyString(th, "bookinfo", extraPeerAuthenticationYaml)
+
+	drifts := before.Diff(oc.TakeSnapshot(th))
+	expected := []Drift{
+		{Kind: "PeerAuthentication", Namespace: "istio-system", Name: "strict", Change: DriftAdded},
+		{Kind: "Node", Name: "worker-1", Change: DriftModified,
+			Fields: []string{`spec.taints: <none> -> [{"effect":"NoSchedule","key":"dedicated","value":"test"}]`}},
+		{Kind: "PeerAuthentication", Namespace: "istio-system", Name: "default", Change: DriftModified,
+			Fields: []string{`spec.mtls.mode: "PERMISSIVE" -> "STRICT"`}},
+		{Kind: "ServiceMeshControlPlane", Namespace: "istio-system", Name: "basic", Change: DriftModified,
+			Fields: []string{`spec.tracing: <none> -> {"type":"None"}`}},
+	}
+	if !reflect.DeepEqual(drifts, expected) {
+		t.Errorf("expected drift:\n%v\ngot:\n%v", expected, drifts)
+	}
+
+	oc.DeleteResource(th, "istio-system", "pa", "default")
+	oc.RestoreSnapshot(th, before)
+	if drifts := before.Diff(oc.TakeSnapshot(th)); len(drifts) != 0 {
+		t.Errorf("expected no drift after restoring the snapshot, got %v", drifts)
+	}
+	taints := cluster.Get(th, "", "node", "worker-1").Object["spec"].(map[string]interface{})["taints"]
+	expectedTaints := []interface{}{map[string]interface{}{"key": "node.kubernetes.io/unreachable", "effect": "NoExecute"}}
+	if !reflect.DeepEqual(taints, expectedTaints) {
+		t.Errorf("expected the system taints to be kept, got %v", taints)
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	before := map[string]interface{}{"a": map[string]interface{}{"b": "x", "c": true}, "d": []interface{}{"1"}}
+	after := map[string]interface{}{"a": map[string]interface{}{"b": "y"}, "d": []interface{}{"1", "2"}, "e": 1}
+	expected := []string{
+		`spec.a.b: "x" -> "y"`,
+		`spec.a.c: true -> <none>`,
+		`spec.d: ["1"] -> ["1","2"]`,
+		`spec.e: <none> -> 1`,
+	}
+	if fields := diffFields("spec", before, after); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected %v, got %v", expected, fields)
+	}
+}
+
+func TestDriftOfParallelTestIsNotRestored(t *testing.T) {
+	t.Setenv("TEST_GROUP", string(test.Full))
+	t.Setenv("OCP_ARCH", "x86")
+	t.Setenv("LEAK_CHECK", "off")
+	t.Setenv("DRIFT_CHECK", "restore")
+	th := test.NewTestHelper(t)
+	oc, cluster := NewFakeOC(th, meshStateYaml)
+	cluster.UseAsDefault(th)
+
+	t.Run("group", func(t *testing.T) {
+		t.Run("test", func(t *testing.T) {
+			test.NewTest(t).Groups(test.Full).Run(func(t test.TestHelper) {
+				t.Parallel()
+				oc.Patch(t, "istio-system", "pa", "default", "merge", `{"spec":{"mtls":{"mode":"STRICT"}}}`)
+			})
+		})
+	})
+
+	mode := cluster.Get(th, "istio-system", "pa", "default").Object["spec"].(map[string]interface{})["mtls"].(map[string]interface{})["mode"]
+	if mode != "STRICT" {
+		t.Errorf("expected the modification of a parallel test to be kept, got mtls mode %v", mode)
+	}
+}
@@ -34,7 +34,7 @@ func GetFullCsvName(t test.TestHelper, namespace string, partialCsvName string)
 func WaitForOperatorInNamespaceReady(t test.TestHelper, namespace string, operatorSelector string, partialCsvName string) {
 	t.Logf("Waiting for operator csv %s to succeed", partialCsvName)
 	// When the operator is installed, the CSV take some time to be created, need to wait until is created to validate the phase
-	retry.UntilSuccessWithOptions(t, retry.Options().DelayBetweenAttempts(5*time.Second).MaxAttempts(70), func(t test.TestHelper) {
+	retry.UntilSuccessWithOptions(t, retry.Options().Timeout(6*time.Minute).DelayBetweenAttempts(5*time.Second), func(t test.TestHelper) {
 		if !operatorCsvExistsGlobally(t, partialCsvName) {
 			t.Errorf("Operator csv %s is not yet installed", partialCsvName)
 		}
@@ -43,7 +43,7 @@ func WaitForOperatorInNamespaceReady(t test.TestHelper, namespace string, operat
 	csvFullName := GetFullCsvName(t, namespace, partialCsvName)
 	oc.WaitForPhase(t, namespace, "csv", csvFullName, "Succeeded")
 	t.Logf("Waiting for operator pod with the selector %s", operatorSelector)
-	oc.WaitPodReadyWithOptions(t, retry.Options().MaxAttempts(70).DelayBetweenAttempts(5*time.Second), pod.MatchingSelector(operatorSelector, namespace))
+	oc.WaitPodReadyWithOptions(t, retry.Options().Timeout(6*time.Minute).DelayBetweenAttempts(5*time.Second), pod.MatchingSelector(operatorSelector, namespace))
 }
 
 func CreateOperatorViaOlm(t test.TestHelper, namespace string, partialCsvName string, subscriptionYaml string, operatorPodSelector string, input interface{}) {
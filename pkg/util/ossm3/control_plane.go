@@ -0,0 +1,199 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossm3
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// DeployControlPlane installs the IstioCNI and the Istio, waits until they're ready and enrolls the namespaces
+// in the control plane (see Istio.EnrollNamespaces)
+func DeployControlPlane(t test.TestHelper, istio Istio, namespaces ...string) {
+	t.T().Helper()
+	DefaultIstioCNI().Install(t)
+	istio.Install(t)
+	if len(namespaces) > 0 {
+		istio.EnrollNamespaces(t, namespaces...)
+	}
+}
+
+// Install applies the Istio resource and waits until the control plane is ready
+func (i Istio) Install(t test.TestHelper) {
+	t.T().Helper()
+	t.Logf("Install Istio %s (version %s) in namespace %s", i.Name, i.Version, i.Namespace)
+	oc.ApplyString(t, "", i.Manifest())
+	oc.Label(t, "", "Istio", i.Name, oc.MaistraTestLabel+`=""`)
+	i.WaitReady(t)
+}
+
+// WaitReady waits until the Istio resource has the Ready condition
+func (i Istio) WaitReady(t test.TestHelper) {
+	t.T().Helper()
+	oc.DefaultOC.WaitFor(t, "", "Istio", i.Name, "condition=Ready")
+}
+
+func (i Istio) Delete(t test.TestHelper) {
+	t.T().Helper()
+	t.Logf("Delete Istio %s", i.Name)
+	oc.DeleteResource(t, "", "Istio", i.Name)
+}
+
+// ActiveRevision returns the name of the revision that the operator deployed for the current spec of the Istio resource.
+// It's the same as the name of the Istio resource with the InPlace update strategy.
+func (i Istio) ActiveRevision(t test.TestHelper) string {
+	t.T().Helper()
+	revision := oc.GetJson(t, "", "Istio", i.Name, "{.status.activeRevisionName}")
+	if revision == "" {
+		t.Fatalf("Istio %s doesn't have an active revision yet", i.Name)
+	}
+	return revision
+}
+
+// Revisions returns the revisions of the Istio resource, including inactive revisions that are still in use
+func (i Istio) Revisions(t test.TestHelper) []IstioRevision {
+	t.T().Helper()
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name            string `json:"name"`
+				OwnerReferences []struct {
+					Kind string `json:"kind"`
+					Name string `json:"name"`
+				} `json:"ownerReferences"`
+			} `json:"metadata"`
+			Spec struct {
+				Namespace string `json:"namespace"`
+				Version   string `json:"version"`
+			} `json:"spec"`
+			Status struct {
+				Conditions []struct {
+					Type   string `json:"type"`
+					Status string `json:"status"`
+				} `json:"conditions"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	output := oc.GetJson(t, "", "IstioRevision", "", "")
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		t.Fatalf("could not parse IstioRevisions: %v", err)
+	}
+
+	var revisions []IstioRevision
+	for _, item := range list.Items {
+		owned := false
+		for _, owner := range item.Metadata.OwnerReferences {
+			owned = owned || (owner.Kind == "Istio" && owner.Name == i.Name)
+		}
+		if !owned {
+			continue
+		}
+		revision := IstioRevision{Name: item.Metadata.Name, Namespace: item.Spec.Namespace, Version: item.Spec.Version}
+		for _, c := range item.Status.Conditions {
+			switch c.Type {
+			case "Ready":
+				revision.Ready = c.Status == "True"
+			case "InUse":
+				revision.InUse = c.Status == "True"
+			}
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions
+}
+
+// UpdateVersion changes the version of the control plane and waits until the operator has rolled out the active
+// revision with the new version. With the RevisionBased strategy, the workloads keep using the old revision until
+// their namespaces are enrolled in the new one (see EnrollNamespaces) and they're restarted.
+func (i Istio) UpdateVersion(t test.TestHelper, version string) Istio {
+	t.T().Helper()
+	t.Logf("Update Istio %s from version %s to %s (%s)", i.Name, i.Version, version, i.UpdateStrategy)
+	oc.Patch(t, "", "Istio", i.Name, "merge", fmt.Sprintf(`{"spec":{"version":%q}}`, version))
+	i.Version = version
+	i.waitActiveRevision(t)
+	return i
+}
+
+func (i Istio) waitActiveRevision(t test.TestHelper) {
+	t.T().Helper()
+	retry.UntilSuccessWithOptions(t, retry.Options().DelayBetweenAttempts(5*time.Second).MaxAttempts(120), func(t test.TestHelper) {
+		t.T().Helper()
+		active := i.ActiveRevision(t)
+		for _, revision := range i.Revisions(t) {
+			if revision.Name != active {
+				continue
+			}
+			if revision.Version != i.Version || !revision.Ready {
+				t.Fatalf("active revision %s of Istio %s has version %s and ready=%v, expected version %s to be ready",
+					active, i.Name, revision.Version, revision.Ready, i.Version)
+			}
+			t.Logf("Active revision %s of Istio %s is ready with version %s", active, i.Name, i.Version)
+			return
+		}
+		t.Fatalf("IstioRevision %s of Istio %s not found", active, i.Name)
+	})
+	i.WaitReady(t)
+}
+
+// EnrollNamespaces labels the namespaces so that their pods are injected by the active revision of the control plane:
+// with istio-injection=enabled if the revision is named "default" and with istio.io/rev otherwise. Existing pods
+// must be restarted to pick up the new sidecar.
+func (i Istio) EnrollNamespaces(t test.TestHelper, namespaces ...string) {
+	t.T().Helper()
+	revision := i.ActiveRevision(t)
+	labels := fmt.Sprintf(`{"istio-injection":null,"istio.io/rev":%q}`, revision)
+	if revision == "default" {
+		labels = `{"istio-injection":"enabled","istio.io/rev":null}`
+	}
+	for _, ns := range namespaces {
+		t.Logf("Enroll namespace %s in revision %s", ns, revision)
+		oc.Patch(t, "", "Namespace", ns, "merge", fmt.Sprintf(`{"metadata":{"labels":%s}}`, labels))
+	}
+}
+
+// Install applies the IstioCNI resource in its namespace and waits until the node agents are ready
+func (c IstioCNI) Install(t test.TestHelper) {
+	t.T().Helper()
+	t.Logf("Install IstioCNI %s (version %s) in namespace %s", c.Name, c.Version, c.Namespace)
+	oc.CreateNamespace(t, c.Namespace)
+	oc.ApplyString(t, "", c.Manifest())
+	oc.DefaultOC.WaitFor(t, "", "IstioCNI", c.Name, "condition=Ready")
+}
+
+func (c IstioCNI) Delete(t test.TestHelper) {
+	t.T().Helper()
+	t.Logf("Delete IstioCNI %s", c.Name)
+	oc.DeleteResource(t, "", "IstioCNI", c.Name)
+}
+
+// Install applies the ZTunnel resource in its namespace and waits until the node proxies are ready
+func (z ZTunnel) Install(t test.TestHelper) {
+	t.T().Helper()
+	t.Logf("Install ZTunnel %s (version %s) in namespace %s", z.Name, z.Version, z.Namespace)
+	oc.CreateNamespace(t, z.Namespace)
+	oc.ApplyString(t, "", z.Manifest())
+	oc.DefaultOC.WaitFor(t, "", "ZTunnel", z.Name, "condition=Ready")
+}
+
+func (z ZTunnel) Delete(t test.TestHelper) {
+	t.T().Helper()
+	t.Logf("Delete ZTunnel %s", z.Name)
+	oc.DeleteResource(t, "", "ZTunnel", z.Name)
+}
@@ -0,0 +1,203 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ossm3 models the OSSM 3 control plane, which the Sail operator manages through the cluster-scoped
+// Istio, IstioRevision, IstioCNI and ZTunnel resources instead of the ServiceMeshControlPlane.
+package ossm3
+
+import (
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/env"
+)
+
+const (
+	APIVersion = "sailoperator.io/v1"
+	// ZTunnelAPIVersion is the API version of ZTunnel, which is still an alpha API in OSSM 3.0 and 3.1
+	ZTunnelAPIVersion = "sailoperator.io/v1alpha1"
+)
+
+// UpdateStrategy determines how the operator updates the control plane when the Istio version changes
+type UpdateStrategy string
+
+const (
+	// InPlace replaces the control plane pods of the single revision, which is named like the Istio resource
+	InPlace UpdateStrategy = "InPlace"
+	// RevisionBased deploys a new revision next to the old one, which is deleted when no workload uses it anymore
+	RevisionBased UpdateStrategy = "RevisionBased"
+)
+
+// Istio is the control plane. Use the With... methods to customize a copy of DefaultIstio().
+type Istio struct {
+	Name      string
+	Namespace string
+	Version   string
+	Profile   string
+
+	UpdateStrategy UpdateStrategy
+	// InactiveRevisionDeletionGracePeriod is how long the operator keeps a revision that's no longer in use
+	// (RevisionBased only; the operator's default is used if zero)
+	InactiveRevisionDeletionGracePeriod time.Duration
+
+	// Values are the Helm values of the control plane (spec.values), e.g. {"meshConfig": {"accessLogFile": "/dev/stdout"}}
+	Values map[string]any
+}
+
+// IstioRevision is a revision of the control plane, which the operator creates for the Istio resource
+type IstioRevision struct {
+	Name      string
+	Namespace string
+	Version   string
+	Ready     bool
+	InUse     bool
+}
+
+// IstioCNI is the Istio CNI node agent, which OpenShift requires for the sidecars of all control planes
+type IstioCNI struct {
+	Name      string
+	Namespace string
+	Version   string
+	Profile   string
+	Values    map[string]any
+}
+
+// ZTunnel is the node proxy of the ambient data plane
+type ZTunnel struct {
+	Name      string
+	Namespace string
+	Version   string
+	Values    map[string]any
+}
+
+func DefaultIstio() Istio {
+	return Istio{
+		Name:           env.GetIstioName(),
+		Namespace:      env.GetIstioNamespace(),
+		Version:        env.GetIstioVersion(),
+		UpdateStrategy: InPlace,
+	}
+}
+
+func DefaultIstioCNI() IstioCNI {
+	return IstioCNI{
+		Name:      "default",
+		Namespace: "istio-cni",
+		Version:   env.GetIstioVersion(),
+	}
+}
+
+func DefaultZTunnel() ZTunnel {
+	return ZTunnel{
+		Name:      "default",
+		Namespace: "ztunnel",
+		Version:   env.GetIstioVersion(),
+	}
+}
+
+// WithName returns a copy of this Istio with the name changed to the specified name
+func (i Istio) WithName(name string) Istio {
+	i.Name = name
+	return i
+}
+
+func (i Istio) WithNamespace(ns string) Istio {
+	i.Namespace = ns
+	return i
+}
+
+func (i Istio) WithVersion(version string) Istio {
+	i.Version = version
+	return i
+}
+
+func (i Istio) WithProfile(profile string) Istio {
+	i.Profile = profile
+	return i
+}
+
+func (i Istio) WithUpdateStrategy(strategy UpdateStrategy) Istio {
+	i.UpdateStrategy = strategy
+	return i
+}
+
+// WithValues returns a copy of this Istio with the values replaced by the specified values
+func (i Istio) WithValues(values map[string]any) Istio {
+	i.Values = values
+	return i
+}
+
+// Manifest returns the YAML manifest of the Istio resource
+func (i Istio) Manifest() string {
+	spec := map[string]any{
+		"namespace": i.Namespace,
+		"version":   i.Version,
+	}
+	if i.Profile != "" {
+		spec["profile"] = i.Profile
+	}
+	if i.UpdateStrategy != "" {
+		strategy := map[string]any{"type": string(i.UpdateStrategy)}
+		if i.InactiveRevisionDeletionGracePeriod > 0 {
+			strategy["inactiveRevisionDeletionGracePeriodSeconds"] = int64(i.InactiveRevisionDeletionGracePeriod.Seconds())
+		}
+		spec["updateStrategy"] = strategy
+	}
+	if len(i.Values) > 0 {
+		spec["values"] = i.Values
+	}
+	return manifest(APIVersion, "Istio", i.Name, spec)
+}
+
+// Manifest returns the YAML manifest of the IstioCNI resource
+func (c IstioCNI) Manifest() string {
+	spec := map[string]any{
+		"namespace": c.Namespace,
+		"version":   c.Version,
+	}
+	if c.Profile != "" {
+		spec["profile"] = c.Profile
+	}
+	if len(c.Values) > 0 {
+		spec["values"] = c.Values
+	}
+	return manifest(APIVersion, "IstioCNI", c.Name, spec)
+}
+
+// Manifest returns the YAML manifest of the ZTunnel resource
+func (z ZTunnel) Manifest() string {
+	spec := map[string]any{
+		"namespace": z.Namespace,
+		"version":   z.Version,
+	}
+	if len(z.Values) > 0 {
+		spec["values"] = z.Values
+	}
+	return manifest(ZTunnelAPIVersion, "ZTunnel", z.Name, spec)
+}
+
+func manifest(apiVersion, kind, name string, spec map[string]any) string {
+	out, err := yaml.Marshal(map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]any{"name": name},
+		"spec":       spec,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("could not marshal %s %s: %v", kind, name, err))
+	}
+	return string(out)
+}
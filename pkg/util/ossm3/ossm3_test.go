@@ -0,0 +1,153 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ossm3
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+func TestManifest(t *testing.T) {
+	istio := DefaultIstio().
+		WithName("tenant-a").
+		WithNamespace("istio-system-a").
+		WithVersion("v1.24.3").
+		WithProfile("openshift").
+		WithUpdateStrategy(RevisionBased).
+		WithValues(map[string]any{"meshConfig": map[string]any{"accessLogFile": "/dev/stdout"}})
+	istio.InactiveRevisionDeletionGracePeriod = time.Minute
+
+	var actual map[string]any
+	if err := yaml.Unmarshal([]byte(istio.Manifest()), &actual); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{
+		"apiVersion": "sailoperator.io/v1",
+		"kind":       "Istio",
+		"metadata":   map[string]any{"name": "tenant-a"},
+		"spec": map[string]any{
+			"namespace": "istio-system-a",
+			"version":   "v1.24.3",
+			"profile":   "openshift",
+			"updateStrategy": map[string]any{
+				"type": "RevisionBased",
+				"inactiveRevisionDeletionGracePeriodSeconds": float64(60),
+			},
+			"values": map[string]any{"meshConfig": map[string]any{"accessLogFile": "/dev/stdout"}},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected manifest\n%v\ngot\n%v", expected, actual)
+	}
+
+	if err := yaml.Unmarshal([]byte(DefaultZTunnel().Manifest()), &actual); err != nil {
+		t.Fatal(err)
+	}
+	if actual["apiVersion"] != ZTunnelAPIVersion || actual["kind"] != "ZTunnel" {
+		t.Errorf("unexpected ZTunnel manifest: %v", actual)
+	}
+}
+
+func TestRevisions(t *testing.T) {
+	th := test.NewTestHelper(t)
+	_, cluster := oc.NewFakeOC(th, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: bookinfo
+  labels:
+    istio-injection: enabled
+---
+apiVersion: sailoperator.io/v1
+kind: Istio
+metadata:
+  name: default
+spec:
+  namespace: istio-system
+  version: v1.24.3
+  updateStrategy:
+    type: RevisionBased
+status:
+  activeRevisionName: default-v1-24-3
+  conditions:
+  - type: Ready
+    status: "True"
+---
+apiVersion: sailoperator.io/v1
+kind: IstioRevision
+metadata:
+  name: default-v1-24-2
+  ownerReferences:
+  - {apiVersion: sailoperator.io/v1, kind: Istio, name: default, uid: "1"}
+spec:
+  namespace: istio-system
+  version: v1.24.2
+status:
+  conditions:
+  - {type: Ready, status: "True"}
+  - {type: InUse, status: "True"}
+---
+apiVersion: sailoperator.io/v1
+kind: IstioRevision
+metadata:
+  name: default-v1-24-3
+  ownerReferences:
+  - {apiVersion: sailoperator.io/v1, kind: Istio, name: default, uid: "1"}
+spec:
+  namespace: istio-system
+  version: v1.24.3
+status:
+  conditions:
+  - {type: Ready, status: "True"}
+  - {type: InUse, status: "False"}
+---
+apiVersion: sailoperator.io/v1
+kind: IstioRevision
+metadata:
+  name: other
+  ownerReferences:
+  - {apiVersion: sailoperator.io/v1, kind: Istio, name: other, uid: "2"}
+spec:
+  namespace: istio-system-other
+  version: v1.24.3
+`)
+	cluster.UseAsDefault(th)
+
+	istio := DefaultIstio().WithName("default").WithVersion("v1.24.2").WithUpdateStrategy(RevisionBased)
+	expected := []IstioRevision{
+		{Name: "default-v1-24-2", Namespace: "istio-system", Version: "v1.24.2", Ready: true, InUse: true},
+		{Name: "default-v1-24-3", Namespace: "istio-system", Version: "v1.24.3", Ready: true, InUse: false},
+	}
+	if revisions := istio.Revisions(th); !reflect.DeepEqual(revisions, expected) {
+		t.Errorf("expected revisions %v, got %v", expected, revisions)
+	}
+
+	istio = istio.UpdateVersion(th, "v1.24.3")
+	if version := cluster.Get(th, "", "Istio", "default").Object["spec"].(map[string]any)["version"]; version != "v1.24.3" {
+		t.Errorf("expected spec.version to be updated to v1.24.3, got %v", version)
+	}
+
+	istio.EnrollNamespaces(th, "bookinfo")
+	labels := cluster.Get(th, "", "Namespace", "bookinfo").GetLabels()
+	if _, found := labels["istio-injection"]; found || labels["istio.io/rev"] != "default-v1-24-3" {
+		t.Errorf("expected namespace to be enrolled in revision default-v1-24-3, got labels %v", labels)
+	}
+}
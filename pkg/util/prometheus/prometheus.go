@@ -15,6 +15,8 @@
 package prometheus
 
 import (
+	"time"
+
 	"github.com/maistra/maistra-test-tool/pkg/util/test"
 )
 
@@ -29,30 +31,61 @@ var DefaultThanos = DefaultPrometheus.
 
 type PrometheusResult struct {
 	Metric map[string]string `json:"metric"`
-	Value  []interface{}     `json:"value"`
+	// Value is the [timestamp, "value"] pair of an instant vector sample
+	Value []interface{} `json:"value"`
+	// Values are the [timestamp, "value"] pairs of a range vector series
+	Values [][]interface{} `json:"values"`
 }
 
+// PrometheusResultData is the result of a query. Besides the raw Result, it contains the typed
+// Vector, Matrix or Scalar, depending on the ResultType.
 type PrometheusResultData struct {
 	ResultType string             `json:"resultType"`
 	Result     []PrometheusResult `json:"result"`
+
+	Vector Vector `json:"-"`
+	Matrix Matrix `json:"-"`
+	Scalar *Point `json:"-"`
 }
 
 type PrometheusResponse struct {
-	Status string               `json:"status"`
-	Data   PrometheusResultData `json:"data"`
+	Status    string               `json:"status"`
+	Data      PrometheusResultData `json:"data"`
+	ErrorType string               `json:"errorType"`
+	Error     string               `json:"error"`
 }
 
 type Prometheus interface {
 	WithSelector(selector string) Prometheus
 	WithContainerName(containerName string) Prometheus
+	// WithBaseURL sets the URL of the API inside the pod (by default "http://localhost:9090")
+	WithBaseURL(baseURL string) Prometheus
+	// WithToken sets the bearer token sent to an API behind an authenticating proxy (e.g. the Thanos querier on port 9091)
+	WithToken(token string) Prometheus
 	Query(t test.TestHelper, ns string, query string) PrometheusResponse
+	QueryRange(t test.TestHelper, ns string, query string, start, end time.Time, step time.Duration) PrometheusResponse
+	// WaitForMetric retries the instant query until all checks pass on the resulting vector and returns the vector
+	WaitForMetric(t test.TestHelper, ns string, query string, checks ...VectorCheck) Vector
 	Targets(t test.TestHelper, ns string) string
+	ActiveTargets(t test.TestHelper, ns string) []Target
+	// WaitForTargetUp retries until an active target of the scrape pool (e.g. "serviceMonitor/istio-system/istiod-monitor") is up
+	WaitForTargetUp(t test.TestHelper, ns string, scrapePool string)
+	Rules(t test.TestHelper, ns string) []RuleGroup
+	Alerts(t test.TestHelper, ns string) []Alert
 }
 
 func Query(t test.TestHelper, ns string, query string) PrometheusResponse {
 	return DefaultPrometheus.Query(t, ns, query)
 }
 
+func QueryRange(t test.TestHelper, ns string, query string, start, end time.Time, step time.Duration) PrometheusResponse {
+	return DefaultPrometheus.QueryRange(t, ns, query, start, end, step)
+}
+
+func WaitForMetric(t test.TestHelper, ns string, query string, checks ...VectorCheck) Vector {
+	return DefaultPrometheus.WaitForMetric(t, ns, query, checks...)
+}
+
 func CustomPrometheusQuery(t test.TestHelper, ns string, query string) PrometheusResponse {
 	return DefaultCustomPrometheus.Query(t, ns, query)
 }
@@ -0,0 +1,78 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"fmt"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// VectorCheck asserts on the result of an instant query (see Prometheus.WaitForMetric).
+// The checks select the samples with labels given as "name=value" and compare the sum of their values,
+// so that a check on a counter isn't affected by how many pods or label combinations report it.
+type VectorCheck func(t test.TestHelper, query string, v Vector)
+
+// HasSeries checks that the result contains a sample with the labels
+func HasSeries(labels ...string) VectorCheck {
+	return func(t test.TestHelper, query string, v Vector) {
+		t.T().Helper()
+		if len(v.Matching(labels...)) == 0 {
+			t.Errorf("expected query %s to return a sample with labels %v, got %v", query, labels, v)
+			return
+		}
+		t.LogSuccess(fmt.Sprintf("query %s returned a sample with labels %v", query, labels))
+	}
+}
+
+// ValueGreaterThan checks that the sum of the samples with the labels is greater than the threshold
+func ValueGreaterThan(threshold float64, labels ...string) VectorCheck {
+	return compareValue(fmt.Sprintf("greater than %v", threshold), func(value float64) bool {
+		return value > threshold
+	}, labels)
+}
+
+// ValueEquals checks that the sum of the samples with the labels equals the expected value
+func ValueEquals(expected float64, labels ...string) VectorCheck {
+	return compareValue(fmt.Sprintf("equal to %v", expected), func(value float64) bool {
+		return value == expected
+	}, labels)
+}
+
+// IncreasedSince checks that the sum of the samples with the labels is greater than in the baseline,
+// which is the result of the same query before the traffic was generated. Samples that are missing in
+// the baseline (e.g. a counter that didn't exist yet) count as zero.
+func IncreasedSince(baseline Vector, labels ...string) VectorCheck {
+	before := baseline.Matching(labels...).Sum()
+	return compareValue(fmt.Sprintf("greater than the baseline %v", before), func(value float64) bool {
+		return value > before
+	}, labels)
+}
+
+func compareValue(description string, matches func(value float64) bool, labels []string) VectorCheck {
+	return func(t test.TestHelper, query string, v Vector) {
+		t.T().Helper()
+		samples := v.Matching(labels...)
+		if len(samples) == 0 {
+			t.Errorf("expected query %s to return a sample with labels %v, got %v", query, labels, v)
+			return
+		}
+		if value := samples.Sum(); !matches(value) {
+			t.Errorf("expected the value of query %s with labels %v to be %s, got %v", query, labels, description, value)
+			return
+		}
+		t.LogSuccess(fmt.Sprintf("value of query %s with labels %v is %s", query, labels, description))
+	}
+}
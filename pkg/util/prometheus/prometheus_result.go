@@ -0,0 +1,230 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Point is a value at a point in time
+type Point struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// Sample is a sample of an instant vector
+type Sample struct {
+	Labels map[string]string
+	Point
+}
+
+// Vector is the result of an instant query
+type Vector []Sample
+
+// Series is a series of a range vector
+type Series struct {
+	Labels map[string]string
+	Points []Point
+}
+
+// Matrix is the result of a range query
+type Matrix []Series
+
+// Matching returns the samples that have all the labels, which are given as "name=value"
+func (v Vector) Matching(labels ...string) Vector {
+	var matching Vector
+	for _, sample := range v {
+		if hasLabels(sample.Labels, labels) {
+			matching = append(matching, sample)
+		}
+	}
+	return matching
+}
+
+// Sum returns the sum of the values of the samples
+func (v Vector) Sum() float64 {
+	var sum float64
+	for _, sample := range v {
+		sum += sample.Value
+	}
+	return sum
+}
+
+// Matching returns the series that have all the labels, which are given as "name=value"
+func (m Matrix) Matching(labels ...string) Matrix {
+	var matching Matrix
+	for _, series := range m {
+		if hasLabels(series.Labels, labels) {
+			matching = append(matching, series)
+		}
+	}
+	return matching
+}
+
+// Increase returns the difference between the last and the first value of the series. Unlike the
+// PromQL increase() function, it doesn't extrapolate or account for counter resets.
+func (s Series) Increase() float64 {
+	if len(s.Points) == 0 {
+		return 0
+	}
+	return s.Points[len(s.Points)-1].Value - s.Points[0].Value
+}
+
+func (s Sample) String() string {
+	return fmt.Sprintf("%s %v", formatLabels(s.Labels), s.Value)
+}
+
+func hasLabels(actual map[string]string, labels []string) bool {
+	for _, label := range labels {
+		name, value, _ := strings.Cut(label, "=")
+		if actual[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func formatLabels(labels map[string]string) string {
+	var pairs []string
+	for name, value := range labels {
+		if name != "__name__" {
+			pairs = append(pairs, fmt.Sprintf("%s=%q", name, value))
+		}
+	}
+	sort.Strings(pairs)
+	return labels["__name__"] + "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (d *PrometheusResultData) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	d.ResultType = raw.ResultType
+	if len(raw.Result) == 0 {
+		return nil
+	}
+
+	switch raw.ResultType {
+	case "vector", "matrix":
+		if err := json.Unmarshal(raw.Result, &d.Result); err != nil {
+			return err
+		}
+		for _, result := range d.Result {
+			if raw.ResultType == "vector" {
+				point, err := parsePoint(result.Value)
+				if err != nil {
+					return err
+				}
+				d.Vector = append(d.Vector, Sample{Labels: result.Metric, Point: point})
+				continue
+			}
+			series := Series{Labels: result.Metric}
+			for _, value := range result.Values {
+				point, err := parsePoint(value)
+				if err != nil {
+					return err
+				}
+				series.Points = append(series.Points, point)
+			}
+			d.Matrix = append(d.Matrix, series)
+		}
+	case "scalar":
+		var value []interface{}
+		if err := json.Unmarshal(raw.Result, &value); err != nil {
+			return err
+		}
+		point, err := parsePoint(value)
+		if err != nil {
+			return err
+		}
+		d.Scalar = &point
+	}
+	return nil
+}
+
+// parsePoint parses a [timestamp, "value"] pair, in which the timestamp is in seconds
+func parsePoint(pair []interface{}) (Point, error) {
+	if len(pair) != 2 {
+		return Point{}, fmt.Errorf("expected a [timestamp, value] pair, got %v", pair)
+	}
+	timestamp, ok := pair[0].(float64)
+	if !ok {
+		return Point{}, fmt.Errorf("expected a numeric timestamp, got %v", pair[0])
+	}
+	text, ok := pair[1].(string)
+	if !ok {
+		return Point{}, fmt.Errorf("expected a string value, got %v", pair[1])
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("could not parse value %q: %v", text, err)
+	}
+	seconds, fraction := math.Modf(timestamp)
+	return Point{Timestamp: time.Unix(int64(seconds), int64(fraction*1e9)).UTC(), Value: value}, nil
+}
+
+// Target is a scrape target
+type Target struct {
+	DiscoveredLabels map[string]string `json:"discoveredLabels"`
+	Labels           map[string]string `json:"labels"`
+	ScrapePool       string            `json:"scrapePool"`
+	ScrapeURL        string            `json:"scrapeUrl"`
+	LastError        string            `json:"lastError"`
+	LastScrape       time.Time         `json:"lastScrape"`
+	// Health is "up", "down" or "unknown"
+	Health string `json:"health"`
+}
+
+func (t Target) Up() bool {
+	return t.Health == "up"
+}
+
+// RuleGroup is a group of recording and alerting rules
+type RuleGroup struct {
+	Name  string `json:"name"`
+	File  string `json:"file"`
+	Rules []Rule `json:"rules"`
+}
+
+type Rule struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	// Type is "alerting" or "recording"
+	Type   string            `json:"type"`
+	Health string            `json:"health"`
+	Labels map[string]string `json:"labels"`
+	// State is the state of an alerting rule: "inactive", "pending" or "firing"
+	State  string  `json:"state"`
+	Alerts []Alert `json:"alerts"`
+}
+
+type Alert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	// State is "pending" or "firing"
+	State    string    `json:"state"`
+	ActiveAt time.Time `json:"activeAt"`
+	Value    string    `json:"value"`
+}
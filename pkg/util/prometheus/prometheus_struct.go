@@ -18,20 +18,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/maistra/maistra-test-tool/pkg/util/oc"
 	"github.com/maistra/maistra-test-tool/pkg/util/pod"
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
 	"github.com/maistra/maistra-test-tool/pkg/util/test"
 )
 
 func NewPrometheus(selector, containerName string) Prometheus {
-	return &prometheus_struct{selector, containerName}
+	return &prometheus_struct{selector: selector, containerName: containerName, baseURL: "http://localhost:9090"}
 }
 
 type prometheus_struct struct {
 	selector      string
 	containerName string
+	baseURL       string
+	token         string
 }
 
 func (pi *prometheus_struct) clone() *prometheus_struct {
@@ -53,17 +58,134 @@ func (pi *prometheus_struct) WithContainerName(containerName string) Prometheus
 	return new
 }
 
+func (pi *prometheus_struct) WithBaseURL(baseURL string) Prometheus {
+	new := pi.clone()
+	new.baseURL = strings.TrimSuffix(baseURL, "/")
+	return new
+}
+
+func (pi *prometheus_struct) WithToken(token string) Prometheus {
+	new := pi.clone()
+	new.token = token
+	return new
+}
+
 func (pi *prometheus_struct) Query(t test.TestHelper, ns string, query string) PrometheusResponse {
 	queryString := url.Values{"query": []string{query}}.Encode()
 	output := getPrometheusApi(t, pi, ns, fmt.Sprintf(`query?%s`, queryString))
 	return parsePrometheusResponse(t, output)
 }
 
+func (pi *prometheus_struct) QueryRange(t test.TestHelper, ns string, query string, start, end time.Time, step time.Duration) PrometheusResponse {
+	queryString := url.Values{
+		"query": []string{query},
+		"start": []string{formatTimestamp(start)},
+		"end":   []string{formatTimestamp(end)},
+		"step":  []string{strconv.FormatFloat(step.Seconds(), 'f', -1, 64)},
+	}.Encode()
+	output := getPrometheusApi(t, pi, ns, fmt.Sprintf(`query_range?%s`, queryString))
+	return parsePrometheusResponse(t, output)
+}
+
+func (pi *prometheus_struct) WaitForMetric(t test.TestHelper, ns string, query string, checks ...VectorCheck) Vector {
+	t.T().Helper()
+	var vector Vector
+	retry.UntilSuccess(t, func(t test.TestHelper) {
+		t.T().Helper()
+		resp := pi.Query(t, ns, query)
+		if resp.Status != "success" {
+			t.Errorf("Prometheus query %s failed: %s: %s", query, resp.ErrorType, resp.Error)
+			return
+		}
+		vector = resp.Data.Vector
+		for _, check := range checks {
+			check(t, query, vector)
+		}
+	})
+	return vector
+}
+
 func (pi *prometheus_struct) Targets(t test.TestHelper, ns string) string {
 	output := getPrometheusApi(t, pi, ns, `targets?state=active`)
 	return output
 }
 
+func (pi *prometheus_struct) ActiveTargets(t test.TestHelper, ns string) []Target {
+	var data struct {
+		ActiveTargets []Target `json:"activeTargets"`
+	}
+	getPrometheusData(t, pi, ns, `targets?state=active`, &data)
+	return data.ActiveTargets
+}
+
+func (pi *prometheus_struct) WaitForTargetUp(t test.TestHelper, ns string, scrapePool string) {
+	t.T().Helper()
+	retry.UntilSuccess(t, func(t test.TestHelper) {
+		t.T().Helper()
+		pi.checkTargetUp(t, ns, scrapePool)
+	})
+}
+
+func (pi *prometheus_struct) checkTargetUp(t test.TestHelper, ns string, scrapePool string) {
+	t.T().Helper()
+	var errors []string
+	for _, target := range pi.ActiveTargets(t, ns) {
+		if !strings.HasPrefix(target.ScrapePool, scrapePool) {
+			continue
+		}
+		if target.Up() {
+			t.LogSuccess(fmt.Sprintf("Prometheus target %s of scrape pool %s is up", target.ScrapeURL, target.ScrapePool))
+			return
+		}
+		errors = append(errors, fmt.Sprintf("%s is %s: %s", target.ScrapeURL, target.Health, target.LastError))
+	}
+	if len(errors) == 0 {
+		t.Errorf("Prometheus has no active target in scrape pool %s", scrapePool)
+		return
+	}
+	t.Errorf("no Prometheus target of scrape pool %s is up: %v", scrapePool, errors)
+}
+
+func (pi *prometheus_struct) Rules(t test.TestHelper, ns string) []RuleGroup {
+	var data struct {
+		Groups []RuleGroup `json:"groups"`
+	}
+	getPrometheusData(t, pi, ns, `rules`, &data)
+	return data.Groups
+}
+
+func (pi *prometheus_struct) Alerts(t test.TestHelper, ns string) []Alert {
+	var data struct {
+		Alerts []Alert `json:"alerts"`
+	}
+	getPrometheusData(t, pi, ns, `alerts`, &data)
+	return data.Alerts
+}
+
+func formatTimestamp(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/1e9, 'f', -1, 64)
+}
+
+// getPrometheusData decodes the data field of a successful API response into v
+func getPrometheusData(t test.TestHelper, pi *prometheus_struct, ns string, endpoint string, v interface{}) {
+	output := getPrometheusApi(t, pi, ns, endpoint)
+	var response struct {
+		Status string          `json:"status"`
+		Data   json.RawMessage `json:"data"`
+		Error  string          `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(output), &response); err != nil {
+		t.Logf("Prometheus response:\n%s", output)
+		t.Fatalf("could not parse Prometheus response as JSON: %v", err)
+	}
+	if response.Status != "success" {
+		t.Fatalf("Prometheus request %s failed: %s", endpoint, response.Error)
+	}
+	if err := json.Unmarshal(response.Data, v); err != nil {
+		t.Fatalf("could not parse the data of Prometheus response to %s: %v", endpoint, err)
+	}
+}
+
 func parsePrometheusResponse(t test.TestHelper, response string) PrometheusResponse {
 	result := &PrometheusResponse{}
 	err := json.Unmarshal([]byte(response), result)
@@ -76,12 +198,15 @@ func parsePrometheusResponse(t test.TestHelper, response string) PrometheusRespo
 }
 
 func getPrometheusApi(t test.TestHelper, pi *prometheus_struct, ns string, endpoint string) string {
-	url := fmt.Sprintf(`http://localhost:9090/api/v1/%s`, endpoint)
+	url := fmt.Sprintf(`%s/api/v1/%s`, pi.baseURL, endpoint)
 	urlShellEscaped := strings.ReplaceAll(url, `'`, `'\\''`)
 
-	output := oc.Exec(t,
-		pod.MatchingSelectorFirst(pi.selector, ns), pi.containerName,
-		// comunity prometheus image doesn't have `curl`, use wget instead
-		fmt.Sprintf("wget -qO- '%s'", urlShellEscaped))
+	// comunity prometheus image doesn't have `curl`, use wget instead
+	cmd := fmt.Sprintf("wget -qO- '%s'", urlShellEscaped)
+	if pi.token != "" {
+		// the authenticating proxies use certificates issued by the service CA
+		cmd = fmt.Sprintf("curl -sSk -H 'Authorization: Bearer %s' '%s'", pi.token, urlShellEscaped)
+	}
+	output := oc.Exec(t, pod.MatchingSelectorFirst(pi.selector, ns), pi.containerName, cmd)
 	return output
 }
@@ -0,0 +1,143 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+const vectorResponse = `{"status": "success", "data": {"resultType": "vector", "result": [
+  {"metric": {"__name__": "istio_requests_total", "destination_app": "httpbin", "response_code": "200"}, "value": [1767225600.5, "10"]},
+  {"metric": {"__name__": "istio_requests_total", "destination_app": "httpbin", "response_code": "503"}, "value": [1767225600.5, "2"]},
+  {"metric": {"__name__": "istio_requests_total", "destination_app": "sleep", "response_code": "200"}, "value": [1767225600.5, "1"]}
+]}}`
+
+func TestParseResults(t *testing.T) {
+	th := test.NewTestHelper(t)
+
+	vector := parsePrometheusResponse(th, vectorResponse).Data.Vector
+	timestamp := time.Date(2026, 1, 1, 0, 0, 0, 500000000, time.UTC)
+	if len(vector) != 3 || !vector[0].Timestamp.Equal(timestamp) || vector[1].Value != 2 {
+		t.Errorf("unexpected vector: %v", vector)
+	}
+	if sum := vector.Matching("destination_app=httpbin").Sum(); sum != 12 {
+		t.Errorf("expected the httpbin samples to sum up to 12, got %v", sum)
+	}
+
+	matrix := parsePrometheusResponse(th, `{"status": "success", "data": {"resultType": "matrix", "result": [
+  {"metric": {"pod": "httpbin-1"}, "values": [[1767225600, "3"], [1767225660, "8"]]}]}}`).Data.Matrix
+	if len(matrix) != 1 || len(matrix[0].Points) != 2 || matrix[0].Increase() != 5 {
+		t.Errorf("unexpected matrix: %+v", matrix)
+	}
+
+	scalar := parsePrometheusResponse(th, `{"status": "success", "data": {"resultType": "scalar", "result": [1767225600, "0.25"]}}`).Data.Scalar
+	if scalar == nil || scalar.Value != 0.25 {
+		t.Errorf("unexpected scalar: %+v", scalar)
+	}
+
+	failed := parsePrometheusResponse(th, `{"status": "error", "errorType": "bad_data", "error": "parse error"}`)
+	if failed.Status != "error" || failed.Error != "parse error" {
+		t.Errorf("unexpected error response: %+v", failed)
+	}
+}
+
+func TestVectorChecks(t *testing.T) {
+	th := test.NewTestHelper(t)
+	vector := parsePrometheusResponse(th, vectorResponse).Data.Vector
+	baseline := Vector{{Labels: map[string]string{"destination_app": "httpbin"}, Point: Point{Value: 12}}}
+	cases := []struct {
+		name   string
+		check  VectorCheck
+		passes bool
+	}{
+		{"has series", HasSeries("destination_app=httpbin", "response_code=503"), true},
+		{"missing series", HasSeries("destination_app=details"), false},
+		{"greater than", ValueGreaterThan(11, "destination_app=httpbin"), true},
+		{"not greater than", ValueGreaterThan(12, "destination_app=httpbin"), false},
+		{"equals", ValueEquals(13), true},
+		{"not equal", ValueEquals(1, "response_code=503"), false},
+		{"increased since empty baseline", IncreasedSince(nil, "destination_app=sleep"), true},
+		{"not increased", IncreasedSince(baseline, "destination_app=httpbin"), false},
+	}
+	for _, c := range cases {
+		attempt := retry.Attempt(th, func(t test.TestHelper) {
+			c.check(t, "istio_requests_total", vector)
+		})
+		if passed := !attempt.Failed(); passed != c.passes {
+			t.Errorf("%s: got pass=%v, want %v", c.name, passed, c.passes)
+		}
+	}
+}
+
+func TestThanosWithToken(t *testing.T) {
+	th := test.NewTestHelper(t)
+	fake := shell.NewFakeExecutor()
+	defer shell.SetExecutor(fake)()
+	fake.OnPattern(`get pods -l 'app.kubernetes.io/instance=thanos-querier'`).Return("thanos-querier-0")
+	fake.OnPattern(`curl -sSk -H 'Authorization: Bearer secret' 'https://localhost:9091/api/v1/targets\?state=active'`).Return(`{"status": "success", "data": {"activeTargets": [
+  {"scrapePool": "podMonitor/foo/istio-proxies-monitor/0", "scrapeUrl": "http://10.0.0.2:15020/stats/prometheus", "health": "down", "lastError": "connection refused"},
+  {"scrapePool": "serviceMonitor/istio-system/istiod-monitor/0", "scrapeUrl": "http://10.0.0.1:15014/metrics", "health": "up"}]}}`)
+	fake.OnPattern(`curl .*/api/v1/rules'`).Return(`{"status": "success", "data": {"groups": [
+  {"name": "istio", "rules": [{"name": "IstiodDown", "type": "alerting", "state": "firing", "alerts": [{"state": "firing", "value": "1e+00"}]}]}]}}`)
+	fake.OnPattern(`curl .*/api/v1/query_range\?`).Return(`{"status": "success", "data": {"resultType": "matrix", "result": []}}`)
+
+	thanos := DefaultThanos.WithBaseURL("https://localhost:9091").WithToken("secret")
+	targets := thanos.ActiveTargets(th, "openshift-monitoring")
+	if len(targets) != 2 || targets[0].Up() || !targets[1].Up() {
+		t.Errorf("unexpected targets: %+v", targets)
+	}
+	if up := retry.Attempt(th, func(t test.TestHelper) {
+		thanos.(*prometheus_struct).checkTargetUp(t, "openshift-monitoring", "serviceMonitor/istio-system/istiod-monitor")
+	}); up.Failed() {
+		t.Error("expected the istiod target to be up")
+	}
+	if down := retry.Attempt(th, func(t test.TestHelper) {
+		thanos.(*prometheus_struct).checkTargetUp(t, "openshift-monitoring", "podMonitor/foo/istio-proxies-monitor")
+	}); !down.Failed() {
+		t.Error("expected the proxies target to be down")
+	}
+
+	groups := thanos.Rules(th, "openshift-monitoring")
+	if len(groups) != 1 || groups[0].Rules[0].State != "firing" || len(groups[0].Rules[0].Alerts) != 1 {
+		t.Errorf("unexpected rule groups: %+v", groups)
+	}
+
+	end := time.Date(2026, 1, 1, 0, 10, 0, 0, time.UTC)
+	thanos.QueryRange(th, "openshift-monitoring", "up", end.Add(-10*time.Minute), end, 30*time.Second)
+	commands := fake.Commands()
+	if last := commands[len(commands)-1]; !strings.Contains(last, "query_range?end=1767226200&query=up&start=1767225600&step=30") {
+		t.Errorf("unexpected range query command: %s", last)
+	}
+}
+
+func TestSampleJson(t *testing.T) {
+	// the raw results are still decoded for the tests that inspect them directly
+	var resp PrometheusResponse
+	if err := json.Unmarshal([]byte(vectorResponse), &resp); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"__name__": "istio_requests_total", "destination_app": "sleep", "response_code": "200"}
+	if !reflect.DeepEqual(resp.Data.Result[2].Metric, expected) {
+		t.Errorf("expected metric %v, got %v", expected, resp.Data.Result[2].Metric)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// nearlyExhaustedRatio is the fraction of the max attempts above which a successful retry loop
+// is considered to have nearly failed (the same threshold as the flakiness warning in UntilSuccessWithOptions)
+const nearlyExhaustedRatio = 0.75
+
+// CallSiteSummary aggregates the retry loops executed at a call site across one or more test runs
+type CallSiteSummary struct {
+	CallSite string
+	TestSite string
+	Tests    []string
+
+	Loops int
+	// Exhausted is the number of loops in which all attempts failed
+	Exhausted int
+	// NearlyExhausted is the number of successful loops that needed more than 75% of the max attempts
+	NearlyExhausted int
+	// Retried is the number of loops that needed more than one attempt
+	Retried int
+
+	// MaxAttempts and Delay are the options used by most loops
+	MaxAttempts int
+	Delay       time.Duration
+	// MaxAttemptsUsed is the highest number of attempts needed by a successful loop
+	MaxAttemptsUsed int
+	// MaxSuccessfulWait is the longest time it took a loop to succeed
+	MaxSuccessfulWait time.Duration
+
+	SuggestedMaxAttempts int
+	SuggestedDelay       time.Duration
+}
+
+// Flakiness is the fraction of loops that failed or nearly failed
+func (s CallSiteSummary) Flakiness() float64 {
+	return float64(s.Exhausted+s.NearlyExhausted) / float64(s.Loops)
+}
+
+// RetriedRatio is the fraction of loops that needed more than one attempt
+func (s CallSiteSummary) RetriedRatio() float64 {
+	return float64(s.Retried) / float64(s.Loops)
+}
+
+// HasSuggestion returns true if the suggested options differ from the ones currently used
+func (s CallSiteSummary) HasSuggestion() bool {
+	return s.SuggestedMaxAttempts != s.MaxAttempts || s.SuggestedDelay != s.Delay
+}
+
+// Summarize groups the stats by call site and returns the summaries ordered from the flakiest to the most stable
+func Summarize(stats []Stat) []CallSiteSummary {
+	type key struct{ callSite, testSite string }
+	grouped := map[key][]Stat{}
+	for _, stat := range stats {
+		k := key{stat.CallSite, stat.TestSite}
+		grouped[k] = append(grouped[k], stat)
+	}
+
+	var summaries []CallSiteSummary
+	for k, group := range grouped {
+		summaries = append(summaries, summarize(k.callSite, k.testSite, group))
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.Flakiness() != b.Flakiness() {
+			return a.Flakiness() > b.Flakiness()
+		}
+		if a.RetriedRatio() != b.RetriedRatio() {
+			return a.RetriedRatio() > b.RetriedRatio()
+		}
+		if a.CallSite != b.CallSite {
+			return a.CallSite < b.CallSite
+		}
+		return a.TestSite < b.TestSite
+	})
+	return summaries
+}
+
+func summarize(callSite, testSite string, stats []Stat) CallSiteSummary {
+	s := CallSiteSummary{
+		CallSite: callSite,
+		TestSite: testSite,
+		Loops:    len(stats),
+	}
+	tests := map[string]bool{}
+	maxAttemptsCount := map[int]int{}
+	delayCount := map[time.Duration]int{}
+	var longestExhaustedWait time.Duration
+	for _, stat := range stats {
+		if !tests[stat.Test] {
+			tests[stat.Test] = true
+			s.Tests = append(s.Tests, stat.Test)
+		}
+		maxAttemptsCount[stat.MaxAttempts]++
+		delayCount[seconds(stat.Delay)]++
+		if stat.Attempts > 1 {
+			s.Retried++
+		}
+		if !stat.Succeeded {
+			s.Exhausted++
+			longestExhaustedWait = max(longestExhaustedWait, seconds(stat.TotalWait))
+			continue
+		}
+		if float64(stat.Attempts) > nearlyExhaustedRatio*float64(stat.MaxAttempts) && stat.MaxAttempts > 1 {
+			s.NearlyExhausted++
+		}
+		s.MaxAttemptsUsed = max(s.MaxAttemptsUsed, stat.Attempts)
+		s.MaxSuccessfulWait = max(s.MaxSuccessfulWait, seconds(stat.TotalWait))
+	}
+	sort.Strings(s.Tests)
+	s.MaxAttempts = mostCommon(maxAttemptsCount)
+	s.Delay = mostCommon(delayCount)
+	s.SuggestedDelay, s.SuggestedMaxAttempts = suggest(s, longestExhaustedWait)
+	return s
+}
+
+// suggest returns the retry options that would have allowed every loop to succeed with 50% headroom.
+// If some loops never succeeded, we don't know how long they would have needed, so the suggestion is
+// to at least double the time they waited.
+func suggest(s CallSiteSummary, longestExhaustedWait time.Duration) (time.Duration, int) {
+	delay := s.Delay
+	if s.MaxAttemptsUsed > 20 && s.MaxSuccessfulWait > 0 {
+		// polling this often only puts more load on the cluster; check about 10 times instead
+		delay = max(delay, (s.MaxSuccessfulWait / 10).Round(time.Second))
+	}
+	if delay <= 0 {
+		return delay, s.MaxAttempts
+	}
+
+	needed := s.MaxSuccessfulWait + s.MaxSuccessfulWait/2
+	if s.Exhausted > 0 {
+		needed = max(needed, 2*longestExhaustedWait)
+	}
+	if needed == 0 {
+		return delay, s.MaxAttempts
+	}
+	attempts := int(math.Ceil(float64(needed) / float64(delay)))
+	if s.Exhausted == 0 && s.NearlyExhausted == 0 && float64(attempts) >= float64(s.MaxAttempts)/4 {
+		// the current value works and isn't excessive, so there's no need to change it
+		attempts = s.MaxAttempts
+	}
+	return delay, max(attempts, 2)
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+func mostCommon[T int | time.Duration](counts map[T]int) T {
+	var result T
+	best := 0
+	for value, count := range counts {
+		if count > best || (count == best && value > result) {
+			result, best = value, count
+		}
+	}
+	return result
+}
@@ -14,7 +14,12 @@
 
 package retry
 
-import "time"
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
 
 var defaultOptions = RetryOptions{
 	maxAttempts:          60,
@@ -26,6 +31,13 @@ type RetryOptions struct {
 	maxAttempts          int
 	delayBetweenAttempts time.Duration
 	logAttempts          bool
+
+	timeout        time.Duration
+	attemptTimeout time.Duration
+	backoffFactor  float64
+	maxDelay       time.Duration
+	jitter         float64
+	ctx            context.Context
 }
 
 func Options() RetryOptions {
@@ -46,3 +58,67 @@ func (o RetryOptions) LogAttempts(logAttempts bool) RetryOptions {
 	o.logAttempts = logAttempts
 	return o
 }
+
+// Timeout makes the function retry until the given time has elapsed instead of a fixed number of times.
+// It removes the limit set by MaxAttempts; call MaxAttempts afterwards to limit both the time and the attempts.
+func (o RetryOptions) Timeout(timeout time.Duration) RetryOptions {
+	o.timeout = timeout
+	o.maxAttempts = math.MaxInt
+	return o
+}
+
+// AttemptTimeout limits the duration of each attempt. When it elapses, the attempt's context (see
+// TestHelper.Context) is canceled, which kills any shell command the attempt is executing.
+func (o RetryOptions) AttemptTimeout(timeout time.Duration) RetryOptions {
+	o.attemptTimeout = timeout
+	return o
+}
+
+// ExponentialBackoff multiplies the delay between attempts by the given factor after each attempt,
+// up to maxDelay. The initial delay is the one set by DelayBetweenAttempts.
+func (o RetryOptions) ExponentialBackoff(factor float64, maxDelay time.Duration) RetryOptions {
+	o.backoffFactor = factor
+	o.maxDelay = maxDelay
+	return o
+}
+
+// Jitter randomizes each delay by up to the given fraction (e.g. 0.2 means +/- 20%), so that
+// tests running in parallel don't poll the cluster in lockstep.
+func (o RetryOptions) Jitter(fraction float64) RetryOptions {
+	o.jitter = fraction
+	return o
+}
+
+// Context stops the retries when the given context is canceled. It's also the parent of
+// the context of each attempt (see TestHelper.Context).
+func (o RetryOptions) Context(ctx context.Context) RetryOptions {
+	o.ctx = ctx
+	return o
+}
+
+// effectiveMaxAttempts returns the max attempts or, if the attempts are limited by a Timeout,
+// the approximate number of attempts that fit in the timeout
+func (o RetryOptions) effectiveMaxAttempts() int {
+	if o.maxAttempts != math.MaxInt || o.timeout == 0 {
+		return o.maxAttempts
+	}
+	if o.delayBetweenAttempts <= 0 {
+		return o.maxAttempts
+	}
+	return int(o.timeout / o.delayBetweenAttempts)
+}
+
+// delay returns the delay after the given (zero-based) attempt
+func (o RetryOptions) delay(attempt int) time.Duration {
+	delay := o.delayBetweenAttempts
+	if o.backoffFactor > 1 {
+		delay = time.Duration(float64(delay) * math.Pow(o.backoffFactor, float64(attempt)))
+		if o.maxDelay > 0 && (delay > o.maxDelay || delay < 0) {
+			delay = o.maxDelay
+		}
+	}
+	if o.jitter > 0 {
+		delay += time.Duration((rand.Float64()*2 - 1) * o.jitter * float64(delay))
+	}
+	return delay
+}
@@ -15,6 +15,9 @@
 package retry
 
 import (
+	"context"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/maistra/maistra-test-tool/pkg/util/env"
@@ -26,18 +29,35 @@ func UntilSuccess(t test.TestHelper, f func(t test.TestHelper)) {
 	UntilSuccessWithOptions(t, defaultOptions, f)
 }
 
+// testDeadlineGracePeriod is the time reserved for cleanup before the go test -timeout elapses, because
+// the test binary panics without executing the cleanup functions when that happens
+const testDeadlineGracePeriod = 30 * time.Second
+
 func UntilSuccessWithOptions(t test.TestHelper, options RetryOptions, f func(t test.TestHelper)) {
 	t.T().Helper()
 	start := time.Now()
+	ctx := options.ctx
+	if ctx == nil {
+		ctx = t.Context()
+	}
+	deadline, deadlineReason := options.deadline(t, start)
+	attempts := 0
+	succeeded := false
+	defer func() {
+		recordStat(t, options, attempts, succeeded, start)
+	}()
 	for i := 0; i < options.maxAttempts; i++ {
 		lastAttempt := i == options.maxAttempts-1
+		attempts++
 
 		var attemptHelper test.TestHelper
+		var retryHelper *test.RetryTestHelper
 		if lastAttempt {
 			attemptHelper = t
-			f(attemptHelper)
+			runLastAttempt(ctx, t, options, f)
 		} else {
-			attemptHelper = attemptInternal(t, f, i, options.maxAttempts)
+			retryHelper = attemptInternal(ctx, t, f, i, options.maxAttempts, options.attemptTimeout)
+			attemptHelper = retryHelper
 		}
 
 		if attemptHelper.Failed() {
@@ -45,23 +65,35 @@ func UntilSuccessWithOptions(t test.TestHelper, options RetryOptions, f func(t t
 				if options.logAttempts && env.IsLogFailedRetryAttempts() {
 					t.Logf("Last attempt (%d/%d) failed.", i+1, options.maxAttempts)
 				}
-				t.T().FailNow()
+				t.FailNow()
 			} else {
+				delay := options.delay(i)
+				if err := ctx.Err(); err != nil {
+					retryHelper.FlushLogBuffer()
+					t.Fatalf("Giving up after %s failed, because the retry was canceled: %v", attemptLabel(i, options), err)
+				}
+				if !deadline.IsZero() && time.Now().Add(delay).After(deadline) {
+					retryHelper.FlushLogBuffer()
+					t.Fatalf("Giving up after %s failed, because %s", attemptLabel(i, options), deadlineReason)
+				}
 				if options.logAttempts && env.IsLogFailedRetryAttempts() {
-					if options.delayBetweenAttempts == defaultOptions.delayBetweenAttempts {
-						t.Logf("--- Attempt %d/%d failed. Retrying...", i+1, options.maxAttempts)
+					if delay == defaultOptions.delayBetweenAttempts {
+						t.Logf("--- %s failed. Retrying...", attemptLabel(i, options))
 					} else {
-						t.Logf("--- Attempt %d/%d failed. Retrying in %v...", i+1, options.maxAttempts, options.delayBetweenAttempts)
+						t.Logf("--- %s failed. Retrying in %v...", attemptLabel(i, options), delay.Round(time.Millisecond))
 					}
 				}
-				time.Sleep(options.delayBetweenAttempts)
+				if err := sleep(ctx, delay); err != nil {
+					t.Fatalf("Giving up after %s failed, because the retry was canceled: %v", attemptLabel(i, options), err)
+				}
 			}
 		} else {
+			succeeded = true
 			if env.IsLogFailedRetryAttempts() {
 				if i > 0 && options.logAttempts {
 					// there was at least one failed attempt, so let's log the current attempt as successful so that
 					// the user isn't left wondering
-					t.Logf("--- Attempt %d/%d successful; total time: %.2fs", i+1, options.maxAttempts, time.Now().Sub(start).Seconds())
+					t.Logf("--- %s successful; total time: %.2fs", attemptLabel(i, options), time.Now().Sub(start).Seconds())
 				}
 			} else {
 				// this attempt was successful, so we must flush the log buffer to display the SUCCESS messages
@@ -69,7 +101,7 @@ func UntilSuccessWithOptions(t test.TestHelper, options RetryOptions, f func(t t
 					retryTestHelper.FlushLogBuffer()
 				}
 			}
-			if options.maxAttempts > 1 {
+			if options.maxAttempts > 1 && options.timeout == 0 {
 				percentage := i * 100 / options.maxAttempts
 				if percentage >= 90 {
 					t.Log("WARNING: This test is is almost certainly flaky since it required more than 90% of the maximum retry count to succeed. Consider increasing the maximum retry count to prevent flakiness.")
@@ -82,18 +114,78 @@ func UntilSuccessWithOptions(t test.TestHelper, options RetryOptions, f func(t t
 	}
 }
 
+// runLastAttempt runs the last attempt directly on t, so that its failures fail the test. The attempt
+// only gets its own context if the options require one, so that t isn't wrapped unnecessarily.
+func runLastAttempt(ctx context.Context, t test.TestHelper, options RetryOptions, f func(t test.TestHelper)) {
+	t.T().Helper()
+	if options.ctx == nil && options.attemptTimeout == 0 {
+		f(t)
+		return
+	}
+	if options.attemptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.attemptTimeout)
+		defer cancel()
+	}
+	f(test.WithContext(t, ctx))
+}
+
+// deadline returns the time after which no further attempts are made, and the reason for it
+func (o RetryOptions) deadline(t test.TestHelper, start time.Time) (time.Time, string) {
+	var deadline time.Time
+	var reason string
+	if o.timeout > 0 {
+		deadline = start.Add(o.timeout)
+		reason = fmt.Sprintf("the retry timeout of %v would be exceeded", o.timeout)
+	}
+	if testDeadline, ok := test.Deadline(t); ok {
+		testDeadline = testDeadline.Add(-testDeadlineGracePeriod)
+		if deadline.IsZero() || testDeadline.Before(deadline) {
+			deadline = testDeadline
+			reason = "the go test -timeout is about to elapse"
+		}
+	}
+	return deadline, reason
+}
+
+func attemptLabel(attempt int, options RetryOptions) string {
+	if options.maxAttempts == math.MaxInt {
+		return fmt.Sprintf("Attempt %d", attempt+1)
+	}
+	return fmt.Sprintf("Attempt %d/%d", attempt+1, options.maxAttempts)
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // Attempt runs the given function, captures any errors thrown by the function, and
 // returns a RetryTestHelper, which you can use to:
 // - check if the attempt failed by invoking retryTestHelper.Failed()
 // - print everything that the function logged by invoking retryTestHelper.FlushLogBuffer()
 func Attempt(t test.TestHelper, f func(t test.TestHelper)) *test.RetryTestHelper {
 	t.T().Helper()
-	return attemptInternal(t, f, 0, 1)
+	return attemptInternal(t.Context(), t, f, 0, 1, 0)
 }
 
-func attemptInternal(t test.TestHelper, f func(t test.TestHelper), currentAttempt, maxAttempts int) *test.RetryTestHelper {
+func attemptInternal(ctx context.Context, t test.TestHelper, f func(t test.TestHelper), currentAttempt, maxAttempts int, timeout time.Duration) *test.RetryTestHelper {
 	t.T().Helper()
-	retryTestHelper := test.NewRetryTestHelper(t.T(), t.CurrentStep(), currentAttempt, maxAttempts)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	retryTestHelper := test.NewRetryTestHelper(ctx, t.T(), t.CurrentStep(), currentAttempt, maxAttempts)
 	retryTestHelper.Attempt(f)
+	if retryTestHelper.Failed() && ctx.Err() == context.DeadlineExceeded && timeout > 0 {
+		retryTestHelper.Logf("Attempt timed out after %v", timeout)
+	}
 	return retryTestHelper
 }
@@ -0,0 +1,176 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+func TestUntilSuccessStopsAfterFirstSuccess(t *testing.T) {
+	attempts := 0
+	UntilSuccessWithOptions(test.NewTestHelper(t), fastOptions(5), func(t test.TestHelper) {
+		attempts++
+		if attempts < 3 {
+			t.Fatal("not yet")
+		}
+	})
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, but got %d", attempts)
+	}
+}
+
+func TestUntilSuccessFailsAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	attempt := Attempt(test.NewTestHelper(t), func(t test.TestHelper) {
+		UntilSuccessWithOptions(t, fastOptions(4), func(t test.TestHelper) {
+			attempts++
+			t.Error("always fails")
+		})
+	})
+	if !attempt.Failed() {
+		t.Fatal("expected retry to fail")
+	}
+	if attempts != 4 {
+		t.Fatalf("expected 4 attempts, but got %d", attempts)
+	}
+}
+
+// TestNestedRetryRetriesOuterAttempt checks that an inner retry that runs out of attempts only fails the attempt of the
+// outer retry, which then tries again, instead of failing the whole test
+func TestNestedRetryRetriesOuterAttempt(t *testing.T) {
+	outer, inner := 0, 0
+	UntilSuccessWithOptions(test.NewTestHelper(t), fastOptions(3), func(t test.TestHelper) {
+		outer++
+		UntilSuccessWithOptions(t, fastOptions(2), func(t test.TestHelper) {
+			inner++
+			if outer < 2 {
+				t.Error("not yet")
+			}
+		})
+	})
+	if outer != 2 || inner != 3 {
+		t.Fatalf("expected 2 outer and 3 inner attempts, but got %d and %d", outer, inner)
+	}
+}
+
+func TestWillRetryIsFalseOnLastAttempt(t *testing.T) {
+	var willRetry []bool
+	UntilSuccessWithOptions(test.NewTestHelper(t), fastOptions(3), func(t test.TestHelper) {
+		willRetry = append(willRetry, t.WillRetry())
+		if len(willRetry) < 3 {
+			t.FailNow()
+		}
+	})
+	if len(willRetry) != 3 || !willRetry[0] || !willRetry[1] || willRetry[2] {
+		t.Fatalf("unexpected WillRetry() results: %v", willRetry)
+	}
+}
+
+func TestAttemptRecoversFromFailNow(t *testing.T) {
+	reachedEnd := false
+	attempt := Attempt(test.NewTestHelper(t), func(t test.TestHelper) {
+		t.FailNow()
+		reachedEnd = true
+	})
+	if !attempt.Failed() {
+		t.Fatal("expected attempt to fail")
+	}
+	if reachedEnd {
+		t.Fatal("expected FailNow to stop the attempt")
+	}
+}
+
+func TestTimeoutStopsRetrying(t *testing.T) {
+	attempts := 0
+	start := time.Now()
+	attempt := Attempt(test.NewTestHelper(t), func(t test.TestHelper) {
+		UntilSuccessWithOptions(t, Options().Timeout(100*time.Millisecond).DelayBetweenAttempts(10*time.Millisecond), func(t test.TestHelper) {
+			attempts++
+			t.FailNow()
+		})
+	})
+	if !attempt.Failed() {
+		t.Fatal("expected retry to fail")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected retry to stop after the timeout, but it took %v", elapsed)
+	}
+	if attempts < 2 {
+		t.Fatalf("expected multiple attempts, but got %d", attempts)
+	}
+}
+
+func TestContextCancelsRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	attempt := Attempt(test.NewTestHelper(t), func(t test.TestHelper) {
+		UntilSuccessWithOptions(t, fastOptions(100).Context(ctx), func(t test.TestHelper) {
+			attempts++
+			if attempts == 3 {
+				cancel()
+			}
+			t.FailNow()
+		})
+	})
+	if !attempt.Failed() {
+		t.Fatal("expected retry to fail")
+	}
+	if attempts != 3 {
+		t.Fatalf("expected retry to stop after the context was canceled, but got %d attempts", attempts)
+	}
+}
+
+func TestAttemptTimeoutKillsShellCommand(t *testing.T) {
+	attempts := 0
+	start := time.Now()
+	UntilSuccessWithOptions(test.NewTestHelper(t), fastOptions(3).AttemptTimeout(200*time.Millisecond), func(t test.TestHelper) {
+		attempts++
+		if attempts == 1 {
+			shell.Execute(t, "exec sleep 10")
+		}
+	})
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, but got %d", attempts)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the hung command to be killed, but the retry took %v", elapsed)
+	}
+}
+
+func TestExponentialBackoffWithJitter(t *testing.T) {
+	options := Options().DelayBetweenAttempts(100*time.Millisecond).ExponentialBackoff(2, time.Second)
+	expected := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, e := range expected {
+		if delay := options.delay(i); delay != e*time.Millisecond {
+			t.Errorf("expected delay %v after attempt %d, but got %v", e*time.Millisecond, i+1, delay)
+		}
+	}
+
+	options = options.Jitter(0.5)
+	for i := 0; i < 100; i++ {
+		if delay := options.delay(0); delay < 50*time.Millisecond || delay > 150*time.Millisecond {
+			t.Fatalf("delay %v is outside of the jitter range", delay)
+		}
+	}
+}
+
+func fastOptions(maxAttempts int) RetryOptions {
+	return Options().MaxAttempts(maxAttempts).DelayBetweenAttempts(time.Millisecond)
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/env"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// Stat describes the execution of a single retry loop (see UntilSuccessWithOptions)
+type Stat struct {
+	Time        time.Time `json:"time"`
+	Test        string    `json:"test"`
+	SMCPVersion string    `json:"smcpVersion"`
+	// CallSite is the location of the code that invoked UntilSuccess (e.g. pkg/util/oc/pod_commands.go:120)
+	CallSite string `json:"callSite"`
+	// TestSite is the location in the test file that (directly or indirectly) invoked UntilSuccess
+	TestSite string `json:"testSite,omitempty"`
+	Attempts int    `json:"attempts"`
+	// MaxAttempts is the number of attempts that fit in the Timeout, if the loop is limited by one
+	MaxAttempts int     `json:"maxAttempts"`
+	Delay       float64 `json:"delaySeconds"`
+	TotalWait   float64 `json:"totalWaitSeconds"`
+	Succeeded   bool    `json:"succeeded"`
+}
+
+var statsFileMutex sync.Mutex
+
+// recordStat adds the retry loop to the test report and appends it to the retry stats file. Loops executed
+// outside of tests created with test.NewTest() (e.g. in unit tests of the framework) aren't recorded.
+func recordStat(t test.TestHelper, options RetryOptions, attempts int, succeeded bool, start time.Time) {
+	if !test.RecordRetry(t, attempts, succeeded) {
+		return
+	}
+	callSite, testSite := callSites()
+	stat := Stat{
+		Time:        start,
+		Test:        t.T().Name(),
+		SMCPVersion: env.GetSMCPVersion().String(),
+		CallSite:    callSite,
+		TestSite:    testSite,
+		Attempts:    attempts,
+		MaxAttempts: options.effectiveMaxAttempts(),
+		Delay:       options.delayBetweenAttempts.Seconds(),
+		TotalWait:   time.Since(start).Seconds(),
+		Succeeded:   succeeded,
+	}
+	if err := appendStat(env.GetRetryStatsFile(), stat); err != nil {
+		t.Logf("could not record retry statistics: %v", err)
+	}
+}
+
+func appendStat(file string, stat Stat) error {
+	data, err := json.Marshal(stat)
+	if err != nil {
+		return err
+	}
+	statsFileMutex.Lock()
+	defer statsFileMutex.Unlock()
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// callSites returns the location of the first caller of UntilSuccessWithOptions outside of the retry package
+// and the location of the first caller in a test file. Since this is called from a deferred function, which may
+// be executed while the goroutine exits or panics, the frames above UntilSuccessWithOptions are skipped.
+func callSites() (callSite string, testSite string) {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	inRetryLoop := false
+	for {
+		frame, more := frames.Next()
+		if strings.HasSuffix(frame.Function, "/pkg/util/retry.UntilSuccessWithOptions") {
+			inRetryLoop = true
+		} else if inRetryLoop && callSite == "" && !isRetryPackage(frame) {
+			callSite = relativeLocation(frame)
+		}
+		if inRetryLoop && strings.HasSuffix(frame.File, "_test.go") {
+			testSite = relativeLocation(frame)
+			break
+		}
+		if !more {
+			break
+		}
+	}
+	return
+}
+
+func isRetryPackage(frame runtime.Frame) bool {
+	return strings.Contains(frame.Function, "/pkg/util/retry.") && !strings.HasSuffix(frame.File, "_test.go")
+}
+
+func relativeLocation(frame runtime.Frame) string {
+	file := frame.File
+	if index := strings.LastIndex(file, "/pkg/"); index != -1 {
+		file = file[index+1:]
+	}
+	return fmt.Sprintf("%s:%d", file, frame.Line)
+}
+
+// LoadStats reads the retry statistics from the given JSON lines files
+func LoadStats(files ...string) ([]Stat, error) {
+	var stats []Stat
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(f)
+		line := 0
+		for scanner.Scan() {
+			line++
+			if strings.TrimSpace(scanner.Text()) == "" {
+				continue
+			}
+			var stat Stat
+			if err := json.Unmarshal(scanner.Bytes(), &stat); err != nil {
+				f.Close()
+				return nil, fmt.Errorf("%s:%d: %v", file, line, err)
+			}
+			stats = append(stats, stat)
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
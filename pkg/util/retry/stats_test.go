@@ -0,0 +1,112 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+func TestUntilSuccessRecordsStats(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "retry-stats.jsonl")
+	t.Setenv("RETRY_STATS_FILE", file)
+
+	t.Run("test", func(t *testing.T) {
+		test.NewTest(t).Groups(test.Full).Run(func(t test.TestHelper) {
+			attempts := 0
+			UntilSuccessWithOptions(t, fastOptions(5), func(t test.TestHelper) {
+				attempts++
+				if attempts < 2 {
+					t.FailNow()
+				}
+			})
+		})
+	})
+
+	stats, err := LoadStats(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("expected 1 stat, but got %d", len(stats))
+	}
+	stat := stats[0]
+	if stat.Test != "TestUntilSuccessRecordsStats/test" || stat.Attempts != 2 || stat.MaxAttempts != 5 || !stat.Succeeded {
+		t.Fatalf("unexpected stat: %+v", stat)
+	}
+	if !strings.HasPrefix(stat.CallSite, "pkg/util/retry/stats_test.go:") || stat.TestSite != stat.CallSite {
+		t.Fatalf("unexpected call site: %+v", stat)
+	}
+}
+
+func TestUntilSuccessDoesNotRecordStatsOutsideOfTests(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "retry-stats.jsonl")
+	t.Setenv("RETRY_STATS_FILE", file)
+
+	UntilSuccessWithOptions(test.NewTestHelper(t), fastOptions(1), func(t test.TestHelper) {})
+
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Fatalf("expected no stats file, but got %v", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	stats := []Stat{
+		stat("a.go:1", 1, 10, true),
+		stat("a.go:1", 1, 10, true),
+		stat("b.go:2", 9, 10, true),
+		stat("b.go:2", 10, 10, false),
+		stat("c.go:3", 3, 10, true),
+	}
+
+	summaries := Summarize(stats)
+
+	if len(summaries) != 3 {
+		t.Fatalf("expected 3 summaries, but got %d", len(summaries))
+	}
+	flakiest := summaries[0]
+	if flakiest.CallSite != "b.go:2" || flakiest.Exhausted != 1 || flakiest.NearlyExhausted != 1 || flakiest.Flakiness() != 1 {
+		t.Fatalf("unexpected flakiest call site: %+v", flakiest)
+	}
+	// the failed loop waited 10s, so the suggestion is to wait at least twice as long
+	if flakiest.SuggestedMaxAttempts != 20 || flakiest.SuggestedDelay != time.Second {
+		t.Fatalf("unexpected suggestion: %d x %v", flakiest.SuggestedMaxAttempts, flakiest.SuggestedDelay)
+	}
+	if summaries[1].CallSite != "c.go:3" || summaries[2].CallSite != "a.go:1" {
+		t.Fatalf("unexpected order: %s, %s", summaries[1].CallSite, summaries[2].CallSite)
+	}
+	// a.go:1 never needed more than one attempt, so 10 attempts are excessive
+	if summaries[2].SuggestedMaxAttempts != 2 {
+		t.Fatalf("expected fewer attempts to be suggested, but got %d", summaries[2].SuggestedMaxAttempts)
+	}
+}
+
+func stat(callSite string, attempts, maxAttempts int, succeeded bool) Stat {
+	return Stat{
+		Test:        "TestSomething",
+		CallSite:    callSite,
+		TestSite:    callSite,
+		Attempts:    attempts,
+		MaxAttempts: maxAttempts,
+		Delay:       1,
+		TotalWait:   float64(attempts),
+		Succeeded:   succeeded,
+	}
+}
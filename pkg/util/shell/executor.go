@@ -0,0 +1,96 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shell
+
+import (
+	"context"
+	"sync"
+)
+
+// Executor executes shell commands on behalf of Execute and its variants (and therefore also oc.Invoke).
+// The default executor runs the command with `sh -c`. Unit tests can replace it with a FakeExecutor
+// (see SetExecutor) to exercise the framework without a cluster.
+type Executor interface {
+	// Execute runs the command with the given environment (nil means the current process's environment)
+	// and standard input, and returns the combined stdout and stderr.
+	Execute(cmd string, env []string, input string) (string, error)
+}
+
+// ContextExecutor is an Executor that can abort a command when a context is canceled
+// (e.g. when a retry attempt times out; see retry.RetryOptions.AttemptTimeout)
+type ContextExecutor interface {
+	Executor
+	ExecuteContext(ctx context.Context, cmd string, env []string, input string) (string, error)
+}
+
+// ExecutorFunc is an adapter that allows the use of an ordinary function as an Executor
+type ExecutorFunc func(cmd string, env []string, input string) (string, error)
+
+func (f ExecutorFunc) Execute(cmd string, env []string, input string) (string, error) {
+	return f(cmd, env, input)
+}
+
+// SystemExecutor executes commands in `sh`
+var SystemExecutor Executor = systemExecutor{}
+
+type systemExecutor struct{}
+
+func (e systemExecutor) Execute(cmd string, env []string, input string) (string, error) {
+	return execShellCommand(context.Background(), cmd, env, input)
+}
+
+func (e systemExecutor) ExecuteContext(ctx context.Context, cmd string, env []string, input string) (string, error) {
+	return execShellCommand(ctx, cmd, env, input)
+}
+
+// executeContext executes the command with the given executor, aborting it when the context is canceled
+// if the executor supports it
+func executeContext(ctx context.Context, e Executor, cmd string, env []string, input string) (string, error) {
+	if ce, ok := e.(ContextExecutor); ok {
+		return ce.ExecuteContext(ctx, cmd, env, input)
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return e.Execute(cmd, env, input)
+}
+
+var (
+	executorMu sync.RWMutex
+	executor   = SystemExecutor
+)
+
+// SetExecutor replaces the executor used by all shell functions and returns a function that restores the previous one.
+// It's meant to be used in unit tests:
+//
+//	fake := shell.NewFakeExecutor()
+//	defer shell.SetExecutor(fake)()
+func SetExecutor(e Executor) (restore func()) {
+	executorMu.Lock()
+	defer executorMu.Unlock()
+	previous := executor
+	executor = e
+	return func() {
+		executorMu.Lock()
+		defer executorMu.Unlock()
+		executor = previous
+	}
+}
+
+func currentExecutor() Executor {
+	executorMu.RLock()
+	defer executorMu.RUnlock()
+	return executor
+}
@@ -0,0 +1,256 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shell
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Call is a command executed through an Executor, along with its result
+type Call struct {
+	Command string `json:"command"`
+	Input   string `json:"input,omitempty"`
+	Output  string `json:"output"`
+	Error   string `json:"error,omitempty"`
+}
+
+// FakeExecutor is an Executor that doesn't execute anything, but returns scripted responses keyed by command.
+// All calls are recorded and can be inspected with Calls(). A command without a matching response fails.
+type FakeExecutor struct {
+	mu        sync.Mutex
+	responses []*FakeResponse
+	calls     []Call
+}
+
+var _ Executor = &FakeExecutor{}
+
+func NewFakeExecutor() *FakeExecutor {
+	return &FakeExecutor{}
+}
+
+// FakeResponse is the scripted response to a command. If multiple outputs are configured,
+// they are returned in order, and the last one is repeated for all subsequent calls.
+type FakeResponse struct {
+	description string
+	matches     func(cmd string) bool
+	outputs     []fakeOutput
+	served      int
+	times       int
+}
+
+type fakeOutput struct {
+	output string
+	err    error
+}
+
+// On registers a response for the given command. Commands are compared after collapsing
+// consecutive whitespace, so "oc get  pods" matches "oc get pods".
+func (f *FakeExecutor) On(cmd string) *FakeResponse {
+	normalized := normalizeCommand(cmd)
+	return f.addResponse(&FakeResponse{
+		description: cmd,
+		matches: func(c string) bool {
+			return normalizeCommand(c) == normalized
+		},
+	})
+}
+
+// OnPattern registers a response for all commands matching the regular expression
+func (f *FakeExecutor) OnPattern(pattern string) *FakeResponse {
+	re := regexp.MustCompile(pattern)
+	return f.addResponse(&FakeResponse{
+		description: pattern,
+		matches:     re.MatchString,
+	})
+}
+
+func (f *FakeExecutor) addResponse(r *FakeResponse) *FakeResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses = append(f.responses, r)
+	return r
+}
+
+// Return adds a successful output to the response
+func (r *FakeResponse) Return(output string) *FakeResponse {
+	r.outputs = append(r.outputs, fakeOutput{output: output})
+	return r
+}
+
+// Fail adds a failed output to the response (as if the command exited with a non-zero exit code)
+func (r *FakeResponse) Fail(output string) *FakeResponse {
+	r.outputs = append(r.outputs, fakeOutput{output: output, err: errors.New("exit status 1")})
+	return r
+}
+
+// FailWithExitCode adds a failed output with the given exit code to the response
+func (r *FakeResponse) FailWithExitCode(exitCode int, output string) *FakeResponse {
+	r.outputs = append(r.outputs, fakeOutput{output: output, err: fmt.Errorf("exit status %d", exitCode)})
+	return r
+}
+
+// Times limits the number of times the response is used. Afterwards, the next matching response is used.
+func (r *FakeResponse) Times(n int) *FakeResponse {
+	r.times = n
+	return r
+}
+
+func (r *FakeResponse) exhausted() bool {
+	return r.times > 0 && r.served >= r.times
+}
+
+func (r *FakeResponse) next() fakeOutput {
+	if len(r.outputs) == 0 {
+		r.served++
+		return fakeOutput{}
+	}
+	i := r.served
+	if i >= len(r.outputs) {
+		i = len(r.outputs) - 1
+	}
+	r.served++
+	return r.outputs[i]
+}
+
+func (f *FakeExecutor) Execute(cmd string, env []string, input string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result := fakeOutput{err: fmt.Errorf("no fake response registered for command: %s", cmd)}
+	for _, r := range f.responses {
+		if !r.exhausted() && r.matches(cmd) {
+			result = r.next()
+			break
+		}
+	}
+
+	call := Call{Command: cmd, Input: input, Output: result.output}
+	if result.err != nil {
+		call.Error = result.err.Error()
+	}
+	f.calls = append(f.calls, call)
+	return result.output, result.err
+}
+
+// ExecuteContext fails if the context is already done, since fake commands complete immediately
+func (f *FakeExecutor) ExecuteContext(ctx context.Context, cmd string, env []string, input string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("command aborted: %w", context.Cause(ctx))
+	}
+	return f.Execute(cmd, env, input)
+}
+
+// Calls returns all the commands executed so far
+func (f *FakeExecutor) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Commands returns the command lines of all the calls executed so far
+func (f *FakeExecutor) Commands() []string {
+	var commands []string
+	for _, call := range f.Calls() {
+		commands = append(commands, call.Command)
+	}
+	return commands
+}
+
+// Recorder is an Executor that delegates to another Executor and records all calls,
+// so that they can be saved and later replayed with LoadFakeExecutor.
+type Recorder struct {
+	delegate Executor
+	mu       sync.Mutex
+	calls    []Call
+}
+
+var _ Executor = &Recorder{}
+
+func NewRecorder(delegate Executor) *Recorder {
+	return &Recorder{delegate: delegate}
+}
+
+func (r *Recorder) Execute(cmd string, env []string, input string) (string, error) {
+	return r.ExecuteContext(context.Background(), cmd, env, input)
+}
+
+func (r *Recorder) ExecuteContext(ctx context.Context, cmd string, env []string, input string) (string, error) {
+	output, err := executeContext(ctx, r.delegate, cmd, env, input)
+	call := Call{Command: cmd, Input: input, Output: output}
+	if err != nil {
+		call.Error = err.Error()
+	}
+	r.mu.Lock()
+	r.calls = append(r.calls, call)
+	r.mu.Unlock()
+	return output, err
+}
+
+// Calls returns all the calls recorded so far
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// Save writes the recorded calls to the specified file as JSON
+func (r *Recorder) Save(file string) error {
+	data, err := json.MarshalIndent(r.Calls(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0644)
+}
+
+// LoadFakeExecutor creates a FakeExecutor that replays the calls saved by Recorder.Save.
+// The outputs of a command that was executed multiple times are returned in the recorded order.
+func LoadFakeExecutor(file string) (*FakeExecutor, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var calls []Call
+	if err := json.Unmarshal(data, &calls); err != nil {
+		return nil, fmt.Errorf("could not parse recording %s: %v", file, err)
+	}
+
+	f := NewFakeExecutor()
+	responses := map[string]*FakeResponse{}
+	for _, call := range calls {
+		key := normalizeCommand(call.Command)
+		r, found := responses[key]
+		if !found {
+			r = f.On(call.Command)
+			responses[key] = r
+		}
+		o := fakeOutput{output: call.Output}
+		if call.Error != "" {
+			o.err = errors.New(call.Error)
+		}
+		r.outputs = append(r.outputs, o)
+	}
+	return f, nil
+}
+
+func normalizeCommand(cmd string) string {
+	return strings.Join(strings.Fields(cmd), " ")
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shell
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+func TestFakeExecutorReturnsOutputsInOrder(t *testing.T) {
+	fake := NewFakeExecutor()
+	fake.On("oc get pods").Return("first").Return("second")
+
+	assertOutput(t, fake, "oc get pods", "first")
+	assertOutput(t, fake, "oc  get   pods", "second")
+	assertOutput(t, fake, "oc get pods", "second")
+
+	if len(fake.Calls()) != 3 {
+		t.Fatalf("expected 3 calls, but got %d", len(fake.Calls()))
+	}
+}
+
+func TestFakeExecutorPatternAndTimes(t *testing.T) {
+	fake := NewFakeExecutor()
+	fake.OnPattern(`^oc wait .*`).Fail("timed out").Times(1)
+	fake.OnPattern(`^oc wait .*`).Return("condition met")
+
+	if _, err := fake.Execute("oc wait pod/foo --for condition=Ready", nil, ""); err == nil {
+		t.Fatal("expected first call to fail")
+	}
+	assertOutput(t, fake, "oc wait pod/foo --for condition=Ready", "condition met")
+}
+
+func TestFakeExecutorFailsUnknownCommand(t *testing.T) {
+	fake := NewFakeExecutor()
+	if _, err := fake.Execute("rm -rf /", nil, ""); err == nil {
+		t.Fatal("expected unknown command to fail")
+	}
+	if calls := fake.Commands(); len(calls) != 1 || calls[0] != "rm -rf /" {
+		t.Fatalf("expected the unknown command to be recorded, but got %v", calls)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	outputs := []string{"1", "2"}
+	recorder := NewRecorder(ExecutorFunc(func(cmd string, env []string, input string) (string, error) {
+		if cmd == "false" {
+			return "", errors.New("exit status 1")
+		}
+		output := outputs[0]
+		outputs = outputs[1:]
+		return output, nil
+	}))
+	_, _ = recorder.Execute("counter", nil, "")
+	_, _ = recorder.Execute("false", nil, "")
+	_, _ = recorder.Execute("counter", nil, "")
+
+	file := filepath.Join(t.TempDir(), "recording.json")
+	if err := recorder.Save(file); err != nil {
+		t.Fatal(err)
+	}
+	fake, err := LoadFakeExecutor(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertOutput(t, fake, "counter", "1")
+	if _, err := fake.Execute("false", nil, ""); err == nil || err.Error() != "exit status 1" {
+		t.Fatalf("expected recorded error, but got %v", err)
+	}
+	assertOutput(t, fake, "counter", "2")
+}
+
+func TestExecuteUsesExecutor(t *testing.T) {
+	fake := NewFakeExecutor()
+	fake.On("oc version").Return("Server Version: 4.16.0")
+	defer SetExecutor(fake)()
+
+	output := Execute(test.NewTestHelper(t), "oc version")
+	if output != "Server Version: 4.16.0" {
+		t.Fatalf("unexpected output: %q", output)
+	}
+}
+
+func assertOutput(t *testing.T, e Executor, cmd string, expected string) {
+	t.Helper()
+	output, err := e.Execute(cmd, nil, "")
+	if err != nil {
+		t.Fatalf("command %q failed: %v", cmd, err)
+	}
+	if output != expected {
+		t.Fatalf("expected output %q for command %q, but got %q", expected, cmd, output)
+	}
+}
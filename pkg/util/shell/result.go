@@ -0,0 +1,147 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/check/common"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// Result is the outcome of a command executed with Run
+type Result struct {
+	Command string
+	Stdout  string
+	Stderr  string
+	// Output is the interleaved stdout and stderr, as returned by Execute
+	Output string
+	// ExitCode is the exit code of the command, or -1 if the command couldn't be started or was killed
+	ExitCode int
+	// Err is set when the command didn't exit with exit code 0
+	Err error
+}
+
+// Succeeded returns true if the command exited with exit code 0
+func (r Result) Succeeded() bool {
+	return r.Err == nil
+}
+
+// ResultExecutor is an Executor that captures stdout and stderr separately. Run falls back to
+// ExecuteContext for executors that don't implement it, in which case the whole output is
+// reported as stdout.
+type ResultExecutor interface {
+	ContextExecutor
+	Run(ctx context.Context, cmd string, env []string, input string) Result
+}
+
+func (e systemExecutor) Run(ctx context.Context, cmd string, env []string, input string) Result {
+	return runShellCommand(ctx, cmd, env, input)
+}
+
+func run(ctx context.Context, e Executor, cmd string, env []string, input string) Result {
+	if re, ok := e.(ResultExecutor); ok {
+		return re.Run(ctx, cmd, env, input)
+	}
+	output, err := executeContext(ctx, e, cmd, env, input)
+	return Result{Command: cmd, Stdout: output, Output: output, ExitCode: exitCode(err), Err: err}
+}
+
+// exitCode returns the exit code of the process that returned the error. Errors that didn't come from
+// exec (e.g. from a FakeExecutor or a recording) are parsed from their "exit status N" message.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	var code int
+	if _, scanErr := fmt.Sscanf(err.Error(), "exit status %d", &code); scanErr == nil {
+		return code
+	}
+	return -1
+}
+
+// ResultCheckFunc is a check run on the Result of a command executed with Run
+type ResultCheckFunc func(t test.TestHelper, r Result)
+
+// Run executes the command and returns its Result. Unlike Execute, it doesn't fail the test when the
+// command exits with a non-zero exit code, so the exit code and stderr can be checked by the caller:
+//
+//	shell.Run(t, "oc get pod foo", shell.ExitCode(1), shell.Stderr(assert.OutputContains("NotFound", "...", "...")))
+func Run(t test.TestHelper, cmd string, checks ...ResultCheckFunc) Result {
+	t.T().Helper()
+	return RunWithEnvAndInput(t, nil, cmd, "", checks...)
+}
+
+// RunWithTimeout executes the command like Run, but kills it if it doesn't complete within the given timeout
+func RunWithTimeout(t test.TestHelper, timeout time.Duration, cmd string, checks ...ResultCheckFunc) Result {
+	t.T().Helper()
+	t, cancel := WithTimeout(t, timeout)
+	defer cancel()
+	return Run(t, cmd, checks...)
+}
+
+func RunWithEnvAndInput(t test.TestHelper, env []string, cmd string, input string, checks ...ResultCheckFunc) Result {
+	t.T().Helper()
+	result := run(t.Context(), currentExecutor(), cmd, env, input)
+	for _, check := range checks {
+		check(t, result)
+	}
+	return result
+}
+
+// ExitCode checks that the command exited with the given exit code
+func ExitCode(expected int) ResultCheckFunc {
+	return func(t test.TestHelper, r Result) {
+		t.T().Helper()
+		if r.ExitCode == expected {
+			t.LogSuccess(fmt.Sprintf("command exited with exit code %d", expected))
+			return
+		}
+		t.Fatalf("expected command %q to exit with exit code %d, but got %d\n%serror: %v", r.Command, expected, r.ExitCode, appendNewLine(r.Output), r.Err)
+	}
+}
+
+// Succeeds checks that the command exited with exit code 0
+func Succeeds() ResultCheckFunc {
+	return ExitCode(0)
+}
+
+// Stdout runs the given output checks on the command's stdout
+func Stdout(checks ...common.CheckFunc) ResultCheckFunc {
+	return func(t test.TestHelper, r Result) {
+		t.T().Helper()
+		for _, check := range checks {
+			check(t, r.Stdout)
+		}
+	}
+}
+
+// Stderr runs the given output checks on the command's stderr
+func Stderr(checks ...common.CheckFunc) ResultCheckFunc {
+	return func(t test.TestHelper, r Result) {
+		t.T().Helper()
+		for _, check := range checks {
+			check(t, r.Stderr)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shell
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+func TestRunCapturesStdoutStderrAndExitCode(t *testing.T) {
+	th := test.NewTestHelper(t)
+	// the streams are read concurrently, so only writes that don't happen at the same time keep their order
+	result := Run(th, "echo out; sleep 0.1; echo err >&2; exit 3", ExitCode(3))
+
+	if result.Stdout != "out\n" {
+		t.Errorf("unexpected stdout: %q", result.Stdout)
+	}
+	if result.Stderr != "err\n" {
+		t.Errorf("unexpected stderr: %q", result.Stderr)
+	}
+	if result.Output != "out\nerr\n" {
+		t.Errorf("unexpected combined output: %q", result.Output)
+	}
+	if result.Succeeded() {
+		t.Error("expected the command to fail")
+	}
+}
+
+func TestRunWithTimeoutKillsProcessGroup(t *testing.T) {
+	th := test.NewTestHelper(t)
+	start := time.Now()
+	result := RunWithTimeout(th, 200*time.Millisecond, "sleep 10 | cat")
+
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Fatalf("expected the command to be killed, but it ran for %v", elapsed)
+	}
+	if result.ExitCode != -1 {
+		t.Errorf("expected exit code -1, but got %d", result.ExitCode)
+	}
+	if result.Err == nil || !strings.Contains(result.Err.Error(), "command timed out after 200ms") {
+		t.Errorf("unexpected error: %v", result.Err)
+	}
+}
+
+func TestExecuteContextAbortsCommand(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	th := test.NewTestHelper(t)
+	t2, stop := withMergedContext(th, ctx)
+	defer stop()
+
+	if _, err := executeContext(t2.Context(), SystemExecutor, "sleep 10", nil, ""); err == nil {
+		t.Fatal("expected the command to be aborted")
+	}
+}
+
+func TestRunWithFakeExecutor(t *testing.T) {
+	fake := NewFakeExecutor()
+	fake.On("oc get pod foo").FailWithExitCode(1, `Error from server (NotFound): pods "foo" not found`)
+	defer SetExecutor(fake)()
+
+	result := Run(test.NewTestHelper(t), "oc get pod foo", ExitCode(1))
+	if !strings.Contains(result.Stdout, "NotFound") {
+		t.Fatalf("expected the fake output to be reported as stdout, but got %q", result.Stdout)
+	}
+}
@@ -15,10 +15,16 @@
 package shell
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/maistra/maistra-test-tool/pkg/util/check/common"
 	"github.com/maistra/maistra-test-tool/pkg/util/test"
@@ -44,9 +50,27 @@ func ExecuteWithEnv(t test.TestHelper, env []string, cmd string, checks ...commo
 	return ExecuteWithEnvAndInput(t, env, cmd, "", checks...)
 }
 
+// ExecuteWithTimeout executes the command like Execute, but kills it (and all the processes it started)
+// if it doesn't complete within the given timeout, and fails the test
+func ExecuteWithTimeout(t test.TestHelper, timeout time.Duration, cmd string, checks ...common.CheckFunc) string {
+	t.T().Helper()
+	t, cancel := WithTimeout(t, timeout)
+	defer cancel()
+	return Execute(t, cmd, checks...)
+}
+
+// ExecuteContext executes the command like Execute, but kills it when the context is canceled
+// (in addition to when the context of the test helper is canceled)
+func ExecuteContext(t test.TestHelper, ctx context.Context, cmd string, checks ...common.CheckFunc) string {
+	t.T().Helper()
+	t, cancel := withMergedContext(t, ctx)
+	defer cancel()
+	return Execute(t, cmd, checks...)
+}
+
 func ExecuteWithEnvAndInput(t test.TestHelper, env []string, cmd string, input string, checks ...common.CheckFunc) string {
 	t.T().Helper()
-	output, err := execShellCommand(cmd, env, input)
+	output, err := executeContext(t.Context(), currentExecutor(), cmd, env, input)
 	if err != nil {
 		t.Fatalf("Command failed: %s\n%serror: %s", cmd, appendNewLine(output), err)
 	}
@@ -56,6 +80,24 @@ func ExecuteWithEnvAndInput(t test.TestHelper, env []string, cmd string, input s
 	return output
 }
 
+// WithTimeout returns a TestHelper whose context is canceled after the given timeout. All commands executed
+// with the returned helper are killed when the timeout expires. The cancel function must always be called.
+func WithTimeout(t test.TestHelper, timeout time.Duration) (test.TestHelper, context.CancelFunc) {
+	ctx, cancel := context.WithTimeoutCause(t.Context(), timeout, fmt.Errorf("command timed out after %v", timeout))
+	return test.WithContext(t, ctx), cancel
+}
+
+func withMergedContext(t test.TestHelper, ctx context.Context) (test.TestHelper, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(t.Context(), func() {
+		cancel(context.Cause(t.Context()))
+	})
+	return test.WithContext(t, ctx), func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
+
 func appendNewLine(str string) string {
 	if str == "" {
 		return ""
@@ -66,15 +108,77 @@ func appendNewLine(str string) string {
 	return str + "\n"
 }
 
-func execShellCommand(command string, env []string, input string) (string, error) {
-	cmd := exec.Command("sh", "-c", command)
+// waitDelay is how long we wait for the output pipes to be closed after the command is killed
+// because its context was canceled (processes started by the command may keep them open)
+const waitDelay = 5 * time.Second
+
+func execShellCommand(ctx context.Context, command string, env []string, input string) (string, error) {
+	result := runShellCommand(ctx, command, env, input)
+	return result.Output, result.Err
+}
+
+func runShellCommand(ctx context.Context, command string, env []string, input string) Result {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
 	cmd.Env = env
+	// run the command in its own process group, so that we can kill the processes it starts (e.g. a hung
+	// oc exec in a pipeline) and not just the shell
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = waitDelay
 	if input != "" {
 		cmd.Stdin = strings.NewReader(input)
 	}
 
-	bytes, err := cmd.CombinedOutput()
-	return string(bytes), err
+	var stdout, stderr bytes.Buffer
+	combined := &combinedWriter{}
+	cmd.Stdout = combined.tee(&stdout)
+	cmd.Stderr = combined.tee(&stderr)
+
+	err := cmd.Run()
+	if err != nil && ctx.Err() != nil {
+		err = fmt.Errorf("command aborted: %w (%v)", context.Cause(ctx), err)
+	}
+	return Result{
+		Command:  command,
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		Output:   combined.String(),
+		ExitCode: exitCode(err),
+		Err:      err,
+	}
+}
+
+// combinedWriter collects the interleaved stdout and stderr, which are copied by separate goroutines.
+// Each stream writes to its own buffer and the combined buffer under the same lock, so that the
+// combined output contains the chunks of both streams in the order in which they were read.
+type combinedWriter struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// tee returns the writer of a stream, which writes to both the stream buffer and the combined buffer
+func (c *combinedWriter) tee(stream *bytes.Buffer) io.Writer {
+	return &streamWriter{combined: c, stream: stream}
+}
+
+func (c *combinedWriter) String() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf.String()
+}
+
+type streamWriter struct {
+	combined *combinedWriter
+	stream   *bytes.Buffer
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.combined.mu.Lock()
+	defer w.combined.mu.Unlock()
+	w.stream.Write(p)
+	return w.combined.buf.Write(p)
 }
 
 func CreateTempDir(t test.TestHelper, namePrefix string) string {
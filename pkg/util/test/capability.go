@@ -0,0 +1,90 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Capability is a feature of the cluster or the test environment that a test requires
+// (see TopLevelTest.RequiresCapability). The capabilities and the probes that detect them
+// are defined in the capability package.
+type Capability string
+
+// CapabilityProbe detects whether a capability is available. If it isn't, the probe returns the reason,
+// which is used as the skip reason of the tests that require the capability.
+type CapabilityProbe func(t TestHelper) (available bool, reason string)
+
+// CapabilityStatus is the result of a CapabilityProbe
+type CapabilityStatus struct {
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type capabilityEntry struct {
+	probe  CapabilityProbe
+	once   sync.Once
+	status CapabilityStatus
+}
+
+var (
+	capabilitiesMu sync.Mutex
+	capabilities   = map[Capability]*capabilityEntry{}
+)
+
+// RegisterCapability registers the probe that detects the capability
+func RegisterCapability(c Capability, probe CapabilityProbe) {
+	capabilitiesMu.Lock()
+	defer capabilitiesMu.Unlock()
+	capabilities[c] = &capabilityEntry{probe: probe}
+}
+
+// ProbeCapability returns the status of the capability. The probe is executed only once per test
+// package, when the first test that requires the capability runs; subsequent calls return the cached result.
+func ProbeCapability(c Capability) CapabilityStatus {
+	capabilitiesMu.Lock()
+	entry := capabilities[c]
+	capabilitiesMu.Unlock()
+	if entry == nil {
+		return CapabilityStatus{Reason: fmt.Sprintf("no probe is registered for capability %s (is the capability package imported?)", c)}
+	}
+	entry.once.Do(func() {
+		entry.status = runProbe(c, entry.probe)
+		report.setCapability(c, entry.status)
+	})
+	return entry.status
+}
+
+// runProbe executes the probe outside of any test, so that a failing probe doesn't fail the test that
+// requires the capability, but marks the capability as unavailable
+func runProbe(c Capability, probe CapabilityProbe) (status CapabilityStatus) {
+	t := NewSetupTestHelper().(*setupTestHelper)
+	defer func() {
+		if r := recover(); r != nil {
+			status = CapabilityStatus{Reason: fmt.Sprintf("the capability probe failed: %v", r)}
+		}
+		if t.cleanup != nil {
+			t.cleanup()
+		}
+		if status.Available {
+			t.Logf("Capability %s: available", c)
+		} else {
+			t.Logf("Capability %s: not available (%s)", c, status.Reason)
+		}
+	}()
+	available, reason := probe(t)
+	return CapabilityStatus{Available: available, Reason: reason}
+}
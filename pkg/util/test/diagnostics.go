@@ -0,0 +1,137 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/env"
+)
+
+// DiagnosticsCollector writes diagnostics about a failed test (or subtest) with Diagnostics.WriteFile.
+// Collectors are executed before the cleanup functions of the test, while the cluster is still in the
+// state in which the test failed. They must not fail the test, so they should ignore command failures
+// and record them in the index instead.
+type DiagnosticsCollector func(t TestHelper, d *Diagnostics)
+
+// Diagnostics is the directory into which the diagnostics of a failed test are collected.
+// The files are listed in the index.json file in the directory.
+type Diagnostics struct {
+	Dir   string    `json:"-"`
+	Test  string    `json:"test"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Files are the collected files, in the order in which they were written
+	Files []DiagnosticsFile `json:"files"`
+
+	mu sync.Mutex
+}
+
+// DiagnosticsFile is a file written by a DiagnosticsCollector
+type DiagnosticsFile struct {
+	// Path is the path of the file, relative to the diagnostics directory
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	// Error is the reason why the file is incomplete or empty (e.g. the command that produced it failed)
+	Error string `json:"error,omitempty"`
+}
+
+var (
+	collectorsMu sync.Mutex
+	collectors   []DiagnosticsCollector
+)
+
+// RegisterDiagnosticsCollector registers a collector that is executed when a test or subtest fails,
+// unless DIAGNOSTICS=false. Like hooks, collectors are registered by the packages that can access
+// the cluster (see oc.collectDiagnostics).
+func RegisterDiagnosticsCollector(collector DiagnosticsCollector) {
+	collectorsMu.Lock()
+	defer collectorsMu.Unlock()
+	collectors = append(collectors, collector)
+}
+
+// WriteFile writes the content into the file at the given path relative to the diagnostics directory and
+// adds it to the index. If err isn't nil, it's recorded in the index as the reason why the content is incomplete.
+func (d *Diagnostics) WriteFile(path string, description string, content string, err error) {
+	file := DiagnosticsFile{Path: path, Description: description}
+	if err != nil {
+		file.Error = err.Error()
+	}
+	fullPath := filepath.Join(d.Dir, path)
+	if mkdirErr := os.MkdirAll(filepath.Dir(fullPath), 0o755); mkdirErr != nil {
+		file.Error = mkdirErr.Error()
+	} else if writeErr := os.WriteFile(fullPath, []byte(content), 0o644); writeErr != nil {
+		file.Error = writeErr.Error()
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.Files = append(d.Files, file)
+}
+
+// captureDiagnostics runs the registered collectors and returns the directory containing their output,
+// or an empty string if no collector is registered or the index can't be written
+func captureDiagnostics(t TestHelper, start time.Time) string {
+	t.T().Helper()
+	collectorsMu.Lock()
+	registered := append([]DiagnosticsCollector(nil), collectors...)
+	collectorsMu.Unlock()
+	if len(registered) == 0 {
+		return ""
+	}
+
+	d := &Diagnostics{
+		Dir: fmt.Sprintf("%s/failures/%s-%s",
+			env.GetOutputDir(),
+			time.Now().Format("20060102150405"),
+			strings.ReplaceAll(t.T().Name(), "/", "-")),
+		Test:  t.T().Name(),
+		Start: start,
+	}
+	t.T().Logf("Collecting diagnostics into %s", d.Dir)
+	for _, collect := range registered {
+		runCollector(t, collect, d)
+	}
+	d.End = time.Now()
+
+	index, err := json.MarshalIndent(d, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(d.Dir, 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(filepath.Join(d.Dir, "index.json"), index, 0o644)
+	}
+	if err != nil {
+		t.T().Logf("failed to write diagnostics index: %v", err)
+		return ""
+	}
+	return d.Dir
+}
+
+// runCollector runs the collector, logging any panic so that the remaining collectors are still executed
+func runCollector(t TestHelper, collect DiagnosticsCollector, d *Diagnostics) {
+	t.T().Helper()
+	defer func() {
+		if err := recover(); err != nil {
+			t.T().Logf("diagnostics collector panicked: %v", err)
+		}
+	}()
+	collect(t, d)
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCaptureDiagnosticsWritesIndex(t *testing.T) {
+	outputDir := t.TempDir()
+	t.Setenv("OUTPUT_DIR", outputDir)
+	previous := collectors
+	collectors = nil
+	t.Cleanup(func() {
+		collectors = previous
+	})
+	RegisterDiagnosticsCollector(func(t TestHelper, d *Diagnostics) {
+		d.WriteFile("namespaces/foo/events.txt", "events in namespace foo", "event", nil)
+		d.WriteFile("proxies/foo/pod/clusters.txt", "clusters", "", errors.New("no istio-proxy container"))
+	})
+	RegisterDiagnosticsCollector(func(t TestHelper, d *Diagnostics) {
+		panic("collector bug")
+	})
+
+	start := time.Now()
+	dir := captureDiagnostics(NewTestHelper(t), start)
+	if !strings.HasPrefix(dir, outputDir+"/failures/") || !strings.HasSuffix(dir, "-TestCaptureDiagnosticsWritesIndex") {
+		t.Fatalf("unexpected diagnostics dir %q", dir)
+	}
+	if content, err := os.ReadFile(filepath.Join(dir, "namespaces/foo/events.txt")); err != nil || string(content) != "event" {
+		t.Errorf("expected the collected file to be written, got %q (%v)", content, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var index Diagnostics
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatal(err)
+	}
+	if index.Test != t.Name() || !index.Start.Equal(start) || len(index.Files) != 2 {
+		t.Fatalf("unexpected index: %s", data)
+	}
+	if index.Files[0].Error != "" || index.Files[1].Error != "no istio-proxy container" {
+		t.Errorf("unexpected files in index: %+v", index.Files)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package discovery extracts the test.Metadata of all tests from their sources, without compiling or
+// running them. It understands test declarations of the form
+//
+//	NewTest(t).Id("T1").Groups(Full, ARM).MinVersion(version.SMCP_2_4).RequiresCapability(capability.IPv6).Run(...)
+//
+// and collects the subtests created with t.NewSubTest("name") inside the test function.
+package discovery
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+	"github.com/maistra/maistra-test-tool/pkg/util/version"
+)
+
+// Discover returns the metadata of all tests declared with test.NewTest in the _test.go files under dir.
+// Package and File are relative to root. The result is sorted by package and test name.
+func Discover(root, dir string) ([]test.Metadata, error) {
+	var tests []test.Metadata
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		fileTests, err := discoverFile(path, filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		tests = append(tests, fileTests...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(tests, func(i, j int) bool {
+		if tests[i].Package != tests[j].Package {
+			return tests[i].Package < tests[j].Package
+		}
+		return tests[i].Name < tests[j].Name
+	})
+	return tests, nil
+}
+
+func discoverFile(path, rel string) ([]test.Metadata, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var tests []test.Metadata
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Body == nil || !strings.HasPrefix(fn.Name.Name, "Test") || fn.Name.Name == "TestMain" {
+			continue
+		}
+		meta, found, err := parseTestFunc(fset, fn)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", fset.Position(fn.Pos()), err)
+		}
+		if !found {
+			continue
+		}
+		meta.Package = filepath.ToSlash(filepath.Dir(rel))
+		meta.File = rel
+		tests = append(tests, meta)
+	}
+	return tests, nil
+}
+
+// parseTestFunc looks for the NewTest(t)...Run() chain in the test function
+func parseTestFunc(fset *token.FileSet, fn *ast.FuncDecl) (test.Metadata, bool, error) {
+	meta := test.Metadata{Name: fn.Name.Name}
+	found := false
+	var parseErr error
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || parseErr != nil {
+			return parseErr == nil
+		}
+		if !found && isMethodCall(call, "Run") {
+			if chain, ok := testChain(call); ok {
+				found = true
+				parseErr = applyChain(&meta, chain)
+			}
+		}
+		if isMethodCall(call, "NewSubTest") && len(call.Args) == 1 {
+			meta.Subtests = append(meta.Subtests, subtestName(fset, call.Args[0]))
+		}
+		return true
+	})
+	return meta, found, parseErr
+}
+
+// testChain returns the method calls between NewTest(t) and Run(), in the order they were called
+func testChain(run *ast.CallExpr) ([]*ast.CallExpr, bool) {
+	var chain []*ast.CallExpr
+	expr := run.Fun.(*ast.SelectorExpr).X
+	for {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return nil, false
+		}
+		if identName(call.Fun) == "NewTest" {
+			break
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil, false
+		}
+		chain = append([]*ast.CallExpr{call}, chain...)
+		expr = sel.X
+	}
+	return chain, true
+}
+
+func applyChain(meta *test.Metadata, chain []*ast.CallExpr) error {
+	for _, call := range chain {
+		method := call.Fun.(*ast.SelectorExpr).Sel.Name
+		switch method {
+		case "Id":
+			id, err := stringArg(call)
+			if err != nil {
+				return err
+			}
+			meta.Id = id
+		case "Groups":
+			meta.Groups = nil
+			for _, arg := range call.Args {
+				name := identName(arg)
+				group, ok := test.TestGroupsByName[name]
+				if !ok {
+					return fmt.Errorf("unknown test group %q", name)
+				}
+				meta.Groups = append(meta.Groups, group)
+			}
+		case "MinVersion", "MaxVersion", "MinOperatorVersion", "MinOCPVersion":
+			if len(call.Args) != 1 {
+				return fmt.Errorf("%s() expects a single argument", method)
+			}
+			v, err := versionArg(call.Args[0])
+			if err != nil {
+				return err
+			}
+			switch method {
+			case "MinVersion":
+				meta.MinVersion = &v
+			case "MaxVersion":
+				meta.MaxVersion = &v
+			case "MinOperatorVersion":
+				meta.MinOperatorVersion = &v
+			case "MinOCPVersion":
+				meta.MinOCPVersion = &v
+			}
+		case "RequiresCapability":
+			for _, arg := range call.Args {
+				// the capability constants are named after their values
+				name := identName(arg)
+				if lit, ok := arg.(*ast.BasicLit); ok {
+					name, _ = strconv.Unquote(lit.Value)
+				}
+				meta.Capabilities = append(meta.Capabilities, test.Capability(name))
+			}
+		default:
+			return fmt.Errorf("unsupported method %s() in test declaration", method)
+		}
+	}
+	return nil
+}
+
+// versionArg evaluates version constants like version.SMCP_2_4 or OCP_4_12
+// and calls like version.ParseVersion("2.6.2")
+func versionArg(expr ast.Expr) (version.Version, error) {
+	if call, ok := expr.(*ast.CallExpr); ok && identName(call.Fun) == "ParseVersion" {
+		str, err := stringArg(call)
+		if err != nil {
+			return version.Version{}, err
+		}
+		return version.Parse(str)
+	}
+	name := identName(expr)
+	for _, prefix := range []string{"SMCP_", "OPERATOR_", "OCP_"} {
+		if strings.HasPrefix(name, prefix) {
+			return version.Parse(strings.ReplaceAll(strings.TrimPrefix(name, prefix), "_", "."))
+		}
+	}
+	return version.Version{}, fmt.Errorf("unsupported version expression %q", name)
+}
+
+func stringArg(call *ast.CallExpr) (string, error) {
+	if len(call.Args) == 1 {
+		if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			return strconv.Unquote(lit.Value)
+		}
+	}
+	return "", fmt.Errorf("%s() expects a string literal", identName(call.Fun))
+}
+
+// subtestName returns the name of the subtest, or the expression that computes it
+func subtestName(fset *token.FileSet, expr ast.Expr) string {
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		if name, err := strconv.Unquote(lit.Value); err == nil {
+			return name
+		}
+	}
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, fset, expr)
+	return "<" + buf.String() + ">"
+}
+
+func isMethodCall(call *ast.CallExpr, name string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == name
+}
+
+// identName returns the name of an identifier, ignoring the package qualifier (e.g. "Full" for test.Full)
+func identName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	}
+	return ""
+}
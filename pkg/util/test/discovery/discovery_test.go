@@ -0,0 +1,105 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+	"github.com/maistra/maistra-test-tool/pkg/util/version"
+)
+
+const source = `package foo
+
+import (
+	"testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/capability"
+	. "github.com/maistra/maistra-test-tool/pkg/util/test"
+	"github.com/maistra/maistra-test-tool/pkg/util/version"
+)
+
+func TestFoo(t *testing.T) {
+	NewTest(t).Id("T1").Groups(Full, ARM).MinVersion(version.SMCP_2_4).MaxVersion(version.SMCP_2_5).
+		MinOperatorVersion(version.OPERATOR_2_6_2).MinOCPVersion(version.ParseVersion("4.12")).
+		RequiresCapability(capability.IPv6).Run(func(t TestHelper) {
+		t.NewSubTest("first").Run(func(t TestHelper) {})
+		for _, name := range []string{"a", "b"} {
+			t.NewSubTest(name).Run(func(t TestHelper) {})
+		}
+	})
+}
+
+func TestPlain(t *testing.T) {
+	t.Run("not a framework test", func(t *testing.T) {})
+}
+
+func helper(t *testing.T) {}
+`
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "pkg", "tests", "foo")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "foo_test.go"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests, err := Discover(root, filepath.Join(root, "pkg", "tests"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) != 1 {
+		t.Fatalf("expected a single test, but got %+v", tests)
+	}
+
+	smcp24, smcp25 := version.SMCP_2_4, version.SMCP_2_5
+	operator, ocp := version.OPERATOR_2_6_2, version.OCP_4_12
+	expected := test.Metadata{
+		Name:               "TestFoo",
+		Package:            "pkg/tests/foo",
+		File:               "pkg/tests/foo/foo_test.go",
+		Id:                 "T1",
+		Groups:             []test.TestGroup{test.Full, test.ARM},
+		MinVersion:         &smcp24,
+		MaxVersion:         &smcp25,
+		MinOperatorVersion: &operator,
+		MinOCPVersion:      &ocp,
+		Capabilities:       []test.Capability{"IPv6"},
+		Subtests:           []string{"first", "<name>"},
+	}
+	if !reflect.DeepEqual(tests[0], expected) {
+		t.Fatalf("unexpected metadata:\n%+v\nexpected:\n%+v", tests[0], expected)
+	}
+}
+
+func TestDiscoverRejectsUnknownGroup(t *testing.T) {
+	dir := t.TempDir()
+	src := `package foo
+func TestFoo(t *testing.T) {
+	NewTest(t).Groups(Nightly).Run(func(t TestHelper) {})
+}`
+	if err := os.WriteFile(filepath.Join(dir, "foo_test.go"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Discover(dir, dir); err == nil {
+		t.Fatal("expected an error for an unknown test group")
+	}
+}
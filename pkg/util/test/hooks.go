@@ -0,0 +1,72 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestHook is executed when a top-level test created with NewTest starts, right before the test function.
+// Cleanup functions that the hook registers with t.T().Cleanup() are executed after all cleanup functions
+// of the test and its subtests.
+type TestHook func(t TestHelper)
+
+var (
+	hooksMu sync.Mutex
+	hooks   []TestHook
+
+	// cleaningUp contains the tests and subtests whose cleanup functions are being executed
+	cleaningUp sync.Map
+	// parallel contains the tests that called TestHelper.Parallel()
+	parallel sync.Map
+)
+
+// RegisterTestHook registers a hook that is executed at the start of every top-level test. Like capability
+// probes, hooks are registered by the packages that need them (e.g. the oc package tracks the objects
+// created by each test), since this package can't depend on them.
+func RegisterTestHook(hook TestHook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hooks = append(hooks, hook)
+}
+
+func runTestHooks(t TestHelper) {
+	t.T().Helper()
+	hooksMu.Lock()
+	registered := append([]TestHook(nil), hooks...)
+	hooksMu.Unlock()
+	for _, hook := range registered {
+		hook(t)
+	}
+}
+
+// IsCleaningUp returns true once the cleanup functions that the test (or subtest) registered with
+// TestHelper.Cleanup are being executed
+func IsCleaningUp(t TestHelper) bool {
+	_, found := cleaningUp.Load(t.T())
+	return found
+}
+
+func setCleaningUp(t *testing.T) {
+	cleaningUp.Store(t, true)
+}
+
+// IsParallel returns true if the test called TestHelper.Parallel(). Such a test runs concurrently with the
+// other parallel tests, so the changes of the cluster made while it runs can't be attributed to it.
+func IsParallel(t TestHelper) bool {
+	_, found := parallel.Load(t.T())
+	return found
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"fmt"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/env"
+	"github.com/maistra/maistra-test-tool/pkg/util/version"
+)
+
+// Metadata describes a top-level test as declared with NewTest(t).Id().Groups().MinVersion()...
+// The discovery package extracts it from the sources, so that tests can be listed without running them.
+type Metadata struct {
+	Name               string           `json:"name"`
+	Package            string           `json:"package,omitempty"`
+	File               string           `json:"file,omitempty"`
+	Id                 string           `json:"id,omitempty"`
+	Groups             []TestGroup      `json:"groups,omitempty"`
+	MinVersion         *version.Version `json:"minVersion,omitempty"`
+	MaxVersion         *version.Version `json:"maxVersion,omitempty"`
+	MinOperatorVersion *version.Version `json:"minOperatorVersion,omitempty"`
+	MinOCPVersion      *version.Version `json:"minOCPVersion,omitempty"`
+	Capabilities       []Capability     `json:"capabilities,omitempty"`
+	// Subtests are the names of the subtests created with NewSubTest. Names that are computed
+	// at runtime are listed as the expression that computes them.
+	Subtests []string `json:"subtests,omitempty"`
+}
+
+// Environment is the test environment that decides which tests are executed
+type Environment struct {
+	TestGroup       TestGroup
+	SMCPVersion     version.Version
+	OperatorVersion version.Version
+	// OCPVersion returns the OpenShift version of the cluster. If nil, MinOCPVersion is not evaluated.
+	OCPVersion func() (version.Version, error)
+	// Capability returns the status of a capability. If nil, capability requirements are not evaluated.
+	Capability func(c Capability) CapabilityStatus
+}
+
+// CurrentEnvironment returns the environment of the current test run, as configured by the
+// TEST_GROUP, OCP_ARCH, SMCP_VERSION and OPERATOR_VERSION environment variables and the cluster
+func CurrentEnvironment() Environment {
+	return Environment{
+		TestGroup:       CurrentTestGroup(),
+		SMCPVersion:     env.GetSMCPVersion(),
+		OperatorVersion: env.GetOperatorVersion(),
+		OCPVersion: func() (version.Version, error) {
+			v, err := ocpVersion()
+			if err != nil {
+				return version.Version{}, err
+			}
+			return version.Parse(v)
+		},
+		Capability: ProbeCapability,
+	}
+}
+
+// CurrentTestGroup returns the test group that is being executed. On arm64 clusters, this is always ARM.
+func CurrentTestGroup() TestGroup {
+	if env.GetArch() == "arm64" {
+		return ARM
+	}
+	return TestGroup(env.GetTestGroup())
+}
+
+// SkipReason returns the reason why the test is skipped in the given environment,
+// or an empty string if the test is executed
+func (m Metadata) SkipReason(e Environment) (string, error) {
+	if !m.IsPartOfGroup(e.TestGroup) {
+		return fmt.Sprintf("This test is being skipped because it is not part of the %q test group", e.TestGroup), nil
+	}
+
+	if m.MinVersion != nil && e.SMCPVersion.LessThan(*m.MinVersion) {
+		return fmt.Sprintf("This test is being skipped because it doesn't support the current SMCP version %s (min version is %s)", e.SMCPVersion, m.MinVersion), nil
+	}
+	if m.MaxVersion != nil && e.SMCPVersion.GreaterThan(*m.MaxVersion) {
+		return fmt.Sprintf("This test is being skipped because it doesn't support the current SMCP version %s (max version is %s)", e.SMCPVersion, m.MaxVersion), nil
+	}
+	if m.MinOperatorVersion != nil && e.OperatorVersion.LessThan(*m.MinOperatorVersion) {
+		return fmt.Sprintf("This test is being skipped because it doesn't support the current operator version %s (min version is %s)", e.OperatorVersion, m.MinOperatorVersion), nil
+	}
+
+	if m.MinOCPVersion != nil && e.OCPVersion != nil {
+		ocp, err := e.OCPVersion()
+		if err != nil {
+			return "", fmt.Errorf("could not determine the OCP version: %v", err)
+		}
+		if ocp.Core().LessThan(*m.MinOCPVersion) {
+			return fmt.Sprintf("This test is being skipped because it doesn't support the current OCP version %s (min version is %s)", ocp, m.MinOCPVersion), nil
+		}
+	}
+
+	if e.Capability != nil {
+		for _, c := range m.Capabilities {
+			if status := e.Capability(c); !status.Available {
+				return fmt.Sprintf("This test is being skipped because it requires capability %s, which is not available: %s", c, status.Reason), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// Unevaluated returns the requirements of the test that SkipReason can't evaluate in the given
+// environment, because it doesn't provide the OCP version or the capabilities
+func (m Metadata) Unevaluated(e Environment) []string {
+	var requirements []string
+	if m.MinOCPVersion != nil && e.OCPVersion == nil {
+		requirements = append(requirements, fmt.Sprintf("OCP version >= %s", m.MinOCPVersion))
+	}
+	if e.Capability == nil {
+		for _, c := range m.Capabilities {
+			requirements = append(requirements, fmt.Sprintf("capability %s", c))
+		}
+	}
+	return requirements
+}
+
+func (m Metadata) IsPartOfGroup(group TestGroup) bool {
+	for _, g := range m.Groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,298 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/env"
+)
+
+type Outcome string
+
+const (
+	Passed  Outcome = "passed"
+	Failed  Outcome = "failed"
+	Skipped Outcome = "skipped"
+)
+
+// Report contains the results of all tests executed in a test package. It is written to
+// a JSON file in env.GetReportDir() when the TestSuite completes.
+type Report struct {
+	Package         string        `json:"package"`
+	TestGroup       string        `json:"testGroup"`
+	SMCPVersion     string        `json:"smcpVersion"`
+	OperatorVersion string        `json:"operatorVersion"`
+	OCPVersion      string        `json:"ocpVersion,omitempty"`
+	Start           time.Time     `json:"start"`
+	End             time.Time     `json:"end"`
+	Tests           []*TestResult `json:"tests"`
+	// Capabilities contains the status of the capabilities required by the executed tests
+	Capabilities map[Capability]CapabilityStatus `json:"capabilities,omitempty"`
+}
+
+// TestResult is the result of a top-level test or subtest. Subtests have their own TestResult,
+// whose Parent is the name of the enclosing test.
+type TestResult struct {
+	Name           string        `json:"name"`
+	Parent         string        `json:"parent,omitempty"`
+	Id             string        `json:"id,omitempty"`
+	Groups         []TestGroup   `json:"groups,omitempty"`
+	Outcome        Outcome       `json:"outcome"`
+	SkipReason     string        `json:"skipReason,omitempty"`
+	Start          time.Time     `json:"start"`
+	End            time.Time     `json:"end"`
+	Duration       float64       `json:"durationSeconds"`
+	Steps          []*StepResult `json:"steps,omitempty"`
+	Retries        RetryStats    `json:"retries"`
+	DiagnosticsDir string        `json:"diagnosticsDir,omitempty"`
+	MustGatherDir  string        `json:"mustGatherDir,omitempty"`
+}
+
+// StepResult is the result of a test step (see TestHelper.LogStep). A step ends when
+// the next step starts or when the test ends.
+type StepResult struct {
+	Number      int        `json:"number"`
+	Description string     `json:"description"`
+	Outcome     Outcome    `json:"outcome"`
+	Start       time.Time  `json:"start"`
+	End         time.Time  `json:"end"`
+	Duration    float64    `json:"durationSeconds"`
+	Retries     RetryStats `json:"retries"`
+
+	failedBefore bool
+}
+
+// RetryStats summarizes the retry.UntilSuccess loops executed in a test or step
+type RetryStats struct {
+	// Loops is the number of retry loops
+	Loops int `json:"loops"`
+	// Attempts is the total number of attempts across all loops
+	Attempts int `json:"attempts"`
+	// FailedAttempts is the number of attempts that failed, including those that were retried successfully
+	FailedAttempts int `json:"failedAttempts"`
+	// Exhausted is the number of loops in which every attempt failed
+	Exhausted int `json:"exhausted"`
+}
+
+func (s *RetryStats) add(attempts int, succeeded bool) {
+	s.Loops++
+	s.Attempts += attempts
+	if succeeded {
+		s.FailedAttempts += attempts - 1
+	} else {
+		s.FailedAttempts += attempts
+		s.Exhausted++
+	}
+}
+
+var report = &reportCollector{
+	report:  Report{Start: time.Now()},
+	results: map[string]*TestResult{},
+}
+
+type reportCollector struct {
+	mu      sync.Mutex
+	report  Report
+	results map[string]*TestResult
+}
+
+func (r *reportCollector) startTest(t *testing.T, parent string, id string, groups []TestGroup) *TestResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := &TestResult{
+		Name:   t.Name(),
+		Parent: parent,
+		Id:     id,
+		Groups: groups,
+		Start:  time.Now(),
+	}
+	r.results[result.Name] = result
+	r.report.Tests = append(r.report.Tests, result)
+	return result
+}
+
+func (r *reportCollector) finishTest(t *testing.T, result *TestResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result.End = time.Now()
+	result.Duration = result.End.Sub(result.Start).Seconds()
+	result.Outcome = outcomeOf(t)
+	if len(result.Steps) > 0 {
+		finishStep(t, result.Steps[len(result.Steps)-1], result.End)
+	}
+}
+
+func (r *reportCollector) setSkipReason(result *TestResult, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result.SkipReason = reason
+}
+
+func (r *reportCollector) setCapability(c Capability, status CapabilityStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.report.Capabilities == nil {
+		r.report.Capabilities = map[Capability]CapabilityStatus{}
+	}
+	r.report.Capabilities[c] = status
+}
+
+func (r *reportCollector) resumeTest(t *testing.T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if result := r.results[t.Name()]; result != nil {
+		result.Start = time.Now()
+	}
+}
+
+func (r *reportCollector) startStep(t *testing.T, number int, description string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := r.results[t.Name()]
+	if result == nil {
+		return // not a test created with NewTest()
+	}
+	now := time.Now()
+	if len(result.Steps) > 0 {
+		last := result.Steps[len(result.Steps)-1]
+		if last.Number >= number {
+			return // the step is being logged again by a retry attempt
+		}
+		finishStep(t, last, now)
+	}
+	result.Steps = append(result.Steps, &StepResult{
+		Number:       number,
+		Description:  description,
+		Start:        now,
+		failedBefore: t.Failed(),
+	})
+}
+
+func (r *reportCollector) recordRetry(t *testing.T, attempts int, succeeded bool) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := r.results[t.Name()]
+	if result == nil {
+		return false
+	}
+	result.Retries.add(attempts, succeeded)
+	if len(result.Steps) > 0 {
+		result.Steps[len(result.Steps)-1].Retries.add(attempts, succeeded)
+	}
+	return true
+}
+
+func (r *reportCollector) setDiagnosticsDir(t *testing.T, dir string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if result := r.results[t.Name()]; result != nil {
+		result.DiagnosticsDir = dir
+	}
+}
+
+func (r *reportCollector) setMustGatherDir(t *testing.T, dir string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if result := r.results[t.Name()]; result != nil {
+		result.MustGatherDir = dir
+	}
+}
+
+// snapshot returns a copy of the report with the test run metadata filled in
+func (r *reportCollector) snapshot() Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rep := r.report
+	rep.Package = packageName()
+	rep.TestGroup = env.GetTestGroup()
+	rep.SMCPVersion = env.GetSMCPVersion().String()
+	rep.OperatorVersion = env.GetOperatorVersion().String()
+	rep.End = time.Now()
+	rep.Tests = append([]*TestResult(nil), r.report.Tests...)
+	return rep
+}
+
+func finishStep(t *testing.T, step *StepResult, end time.Time) {
+	if step.Outcome != "" {
+		return
+	}
+	step.End = end
+	step.Duration = end.Sub(step.Start).Seconds()
+	if t.Failed() && !step.failedBefore {
+		step.Outcome = Failed
+	} else if t.Skipped() {
+		step.Outcome = Skipped
+	} else {
+		step.Outcome = Passed
+	}
+}
+
+func outcomeOf(t *testing.T) Outcome {
+	switch {
+	case t.Skipped():
+		return Skipped
+	case t.Failed():
+		return Failed
+	default:
+		return Passed
+	}
+}
+
+// RecordRetry adds the outcome of a retry loop to the report entry of the test and its current step.
+// It returns false if the test isn't part of the report, because it wasn't created with NewTest().
+func RecordRetry(t TestHelper, attempts int, succeeded bool) bool {
+	return report.recordRetry(t.T(), attempts, succeeded)
+}
+
+// writeReport writes the report of the current test package to the report directory
+func writeReport() error {
+	rep := report.snapshot()
+	if v, err := ocpVersion(); err == nil {
+		rep.OCPVersion = v
+	} else {
+		fmt.Fprintf(os.Stderr, "could not determine the OCP version for the test report: %v\n", err)
+	}
+	dir := env.GetReportDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(rep, "", "  ")
+	if err != nil {
+		return err
+	}
+	file := filepath.Join(dir, strings.ReplaceAll(rep.Package, "/", "-")+".json")
+	return os.WriteFile(file, append(data, '\n'), 0644)
+}
+
+// packageName returns the path of the test package relative to the root dir (e.g. pkg/tests/tasks/traffic),
+// since go test runs each test binary in the directory of its package
+func packageName() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "unknown"
+	}
+	if rel, err := filepath.Rel(env.GetRootDir(), wd); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.Base(wd)
+}
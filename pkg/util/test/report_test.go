@@ -0,0 +1,121 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReportRecordsStepsAndRetries(t *testing.T) {
+	var name string
+	t.Run("top-level", func(t *testing.T) {
+		name = t.Name()
+		NewTest(t).Id("T1").Groups(Full).Run(func(t TestHelper) {
+			t.LogStep("first")
+			RecordRetry(t, 3, true)
+			t.LogStep("second")
+			RecordRetry(t, 1, true)
+			RecordRetry(t, 2, false)
+
+			t.NewSubTest("subtest").Run(func(t TestHelper) {
+				t.LogStep("only")
+			})
+		})
+	})
+
+	result := report.results[name]
+	if result == nil {
+		t.Fatalf("no result recorded for %s", name)
+	}
+	if result.Id != "T1" || len(result.Groups) != 1 || result.Outcome != Passed {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(result.Steps) != 2 || result.Steps[0].Description != "first" || result.Steps[1].Number != 2 {
+		t.Fatalf("unexpected steps: %+v", result.Steps)
+	}
+	assertRetryStats(t, result.Steps[0].Retries, RetryStats{Loops: 1, Attempts: 3, FailedAttempts: 2})
+	assertRetryStats(t, result.Steps[1].Retries, RetryStats{Loops: 2, Attempts: 3, FailedAttempts: 2, Exhausted: 1})
+	assertRetryStats(t, result.Retries, RetryStats{Loops: 3, Attempts: 6, FailedAttempts: 4, Exhausted: 1})
+
+	subtest := report.results[name+"/subtest"]
+	if subtest == nil || subtest.Parent != name || len(subtest.Steps) != 1 {
+		t.Fatalf("unexpected subtest result: %+v", subtest)
+	}
+}
+
+func TestReportRecordsSkippedTests(t *testing.T) {
+	var name string
+	t.Run("skipped", func(t *testing.T) {
+		name = t.Name()
+		NewTest(t).Groups(Migration).Run(func(t TestHelper) {
+			t.Fatal("test should have been skipped")
+		})
+	})
+
+	if result := report.results[name]; result == nil || result.Outcome != Skipped {
+		t.Fatalf("expected test to be reported as skipped, but got %+v", result)
+	}
+}
+
+func TestReportIncludesCleanup(t *testing.T) {
+	var name string
+	var cleanedUp time.Time
+	t.Run("cleanup", func(t *testing.T) {
+		name = t.Name()
+		NewTest(t).Groups(Full).Run(func(t TestHelper) {
+			t.T().Cleanup(func() {
+				time.Sleep(10 * time.Millisecond)
+				cleanedUp = time.Now()
+			})
+		})
+	})
+
+	result := report.results[name]
+	if result == nil || cleanedUp.IsZero() || result.End.Before(cleanedUp) {
+		t.Fatalf("expected the test to be finished after its cleanup at %v, but got %+v", cleanedUp, result)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("REPORT_DIR", dir)
+
+	if err := writeReport(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "pkg-util-test.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rep Report
+	if err := json.Unmarshal(data, &rep); err != nil {
+		t.Fatal(err)
+	}
+	if rep.Package != "pkg/util/test" || rep.SMCPVersion == "" {
+		t.Fatalf("unexpected report metadata: %+v", rep)
+	}
+}
+
+func assertRetryStats(t *testing.T, actual, expected RetryStats) {
+	t.Helper()
+	if actual != expected {
+		t.Fatalf("expected retry stats %+v, but got %+v", expected, actual)
+	}
+}
@@ -30,8 +30,11 @@ var _ Test = subTest{}
 
 func (t subTest) Run(f func(t TestHelper)) {
 	t.t.Helper()
+	parent := t.t.Name()
 	t.t.Run(t.name, func(t *testing.T) {
 		t.Helper()
+		result := report.startTest(t, parent, "", nil)
+		t.Cleanup(func() { report.finishTest(t, result) })
 		start := time.Now()
 		th := NewTestHelper(t)
 		defer func() {
@@ -39,8 +42,11 @@ func (t subTest) Run(f func(t TestHelper)) {
 			t.Log()
 			if th.Failed() {
 				t.Logf("Subtest failed in %.2fs (excluding cleanup)", time.Now().Sub(start).Seconds())
+				if env.IsDiagnosticsEnabled() {
+					report.setDiagnosticsDir(t, captureDiagnostics(th, start))
+				}
 				if env.IsMustGatherEnabled() {
-					captureMustGather(t)
+					report.setMustGatherDir(t, captureMustGather(t))
 				}
 			} else {
 				t.Logf("Subtest completed in %.2fs (excluding cleanup)", time.Now().Sub(start).Seconds())
@@ -15,6 +15,7 @@
 package test
 
 import (
+	"fmt"
 	"os"
 	"testing"
 )
@@ -41,6 +42,9 @@ func (s *testSuite) Run() {
 	if t.cleanup != nil {
 		t.cleanup()
 	}
+	if err := writeReport(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write test report: %v\n", err)
+	}
 	os.Exit(exitCode)
 }
 
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -37,6 +38,17 @@ const (
 	Persistent   TestGroup = "persistent"
 )
 
+// TestGroupsByName maps the names of the TestGroup constants to their values (e.g. "Full" to "full")
+var TestGroupsByName = map[string]TestGroup{
+	"ARM":          ARM,
+	"Full":         Full,
+	"Migration":    Migration,
+	"Smoke":        Smoke,
+	"InterOp":      InterOp,
+	"Disconnected": Disconnected,
+	"Persistent":   Persistent,
+}
+
 type Test interface {
 	Run(f func(t TestHelper))
 }
@@ -46,46 +58,76 @@ type TopLevelTest interface {
 	Groups(groups ...TestGroup) TopLevelTest
 	MinVersion(v version.Version) TopLevelTest
 	MaxVersion(v version.Version) TopLevelTest
+	// MinOperatorVersion skips the test if the operator version (OPERATOR_VERSION) is lower than v
+	MinOperatorVersion(v version.Version) TopLevelTest
+	// MinOCPVersion skips the test if the OpenShift version of the cluster is lower than v.
+	// Release candidates and nightly builds are considered to be the release they precede.
+	MinOCPVersion(v version.Version) TopLevelTest
+	// RequiresCapability skips the test if any of the capabilities isn't available (see ProbeCapability)
+	RequiresCapability(capabilities ...Capability) TopLevelTest
 	Id(id string) TopLevelTest
 }
 
 func NewTest(t *testing.T) TopLevelTest {
-	return &topLevelTest{t: t}
+	return &topLevelTest{t: t, meta: Metadata{Name: t.Name()}}
 }
 
 var _ Test = &topLevelTest{}
 
 type topLevelTest struct {
-	t          *testing.T
-	id         string
-	groups     []TestGroup
-	minVersion *version.Version
-	maxVersion *version.Version
+	t    *testing.T
+	meta Metadata
 }
 
 func (t *topLevelTest) Groups(groups ...TestGroup) TopLevelTest {
-	t.groups = groups
+	t.meta.Groups = groups
 	return t
 }
 
 func (t *topLevelTest) MinVersion(v version.Version) TopLevelTest {
-	t.minVersion = &v
+	t.meta.MinVersion = &v
 	return t
 }
 
 func (t *topLevelTest) MaxVersion(v version.Version) TopLevelTest {
-	t.maxVersion = &v
+	t.meta.MaxVersion = &v
+	return t
+}
+
+func (t *topLevelTest) MinOperatorVersion(v version.Version) TopLevelTest {
+	t.meta.MinOperatorVersion = &v
+	return t
+}
+
+func (t *topLevelTest) MinOCPVersion(v version.Version) TopLevelTest {
+	t.meta.MinOCPVersion = &v
+	return t
+}
+
+func (t *topLevelTest) RequiresCapability(capabilities ...Capability) TopLevelTest {
+	t.meta.Capabilities = append(t.meta.Capabilities, capabilities...)
 	return t
 }
 
 func (t *topLevelTest) Id(id string) TopLevelTest {
-	t.id = id
+	t.meta.Id = id
 	return t
 }
 
 func (t *topLevelTest) Run(f func(t TestHelper)) {
 	t.t.Helper()
-	t.skipIfNecessary()
+	result := report.startTest(t.t, "", t.meta.Id, t.meta.Groups)
+	// registered before the cleanup functions of the test hooks (e.g. the leak and drift checks), so that it runs
+	// after them and the outcome includes their failures
+	t.t.Cleanup(func() { report.finishTest(t.t, result) })
+	reason, err := t.meta.SkipReason(CurrentEnvironment())
+	if err != nil {
+		t.t.Fatal(err)
+	}
+	if reason != "" {
+		report.setSkipReason(result, reason)
+		t.t.Skip(reason)
+	}
 	start := time.Now()
 	th := &testHelper{t: t.t}
 	defer func() {
@@ -93,17 +135,23 @@ func (t *topLevelTest) Run(f func(t TestHelper)) {
 		t.t.Log()
 		if th.Failed() {
 			t.t.Logf("Test failed in %.2fs (excluding cleanup)", time.Now().Sub(start).Seconds())
+			if env.IsDiagnosticsEnabled() {
+				report.setDiagnosticsDir(t.t, captureDiagnostics(th, start))
+			}
 			if env.IsMustGatherEnabled() {
-				captureMustGather(t.t)
+				report.setMustGatherDir(t.t, captureMustGather(t.t))
 			}
 		} else {
 			t.t.Logf("Test completed in %.2fs (excluding cleanup)", time.Now().Sub(start).Seconds())
 		}
 	}()
+	runTestHooks(th)
 	f(th)
 }
 
-func captureMustGather(t *testing.T) {
+// captureMustGather runs must-gather and returns the directory containing its output,
+// or an empty string if must-gather failed
+func captureMustGather(t *testing.T) string {
 	image := env.GetMustGatherImage()
 	dir := fmt.Sprintf("%s/failures-must-gather/%s-%s",
 		env.GetOutputDir(),
@@ -113,37 +161,44 @@ func captureMustGather(t *testing.T) {
 	t.Logf("Capturing cluster state using must-gather %s", image)
 	cmd := exec.Command("sh", "-c", fmt.Sprintf(`rm -rf %s; mkdir -p %s; oc adm must-gather --dest-dir=%s --image=%s`, dir, dir, dir, image))
 	_, err := cmd.CombinedOutput()
-	if err == nil {
-		t.Log(dir)
-	} else {
+	if err != nil {
 		t.Logf("failed to create must-gather: %v", err)
+		return ""
 	}
+	t.Log(dir)
+	return dir
 }
 
-func (t *topLevelTest) skipIfNecessary() {
-	testGroup := TestGroup(env.GetTestGroup())
-	if env.GetArch() == "arm64" {
-		testGroup = "arm64"
-	}
+// OCPVersionProvider returns the OpenShift version of the cluster (see oc.GetOCPVersion)
+type OCPVersionProvider func(t TestHelper) string
 
-	if !t.isPartOfGroup(testGroup) {
-		t.t.Skipf("This test is being skipped because it is not part of the %q test group", testGroup)
-	}
+var (
+	ocpVersionProviderMu sync.Mutex
+	ocpVersionProvider   OCPVersionProvider
+)
 
-	smcpVersion := env.GetSMCPVersion()
-	if t.minVersion != nil && smcpVersion.LessThan(*t.minVersion) {
-		t.t.Skipf("This test is being skipped because it doesn't support the current SMCP version %s (min version is %s)", smcpVersion, t.minVersion)
-	}
-	if t.maxVersion != nil && smcpVersion.GreaterThan(*t.maxVersion) {
-		t.t.Skipf("This test is being skipped because it doesn't support the current SMCP version %s (max version is %s)", smcpVersion, t.maxVersion)
-	}
+// RegisterOCPVersionProvider registers the function that determines the OpenShift version of the cluster.
+// Like hooks, the provider is registered by the oc package, since this package can't depend on it.
+func RegisterOCPVersionProvider(provider OCPVersionProvider) {
+	ocpVersionProviderMu.Lock()
+	defer ocpVersionProviderMu.Unlock()
+	ocpVersionProvider = provider
 }
 
-func (t *topLevelTest) isPartOfGroup(group TestGroup) bool {
-	for _, g := range t.groups {
-		if g == group {
-			return true
-		}
+// ocpVersion returns the OpenShift version of the cluster. The provider is executed only once per test package.
+var ocpVersion = sync.OnceValues(detectOCPVersion)
+
+func detectOCPVersion() (version string, err error) {
+	ocpVersionProviderMu.Lock()
+	provider := ocpVersionProvider
+	ocpVersionProviderMu.Unlock()
+	if provider == nil {
+		return "", fmt.Errorf("no OCP version provider is registered (is the oc package imported?)")
 	}
-	return false
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("the OCP version provider failed: %v", r)
+		}
+	}()
+	return provider(NewSetupTestHelper()), nil
 }
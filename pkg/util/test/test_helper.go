@@ -15,6 +15,7 @@
 package test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -63,10 +64,15 @@ type TestHelper interface {
 	Parallel()
 
 	WillRetry() bool
+
+	// Context returns the context that long-running operations (e.g. shell commands) executed on behalf
+	// of the test should observe. Within a retry attempt, it is canceled when the attempt times out.
+	Context() context.Context
 }
 
 type testHelper struct {
 	t           *testing.T
+	ctx         context.Context
 	currentStep int
 }
 
@@ -147,6 +153,7 @@ func (t *testHelper) Cleanup(f func()) {
 		start := time.Now()
 		t.T().Log()
 		t.T().Log("Performing cleanup")
+		setCleaningUp(t.t)
 		f()
 		t.T().Logf("Cleanup completed in %.2fs", time.Now().Sub(start).Seconds())
 	})
@@ -157,6 +164,7 @@ func (t *testHelper) LogStep(str string) {
 	t.currentStep++
 	t.Log("")
 	t.t.Logf("STEP %d: %s", t.currentStep, str)
+	report.startStep(t.t, t.currentStep, str)
 }
 
 func (t *testHelper) LogStepf(format string, args ...any) {
@@ -190,13 +198,50 @@ func (t *testHelper) T() *testing.T {
 }
 
 func (t *testHelper) Parallel() {
+	parallel.Store(t.t, true)
 	t.t.Parallel()
+	// the test was paused until the non-parallel tests completed, which shouldn't count towards its duration
+	report.resumeTest(t.t)
 }
 
 func (t *testHelper) WillRetry() bool {
 	return false
 }
 
+func (t *testHelper) Context() context.Context {
+	if t.ctx == nil {
+		// testing.T.Context() isn't used, because it's canceled before the cleanup functions run
+		return context.Background()
+	}
+	return t.ctx
+}
+
+// Deadline returns the time at which the test binary panics because the timeout specified
+// with go test -timeout has elapsed
+func Deadline(t TestHelper) (time.Time, bool) {
+	if c, ok := t.(contextTestHelper); ok {
+		return Deadline(c.TestHelper)
+	}
+	if _, ok := t.(*setupTestHelper); ok {
+		return time.Time{}, false
+	}
+	return t.T().Deadline()
+}
+
+// WithContext returns a TestHelper that behaves exactly like t, except that its Context() returns ctx
+func WithContext(t TestHelper, ctx context.Context) TestHelper {
+	return contextTestHelper{TestHelper: t, ctx: ctx}
+}
+
+type contextTestHelper struct {
+	TestHelper
+	ctx context.Context
+}
+
+func (t contextTestHelper) Context() context.Context {
+	return t.ctx
+}
+
 func (t *testHelper) indent() string {
 	if t.currentStep > 0 {
 		return "   "
@@ -15,6 +15,7 @@
 package test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -23,10 +24,11 @@ import (
 
 const retryPanicKey = "RetryTestHelper.FailNow"
 
-func NewRetryTestHelper(t *testing.T, currentStep, attempt, maxAttempts int) *RetryTestHelper {
+func NewRetryTestHelper(ctx context.Context, t *testing.T, currentStep, attempt, maxAttempts int) *RetryTestHelper {
 	return &RetryTestHelper{
 		testHelper: testHelper{
 			t:           t,
+			ctx:         ctx,
 			currentStep: currentStep,
 		},
 		attempt:     attempt,
@@ -15,6 +15,7 @@
 package test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -138,6 +139,10 @@ func (t *setupTestHelper) WillRetry() bool {
 	panic("not applicable")
 }
 
+func (t *setupTestHelper) Context() context.Context {
+	return context.Background()
+}
+
 func (t *setupTestHelper) indent() string {
 	panic("not applicable")
 }
@@ -0,0 +1,139 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/version"
+)
+
+func TestMinOperatorAndOCPVersion(t *testing.T) {
+	t.Setenv("TEST_GROUP", string(Full))
+	t.Setenv("OPERATOR_VERSION", "2.6.1")
+	restore := ocpVersion
+	ocpVersion = func() (string, error) { return "4.18.0-rc.5", nil }
+	defer func() { ocpVersion = restore }()
+
+	cases := []struct {
+		name     string
+		test     func(t *testing.T) TopLevelTest
+		executed bool
+	}{
+		{
+			name:     "operator version satisfied",
+			test:     func(t *testing.T) TopLevelTest { return NewTest(t).MinOperatorVersion(version.OPERATOR_2_6_0) },
+			executed: true,
+		},
+		{
+			name: "operator patch version too low",
+			test: func(t *testing.T) TopLevelTest { return NewTest(t).MinOperatorVersion(version.OPERATOR_2_6_2) },
+		},
+		{
+			name:     "OCP release candidate counts as release",
+			test:     func(t *testing.T) TopLevelTest { return NewTest(t).MinOCPVersion(version.OCP_4_18) },
+			executed: true,
+		},
+		{
+			name: "OCP version too low",
+			test: func(t *testing.T) TopLevelTest { return NewTest(t).MinOCPVersion(version.OCP_4_19) },
+		},
+	}
+	for _, c := range cases {
+		executed := false
+		t.Run(c.name, func(t *testing.T) {
+			c.test(t).Groups(Full).Run(func(t TestHelper) {
+				executed = true
+			})
+		})
+		if executed != c.executed {
+			t.Errorf("%s: expected executed=%v, but was %v", c.name, c.executed, executed)
+		}
+	}
+}
+
+func TestDetectOCPVersion(t *testing.T) {
+	defer RegisterOCPVersionProvider(nil)
+
+	RegisterOCPVersionProvider(func(t TestHelper) string { return "4.18.0" })
+	if v, err := detectOCPVersion(); v != "4.18.0" || err != nil {
+		t.Errorf("expected version 4.18.0, got %q (%v)", v, err)
+	}
+
+	RegisterOCPVersionProvider(func(t TestHelper) string {
+		t.Fatal("cluster not reachable")
+		return ""
+	})
+	if _, err := detectOCPVersion(); err == nil {
+		t.Error("expected the failure of the provider to be returned as an error")
+	}
+}
+
+func TestRequiresCapability(t *testing.T) {
+	t.Setenv("TEST_GROUP", string(Full))
+	probes := 0
+	RegisterCapability("Available", func(t TestHelper) (bool, string) {
+		probes++
+		return true, ""
+	})
+	RegisterCapability("Unavailable", func(t TestHelper) (bool, string) {
+		return false, "not on this cluster"
+	})
+	RegisterCapability("Broken", func(t TestHelper) (bool, string) {
+		t.Fatal("probe error")
+		return true, ""
+	})
+	RegisterCapability("NotRequired", func(t TestHelper) (bool, string) {
+		t.Fatal("capabilities that no test requires must not be probed")
+		return true, ""
+	})
+
+	cases := []struct {
+		capability Capability
+		skipReason string
+	}{
+		{capability: "Available"},
+		{capability: "Available"},
+		{capability: "Unavailable", skipReason: "not on this cluster"},
+		{capability: "Broken", skipReason: "the capability probe failed"},
+		{capability: "Unknown", skipReason: "no probe is registered"},
+	}
+	for _, c := range cases {
+		var name string
+		executed := false
+		t.Run(string(c.capability), func(t *testing.T) {
+			name = t.Name()
+			NewTest(t).Groups(Full).RequiresCapability(c.capability).Run(func(t TestHelper) {
+				executed = true
+			})
+		})
+		if executed != (c.skipReason == "") {
+			t.Errorf("%s: unexpected executed=%v", c.capability, executed)
+		}
+		if reason := report.results[name].SkipReason; !strings.Contains(reason, c.skipReason) || (c.skipReason == "") != (reason == "") {
+			t.Errorf("%s: expected skip reason to contain %q, but got %q", c.capability, c.skipReason, reason)
+		}
+	}
+	if probes != 1 {
+		t.Errorf("expected the probe to be executed once, but it was executed %d times", probes)
+	}
+	if _, found := report.report.Capabilities["NotRequired"]; found {
+		t.Error("expected the capability that no test requires not to be probed")
+	}
+	if status := report.report.Capabilities["Unavailable"]; status.Available || status.Reason != "not on this cluster" {
+		t.Errorf("expected the report to contain the status of the probed capability, but got %v", status)
+	}
+}
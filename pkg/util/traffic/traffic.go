@@ -0,0 +1,245 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package traffic asserts how requests are distributed between the destinations of a route, e.g. the versions
+// of a service in a weighted VirtualService. It sends requests with a Sender, determines the destination of each
+// response with a Classifier and compares the distribution to the expected weights with a chi-square test.
+package traffic
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/textproto"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maistra/maistra-test-tool/pkg/app"
+	"github.com/maistra/maistra-test-tool/pkg/util/curl"
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// Response is a response received by a Sender. For TCP, only the Body is set.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// Err is set if the request failed
+	Err error
+}
+
+// Sender sends the specified number of requests and returns the responses
+type Sender func(t test.TestHelper, requests int) []Response
+
+// Classifier returns the destination that served the response, or "" if it can't be determined
+type Classifier func(r Response) string
+
+// Distribution is the number of responses by destination
+type Distribution map[string]int
+
+func (d Distribution) Total() int {
+	total := 0
+	for _, count := range d {
+		total += count
+	}
+	return total
+}
+
+func (d Distribution) String() string {
+	var destinations []string
+	for destination, count := range d {
+		destinations = append(destinations, fmt.Sprintf("%s: %d", destination, count))
+	}
+	sort.Strings(destinations)
+	return strings.Join(destinations, ", ")
+}
+
+// FromRunner sends the requests from the test runner with curl.Request
+func FromRunner(url string, requestOption curl.RequestOption) Sender {
+	return func(t test.TestHelper, requests int) []Response {
+		t.T().Helper()
+		var responses []Response
+		for i := 0; i < requests; i++ {
+			curl.Request(t, url, requestOption,
+				func(t test.TestHelper, response *http.Response, responseBody []byte, responseErr error, duration time.Duration) {
+					r := Response{Body: responseBody, Err: responseErr}
+					if response != nil {
+						r.StatusCode = response.StatusCode
+						r.Header = response.Header
+					}
+					responses = append(responses, r)
+				})
+		}
+		return responses
+	}
+}
+
+// responseSeparator separates the responses that curl prints in FromPod
+const responseSeparator = "--- end of response ---"
+
+// FromPod sends the requests with curl from the container of the client pod (e.g. a sleep pod in the mesh), so that
+// they're routed by the client's sidecar instead of the ingress gateway
+func FromPod(client oc.PodLocatorFunc, container, url string) Sender {
+	return func(t test.TestHelper, requests int) []Response {
+		t.T().Helper()
+		output := oc.Exec(t, client, container, fmt.Sprintf(
+			`sh -c 'i=1; while [ $i -le %d ]; do curl -sSi --http1.1 %s 2>&1; echo; echo "%s"; i=$((i+1)); done'`,
+			requests, url, responseSeparator))
+		return parseCurlResponses(output)
+	}
+}
+
+// TCPFromPod opens the specified number of TCP connections with nc from the container of the client pod and
+// returns each line received from the server as the body of a response
+func TCPFromPod(client oc.PodLocatorFunc, container, host, port string) Sender {
+	return func(t test.TestHelper, requests int) []Response {
+		t.T().Helper()
+		output := oc.Exec(t, client, container, fmt.Sprintf(
+			`sh -c 'i=1; while [ $i -le %d ]; do date | nc %s %s; i=$((i+1)); done'`, requests, host, port))
+		var responses []Response
+		scanner := bufio.NewScanner(strings.NewReader(output))
+		for scanner.Scan() {
+			if line := scanner.Text(); line != "" {
+				responses = append(responses, Response{Body: []byte(line)})
+			}
+		}
+		return responses
+	}
+}
+
+// parseCurlResponses parses the output of curl -i, which prints the status line and headers before the decoded body
+func parseCurlResponses(output string) []Response {
+	var responses []Response
+	for _, chunk := range strings.Split(output, responseSeparator) {
+		chunk = strings.TrimLeft(chunk, "\r\n")
+		if chunk == "" {
+			continue
+		}
+		if !strings.HasPrefix(chunk, "HTTP/") {
+			responses = append(responses, Response{Err: errors.New(strings.TrimSpace(chunk))})
+			continue
+		}
+		reader := textproto.NewReader(bufio.NewReader(strings.NewReader(chunk)))
+		statusLine, _ := reader.ReadLine()
+		r := Response{}
+		if fields := strings.Fields(statusLine); len(fields) > 1 {
+			r.StatusCode, _ = strconv.Atoi(fields[1])
+		}
+		header, err := reader.ReadMIMEHeader()
+		if err != nil {
+			r.Err = fmt.Errorf("could not parse response headers: %v", err)
+		}
+		r.Header = http.Header(header)
+		r.Body, _ = io.ReadAll(reader.R)
+		responses = append(responses, r)
+	}
+	return responses
+}
+
+// ByHeader classifies responses by the value of the response header (e.g. one that each version of the
+// destination adds)
+func ByHeader(name string) Classifier {
+	return func(r Response) string {
+		return r.Header.Get(name)
+	}
+}
+
+// ByBodyRegexp classifies responses by the first submatch of the regular expression in the body or,
+// if the expression has no groups, by the whole match (e.g. `one|two` for the tcp-echo responses)
+func ByBodyRegexp(expr string) Classifier {
+	re := regexp.MustCompile(expr)
+	return func(r Response) string {
+		match := re.FindSubmatch(r.Body)
+		switch {
+		case match == nil:
+			return ""
+		case len(match) > 1:
+			return string(match[1])
+		default:
+			return string(match[0])
+		}
+	}
+}
+
+// ByBookinfoReviewsVersion classifies bookinfo productpage responses by the version of the reviews service
+// ("v1", "v2" or "v3"), which the productpage shows below the reviews (see app.ParseProductPage)
+func ByBookinfoReviewsVersion() Classifier {
+	return func(r Response) string {
+		if r.StatusCode != http.StatusOK {
+			return ""
+		}
+		page, err := app.ParseProductPage(r.Body)
+		if err != nil {
+			return ""
+		}
+		return page.ReviewsVersion
+	}
+}
+
+// maxFailedShare is the share of the requests that may fail or return a response whose destination can't be
+// determined (e.g. a 503 while a proxy reconnects) before Collect fails the test
+const maxFailedShare = 0.02
+
+// Collect sends the requests and returns the distribution of the responses. Failed requests and responses that can't
+// be classified are left out of the distribution; the test fails if there are more of them than maxFailures allows.
+func Collect(t test.TestHelper, send Sender, classify Classifier, requests int) Distribution {
+	t.T().Helper()
+	responses := send(t, requests)
+	if len(responses) != requests {
+		t.Fatalf("expected %d responses, got %d", requests, len(responses))
+	}
+	distribution := Distribution{}
+	var failures []string
+	for _, r := range responses {
+		if r.Err != nil {
+			failures = append(failures, fmt.Sprintf("request failed: %v", r.Err))
+			continue
+		}
+		destination := classify(r)
+		if destination == "" {
+			failures = append(failures, fmt.Sprintf("could not determine the destination of the response (status %d):\n%s",
+				r.StatusCode, excerpt(r.Body)))
+			continue
+		}
+		distribution[destination]++
+	}
+	if len(failures) > maxFailures(requests) {
+		t.Fatalf("%d of %d requests failed or couldn't be classified (at most %d are allowed), the first one: %s",
+			len(failures), requests, maxFailures(requests), failures[0])
+	}
+	if len(failures) > 0 {
+		t.Logf("Ignoring %d of %d requests that failed or couldn't be classified, the first one: %s", len(failures), requests, failures[0])
+	}
+	return distribution
+}
+
+// maxFailures returns how many of the requests may fail (see maxFailedShare), but at least one
+func maxFailures(requests int) int {
+	return int(math.Max(1, math.Floor(maxFailedShare*float64(requests))))
+}
+
+func excerpt(body []byte) string {
+	const maxLength = 1000
+	if len(body) > maxLength {
+		return string(body[:maxLength]) + "..."
+	}
+	return string(body)
+}
@@ -0,0 +1,254 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/oc"
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/shell"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+func TestChiSquareSurvival(t *testing.T) {
+	cases := []struct {
+		x                float64
+		degreesOfFreedom int
+		expected         float64
+	}{
+		{3.841459, 1, 0.05},
+		{6.634897, 1, 0.01},
+		{5.991465, 2, 0.05},
+		{11.344867, 3, 0.01},
+		{0.454936, 1, 0.5},
+		{0, 2, 1},
+	}
+	for _, c := range cases {
+		if actual := chiSquareSurvival(c.x, c.degreesOfFreedom); math.Abs(actual-c.expected) > 1e-5 {
+			t.Errorf("chiSquareSurvival(%v, %d): expected %v, got %v", c.x, c.degreesOfFreedom, c.expected, actual)
+		}
+	}
+}
+
+func TestSampleSize(t *testing.T) {
+	cases := []struct {
+		weights  Weights
+		expected int
+	}{
+		{Weights{"v1": 0.5, "v3": 0.5}, 368},
+		{Weights{"one": 80, "two": 20}, 262},
+		{Weights{"v1": 0.98, "v2": 0.02}, 250},
+		{Weights{"v3": 1}, minRequests},
+	}
+	for _, c := range cases {
+		if actual := SampleSize(c.weights, 0.99, 0.1); actual != c.expected {
+			t.Errorf("SampleSize(%v): expected %d, got %d", c.weights, c.expected, actual)
+		}
+	}
+}
+
+func TestAssertWeights(t *testing.T) {
+	th := test.NewTestHelper(t)
+	cases := []struct {
+		name         string
+		distribution Distribution
+		weights      Weights
+		passes       bool
+	}{
+		{"even split", Distribution{"v1": 196, "v3": 172}, Weights{"v1": 0.5, "v3": 0.5}, true},
+		{"uneven split", Distribution{"v1": 221, "v3": 147}, Weights{"v1": 0.5, "v3": 0.5}, false},
+		{"80/20 split", Distribution{"one": 203, "two": 59}, Weights{"one": 0.8, "two": 0.2}, true},
+		{"all to one destination", Distribution{"v3": 50}, Weights{"v1": 0, "v3": 1}, true},
+		{"request to destination without weight", Distribution{"v1": 1, "v3": 49}, Weights{"v1": 0, "v3": 1}, false},
+		{"request to unknown destination", Distribution{"v2": 1, "v3": 49}, Weights{"v3": 1}, false},
+	}
+	for _, c := range cases {
+		attempt := retry.Attempt(th, func(t test.TestHelper) {
+			AssertWeights(t, c.distribution, c.weights, 0.99)
+		})
+		if passed := !attempt.Failed(); passed != c.passes {
+			t.Errorf("%s: got pass=%v, want %v", c.name, passed, c.passes)
+		}
+	}
+}
+
+func TestCollectToleratesFewFailures(t *testing.T) {
+	th := test.NewTestHelper(t)
+	// the first requests fail, alternately with an error and a 503, and the others are served by v1
+	sender := func(failures int) Sender {
+		return func(t test.TestHelper, requests int) []Response {
+			var responses []Response
+			for i := 0; i < requests; i++ {
+				switch {
+				case i < failures && i%2 == 0:
+					responses = append(responses, Response{Err: fmt.Errorf("connection reset")})
+				case i < failures:
+					responses = append(responses, Response{StatusCode: http.StatusServiceUnavailable})
+				default:
+					responses = append(responses, Response{Header: http.Header{"X-Version": []string{"v1"}}})
+				}
+			}
+			return responses
+		}
+	}
+	cases := []struct {
+		name     string
+		requests int
+		failures int
+		passes   bool
+	}{
+		{"no failures", 100, 0, true},
+		{"failures within the limit", 100, 2, true},
+		{"failures above the limit", 100, 3, false},
+		{"one failure in a small batch", 10, 1, true},
+		{"two failures in a small batch", 10, 2, false},
+	}
+	for _, c := range cases {
+		var distribution Distribution
+		attempt := retry.Attempt(th, func(t test.TestHelper) {
+			distribution = Collect(t, sender(c.failures), ByHeader("X-Version"), c.requests)
+		})
+		if passed := !attempt.Failed(); passed != c.passes {
+			t.Errorf("%s: got pass=%v, want %v", c.name, passed, c.passes)
+		}
+		if c.passes && distribution["v1"] != c.requests-c.failures {
+			t.Errorf("%s: expected the failures to be left out of the distribution, got %v", c.name, distribution)
+		}
+	}
+}
+
+func TestCheckWeightsRetriesOnlyUntilRouted(t *testing.T) {
+	th := test.NewTestHelper(t)
+	batches := 0
+	// the first batch still goes to the old route, and the following batches are split 70/30 instead of 50/50
+	send := func(t test.TestHelper, requests int) []Response {
+		batches++
+		var responses []Response
+		for i := 0; i < requests; i++ {
+			version := "v1"
+			if batches > 1 && i%10 >= 7 {
+				version = "v3"
+			}
+			responses = append(responses, Response{Header: http.Header{"X-Version": []string{version}}})
+		}
+		return responses
+	}
+
+	attempt := retry.Attempt(th, func(t test.TestHelper) {
+		CheckWeightsWithOptions(t, Options().Requests(200), send, ByHeader("X-Version"), Weights{"v1": 0.5, "v3": 0.5})
+	})
+	if !attempt.Failed() {
+		t.Error("expected the 70/30 split to be rejected")
+	}
+	if batches != 3 {
+		t.Errorf("expected 2 batches until the requests are routed and 1 batch for the chi-square test, got %d", batches)
+	}
+}
+
+// TestAssertWeightsFalseFailureRate checks that random samples of the computed size from the expected distribution
+// fail the check about as often as the confidence level allows, and that a shifted distribution is detected
+func TestAssertWeightsFalseFailureRate(t *testing.T) {
+	th := test.NewTestHelper(t)
+	random := rand.New(rand.NewSource(1))
+	failureRate := func(weights Weights, actualWeight float64) float64 {
+		requests := SampleSize(weights, 0.99, 0.1)
+		failures := 0
+		const trials = 1000
+		for i := 0; i < trials; i++ {
+			distribution := Distribution{}
+			for j := 0; j < requests; j++ {
+				if random.Float64() < actualWeight {
+					distribution["a"]++
+				} else {
+					distribution["b"]++
+				}
+			}
+			if retry.Attempt(th, func(t test.TestHelper) { AssertWeights(t, distribution, weights, 0.99) }).Failed() {
+				failures++
+			}
+		}
+		return float64(failures) / trials
+	}
+
+	for _, w := range []float64{0.5, 0.8} {
+		weights := Weights{"a": w, "b": 1 - w}
+		if rate := failureRate(weights, w); rate > 0.03 {
+			t.Errorf("%v: expected the check to fail in about 1%% of the trials, but it failed in %.1f%%", weights, 100*rate)
+		}
+		if rate := failureRate(weights, w-0.1); rate < 0.85 {
+			t.Errorf("%v: expected the check to detect a 10%% shift in about 90%% of the trials, but it failed in only %.1f%%", weights, 100*rate)
+		}
+	}
+}
+
+func TestFromRunner(t *testing.T) {
+	th := test.NewTestHelper(t)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-Version", fmt.Sprintf("v%d", requests%2+1))
+		fmt.Fprint(w, "hello")
+	}))
+	defer server.Close()
+
+	distribution := Collect(th, FromRunner(server.URL, nil), ByHeader("X-Version"), 10)
+	if !reflect.DeepEqual(distribution, Distribution{"v1": 5, "v2": 5}) {
+		t.Errorf("unexpected distribution: %v", distribution)
+	}
+}
+
+func TestFromPod(t *testing.T) {
+	th := test.NewTestHelper(t)
+	fake := shell.NewFakeExecutor()
+	defer shell.SetExecutor(fake)()
+	response := func(version string) string {
+		return fmt.Sprintf("HTTP/1.1 200 OK\r\ncontent-type: text/html\r\ntransfer-encoding: chunked\r\n\r\n"+
+			"<title>Simple Bookstore App</title>\n<dl>\n  <dt>Reviews served by:</dt>\n  <u>reviews-%s-5b7d5c4f9-x2vxk</u>\n</dl>\n%s\n", version, responseSeparator)
+	}
+	fake.OnPattern(`curl -sSi --http1\.1 http://productpage:9080/productpage`).Return(
+		response("v1") + response("v3") + response("v3") + "curl: (7) Failed to connect\n" + responseSeparator + "\n")
+	fake.OnPattern(`nc tcp-echo 9000`).Return("one Mon Jan 1\ntwo Mon Jan 1\none Mon Jan 1\n")
+
+	sleep := func(t test.TestHelper, _ *oc.OC) oc.NamespacedName {
+		return oc.NewNamespacedName("foo", "sleep-1")
+	}
+	responses := FromPod(sleep, "sleep", "http://productpage:9080/productpage")(th, 4)
+	if len(responses) != 4 {
+		t.Fatalf("expected 4 responses, got %d", len(responses))
+	}
+	var versions []string
+	for _, r := range responses[:3] {
+		versions = append(versions, ByBookinfoReviewsVersion()(r))
+	}
+	if !reflect.DeepEqual(versions, []string{"v1", "v3", "v3"}) || responses[0].Header.Get("Content-Type") != "text/html" {
+		t.Errorf("unexpected responses: %v", versions)
+	}
+	if responses[3].Err == nil || !strings.Contains(responses[3].Err.Error(), "Failed to connect") {
+		t.Errorf("expected the last request to fail, got %+v", responses[3])
+	}
+
+	distribution := Collect(th, TCPFromPod(sleep, "sleep", "tcp-echo", "9000"), ByBodyRegexp(`one|two`), 3)
+	if !reflect.DeepEqual(distribution, Distribution{"one": 2, "two": 1}) {
+		t.Errorf("unexpected distribution: %v", distribution)
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/maistra/maistra-test-tool/pkg/util/retry"
+	"github.com/maistra/maistra-test-tool/pkg/util/test"
+)
+
+// Weights are the expected shares of the requests by destination (e.g. {"v1": 0.8, "v2": 0.2}). Destinations
+// that are not listed, or have a zero weight, must not receive any request.
+type Weights map[string]float64
+
+// minRequests is the minimum number of requests, which is also used when all requests go to a single destination
+const minRequests = 50
+
+// power is the probability with which the check detects a deviation from the weights by the margin
+const power = 0.9
+
+// minExpectedCount is the smallest expected number of responses per destination for which the chi-square
+// distribution approximates the test statistic well
+const minExpectedCount = 5
+
+type CheckOptions struct {
+	requests   int
+	confidence float64
+	margin     float64
+}
+
+var defaultOptions = CheckOptions{
+	confidence: 0.99,
+	margin:     0.1,
+}
+
+func Options() CheckOptions {
+	return defaultOptions
+}
+
+// Requests sets the number of requests instead of computing it from the weights (see SampleSize)
+func (o CheckOptions) Requests(requests int) CheckOptions {
+	o.requests = requests
+	return o
+}
+
+// Confidence sets the probability that the check passes when the traffic is distributed as expected (0.99 by default)
+func (o CheckOptions) Confidence(confidence float64) CheckOptions {
+	o.confidence = confidence
+	return o
+}
+
+// Margin sets the deviation from the expected weights that the check must detect (0.1 by default, i.e. a 50/50 split
+// fails if the actual split is 60/40). Smaller margins need more requests (see SampleSize).
+func (o CheckOptions) Margin(margin float64) CheckOptions {
+	o.margin = margin
+	return o
+}
+
+// CheckWeights waits until the requests reach the destinations of the weights, which allows for the time it takes
+// until the proxies apply a new route, and then checks the distribution of the requests (see CheckWeightsWithOptions)
+func CheckWeights(t test.TestHelper, send Sender, classify Classifier, weights Weights) {
+	t.T().Helper()
+	CheckWeightsWithOptions(t, Options(), send, classify, weights)
+}
+
+// CheckWeightsWithOptions sends batches of requests until all destinations with a weight, and no other destination,
+// receive requests. It then classifies the responses of a new batch and checks their distribution with AssertWeights.
+// The chi-square test isn't retried, since the check would then pass eventually even if the weights were wrong.
+func CheckWeightsWithOptions(t test.TestHelper, options CheckOptions, send Sender, classify Classifier, weights Weights) {
+	t.T().Helper()
+	requests := options.requests
+	if requests == 0 {
+		requests = SampleSize(weights, options.confidence, options.margin)
+	}
+	t.Logf("Wait until the requests are routed to %s", weights)
+	retry.UntilSuccess(t, func(t test.TestHelper) {
+		t.T().Helper()
+		assertRouted(t, Collect(t, send, classify, requests), weights)
+	})
+	t.Logf("Send %d requests and check that they're distributed as %s", requests, weights)
+	AssertWeights(t, Collect(t, send, classify, requests), weights, options.confidence)
+}
+
+// assertRouted fails the test unless all destinations with a weight, and no other destination, received requests
+func assertRouted(t test.TestHelper, distribution Distribution, weights Weights) {
+	t.T().Helper()
+	normalized := weights.normalized()
+	var destinations []string
+	for destination := range normalized {
+		destinations = append(destinations, destination)
+	}
+	for destination := range distribution {
+		if _, found := normalized[destination]; !found {
+			destinations = append(destinations, destination)
+		}
+	}
+	sort.Strings(destinations)
+	for _, destination := range destinations {
+		if normalized[destination] == 0 && distribution[destination] > 0 {
+			t.Errorf("%s should not receive any requests, but received %d (distribution: %s)",
+				destination, distribution[destination], distribution)
+			return
+		}
+		if normalized[destination] > 0 && distribution[destination] == 0 {
+			t.Errorf("%s didn't receive any requests (distribution: %s)", destination, distribution)
+			return
+		}
+	}
+}
+
+// SampleSize returns the number of requests for which the chi-square test detects a deviation of each weight by
+// the margin with a probability of 90% (see power), while passing with the confidence level if there's no deviation.
+// Every destination is also expected to receive enough requests for the chi-square approximation to hold.
+func SampleSize(weights Weights, confidence, margin float64) int {
+	zConfidence := math.Sqrt2 * math.Erfinv(confidence)
+	zPower := math.Sqrt2 * math.Erfinv(2*power-1)
+	requests := float64(minRequests)
+	for _, w := range weights.normalized() {
+		if w <= 0 || w >= 1 {
+			continue
+		}
+		for _, shifted := range []float64{w - margin, w + margin} {
+			if shifted <= 0 || shifted >= 1 {
+				continue
+			}
+			n := (zConfidence*math.Sqrt(w*(1-w)) + zPower*math.Sqrt(shifted*(1-shifted))) / margin
+			requests = math.Max(requests, n*n)
+		}
+		requests = math.Max(requests, minExpectedCount/w)
+	}
+	return int(math.Ceil(requests))
+}
+
+// AssertWeights fails the test if a destination without weight received requests or if a chi-square goodness-of-fit
+// test rejects the hypothesis that the requests were distributed according to the weights at the confidence level
+func AssertWeights(t test.TestHelper, distribution Distribution, weights Weights, confidence float64) {
+	t.T().Helper()
+	total := distribution.Total()
+	normalized := weights.normalized()
+	for destination, count := range distribution {
+		if normalized[destination] == 0 && count > 0 {
+			t.Errorf("%d/%d responses came from %s, which should not receive any requests (distribution: %s)",
+				count, total, destination, distribution)
+			return
+		}
+	}
+
+	var destinations []string
+	chiSquare := 0.0
+	for destination, w := range normalized {
+		if w > 0 {
+			destinations = append(destinations, destination)
+			expected := w * float64(total)
+			chiSquare += math.Pow(float64(distribution[destination])-expected, 2) / expected
+		}
+	}
+	sort.Strings(destinations)
+	var summary []string
+	for _, destination := range destinations {
+		summary = append(summary, fmt.Sprintf("%s: %d/%d (%.1f%%, expected %.1f%%)", destination, distribution[destination], total,
+			100*float64(distribution[destination])/float64(total), 100*normalized[destination]))
+	}
+
+	if len(destinations) < 2 {
+		t.LogSuccessf("all %d responses came from %s", total, strings.Join(destinations, ""))
+		return
+	}
+	pValue := chiSquareSurvival(chiSquare, len(destinations)-1)
+	if pValue < 1-confidence {
+		t.Errorf("the responses are not distributed as expected (chi-square p-value %.4f < %.4f): %s",
+			pValue, 1-confidence, strings.Join(summary, ", "))
+		return
+	}
+	t.LogSuccessf("the responses are distributed as expected (chi-square p-value %.4f): %s", pValue, strings.Join(summary, ", "))
+}
+
+func (w Weights) normalized() Weights {
+	sum := 0.0
+	for _, weight := range w {
+		sum += weight
+	}
+	normalized := Weights{}
+	for destination, weight := range w {
+		if sum > 0 {
+			normalized[destination] = weight / sum
+		}
+	}
+	return normalized
+}
+
+func (w Weights) String() string {
+	var destinations []string
+	for destination, weight := range w.normalized() {
+		destinations = append(destinations, fmt.Sprintf("%s: %.3g%%", destination, 100*weight))
+	}
+	sort.Strings(destinations)
+	return strings.Join(destinations, ", ")
+}
+
+// chiSquareSurvival returns the probability that a chi-square distributed variable with the degrees of freedom
+// is at least x, i.e. the p-value of the test statistic x
+func chiSquareSurvival(x float64, degreesOfFreedom int) float64 {
+	if x <= 0 {
+		return 1
+	}
+	return upperRegularizedGamma(float64(degreesOfFreedom)/2, x/2)
+}
+
+// upperRegularizedGamma returns Q(a, x) = Γ(a, x) / Γ(a), computed with its series expansion for x < a+1 and
+// with its continued fraction otherwise (see Numerical Recipes, section 6.2)
+func upperRegularizedGamma(a, x float64) float64 {
+	const (
+		maxIterations = 1000
+		epsilon       = 1e-15
+		tiny          = 1e-300
+	)
+	lgamma, _ := math.Lgamma(a)
+	prefactor := math.Exp(-x + a*math.Log(x) - lgamma)
+
+	if x < a+1 {
+		term := 1 / a
+		sum := term
+		for n := 1; n < maxIterations; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*epsilon {
+				break
+			}
+		}
+		return 1 - sum*prefactor
+	}
+
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i < maxIterations; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return h * prefactor
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Range is a set of versions described by an expression like ">=2.4 <2.7". Comparators separated by
+// spaces must all be satisfied, and alternatives can be separated by "||" (e.g. "<2.3 || >=2.5").
+// Supported operators are =, >, >=, < and <=; a version without an operator means "=".
+//
+// If the version in a comparator has no patch version, the patch version is ignored when comparing,
+// so "=2.6" and "<=2.6" include 2.6.2, while ">2.6" does not.
+type Range struct {
+	expr         string
+	alternatives [][]comparator
+}
+
+type comparator struct {
+	op         string
+	version    Version
+	majorMinor bool
+}
+
+// ParseRange parses the range expression
+func ParseRange(expr string) (Range, error) {
+	r := Range{expr: expr}
+	for _, alternative := range strings.Split(expr, "||") {
+		fields := strings.Fields(alternative)
+		if len(fields) == 0 {
+			return Range{}, fmt.Errorf("invalid version range %q: empty alternative", expr)
+		}
+		var comparators []comparator
+		for _, field := range fields {
+			c, err := parseComparator(field)
+			if err != nil {
+				return Range{}, fmt.Errorf("invalid version range %q: %v", expr, err)
+			}
+			comparators = append(comparators, c)
+		}
+		r.alternatives = append(r.alternatives, comparators)
+	}
+	return r, nil
+}
+
+// MustParseRange parses the range expression like ParseRange, but panics if the expression is invalid
+func MustParseRange(expr string) Range {
+	r, err := ParseRange(expr)
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
+}
+
+func parseComparator(str string) (comparator, error) {
+	op := "="
+	for _, o := range []string{">=", "<=", "==", ">", "<", "="} {
+		if strings.HasPrefix(str, o) {
+			op = o
+			str = strings.TrimPrefix(str, o)
+			break
+		}
+	}
+	if op == "==" {
+		op = "="
+	}
+	v, components, err := parse(str)
+	if err != nil {
+		return comparator{}, err
+	}
+	return comparator{op: op, version: v, majorMinor: components == 2}, nil
+}
+
+// Contains returns true if the version satisfies the range
+func (r Range) Contains(v Version) bool {
+	for _, comparators := range r.alternatives {
+		if allSatisfied(comparators, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func allSatisfied(comparators []comparator, v Version) bool {
+	for _, c := range comparators {
+		if !c.satisfiedBy(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c comparator) satisfiedBy(v Version) bool {
+	if c.majorMinor {
+		v = v.MajorMinor()
+	}
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return cmp == 0
+	}
+}
+
+func (r Range) String() string {
+	return r.expr
+}
@@ -20,62 +20,182 @@ import (
 	"strings"
 )
 
+// Version is a semantic version (https://semver.org). SMCP versions are usually specified without
+// the patch version (e.g. "v2.6"), in which case Patch is 0.
 type Version struct {
 	Major int
 	Minor int
+	Patch int
+	// PreRelease is the pre-release version without the leading "-" (e.g. "rc.5" in "4.18.0-rc.5")
+	PreRelease string
+	// Build is the build metadata without the leading "+". It is ignored when comparing versions.
+	Build string
 }
 
+// ParseVersion parses the version like Parse, but panics if the version is invalid.
+// It's meant to be used for versions that are known to be valid (constants, env vars).
 func ParseVersion(version string) Version {
-	// This func can handle smcp versions and ocp versions. Example: smcp version v2.1.0 and ocp version 4.10.0
-	if len(version) > 0 && version[0] == 'v' {
-		version = version[1:]
+	v, err := Parse(version)
+	if err != nil {
+		panic(err.Error())
 	}
+	return v
+}
 
-	majorMinor := strings.Split(version, ".")
+// Parse parses versions in the formats used by SMCP versions (v2.6), operator versions (2.6.2)
+// and OCP versions (4.18.0-rc.5, 4.19.0-0.nightly-2025-01-01-000000)
+func Parse(version string) (Version, error) {
+	v, _, err := parse(version)
+	return v, err
+}
 
-	if len(majorMinor) < 2 {
-		panic(fmt.Sprintf("invalid version: %s", version))
+// parse returns the parsed version and the number of components (major, minor, patch) that were specified
+func parse(version string) (Version, int, error) {
+	invalid := func() (Version, int, error) {
+		return Version{}, 0, fmt.Errorf("invalid version: %s", version)
 	}
 
-	major, err := strconv.Atoi(majorMinor[0])
-	if err != nil {
-		panic(fmt.Sprintf("invalid version: %s", version))
+	str := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	var v Version
+	if i := strings.Index(str, "+"); i >= 0 {
+		v.Build = str[i+1:]
+		str = str[:i]
+		if v.Build == "" {
+			return invalid()
+		}
+	}
+	if i := strings.Index(str, "-"); i >= 0 {
+		v.PreRelease = str[i+1:]
+		str = str[:i]
+		if v.PreRelease == "" {
+			return invalid()
+		}
 	}
 
-	minor, err := strconv.Atoi(majorMinor[1])
-	if err != nil {
-		panic(fmt.Sprintf("invalid version: %s", version))
+	parts := strings.Split(str, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return invalid()
+	}
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return invalid()
+		}
+		*numbers[i] = n
+	}
+	return v, len(parts), nil
+}
+
+// Compare returns -1, 0 or 1 if this version is lower than, equal to or greater than that version,
+// following the semantic versioning precedence rules (e.g. 4.18.0-rc.5 < 4.18.0)
+func (this Version) Compare(that Version) int {
+	if c := compareInts(this.Major, that.Major); c != 0 {
+		return c
+	}
+	if c := compareInts(this.Minor, that.Minor); c != 0 {
+		return c
+	}
+	if c := compareInts(this.Patch, that.Patch); c != 0 {
+		return c
 	}
+	return comparePreRelease(this.PreRelease, that.PreRelease)
+}
+
+func compareInts(a, b int) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
 
-	return Version{Major: major, Minor: minor}
+func comparePreRelease(a, b string) int {
+	// a version without a pre-release has a higher precedence than the same version with one
+	if a == "" || b == "" {
+		return -compareInts(len(a), len(b))
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareInts(an, bn); c != 0 {
+				return c
+			}
+		case aErr == nil: // numeric identifiers have lower precedence than alphanumeric ones
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInts(len(as), len(bs))
 }
 
 func (this Version) Equals(that Version) bool {
-	return this.Major == that.Major && this.Minor == that.Minor
+	return this.Compare(that) == 0
 }
 
 func (this Version) GreaterThan(that Version) bool {
-	return !this.LessThanOrEqual(that)
+	return this.Compare(that) > 0
 }
 
 func (this Version) GreaterThanOrEqual(that Version) bool {
-	return !this.LessThan(that)
+	return this.Compare(that) >= 0
 }
 
 func (this Version) LessThan(that Version) bool {
-	if this.Major < that.Major {
-		return true
-	} else if this.Major > that.Major {
-		return false
-	} else { // this.Major == that.Major
-		return this.Minor < that.Minor
-	}
+	return this.Compare(that) < 0
 }
 
 func (this Version) LessThanOrEqual(that Version) bool {
-	return this.LessThan(that) || this.Equals(that)
+	return this.Compare(that) <= 0
+}
+
+// MajorMinor returns the version without the patch version, pre-release and build metadata (e.g. 2.6.2 -> 2.6)
+func (this Version) MajorMinor() Version {
+	return Version{Major: this.Major, Minor: this.Minor}
+}
+
+// Core returns the version without the pre-release and build metadata (e.g. 4.18.0-rc.5 -> 4.18.0).
+// OCP release candidates and nightly builds already contain the features of the release, so OCP versions
+// should be compared using Core().
+func (this Version) Core() Version {
+	return Version{Major: this.Major, Minor: this.Minor, Patch: this.Patch}
 }
 
+// String returns the version prefixed with "v". The patch version is omitted if it is 0 and there's no
+// pre-release or build metadata, so that SMCP versions are printed as e.g. "v2.6".
 func (this Version) String() string {
-	return fmt.Sprintf("v%d.%d", this.Major, this.Minor)
+	str := fmt.Sprintf("v%d.%d", this.Major, this.Minor)
+	if this.Patch != 0 || this.PreRelease != "" || this.Build != "" {
+		str += fmt.Sprintf(".%d", this.Patch)
+	}
+	if this.PreRelease != "" {
+		str += "-" + this.PreRelease
+	}
+	if this.Build != "" {
+		str += "+" + this.Build
+	}
+	return str
+}
+
+// MarshalText encodes the version as returned by String, so that versions are readable in JSON reports
+func (this Version) MarshalText() ([]byte, error) {
+	return []byte(this.String()), nil
+}
+
+func (this *Version) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*this = v
+	return nil
 }
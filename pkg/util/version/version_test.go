@@ -14,11 +14,123 @@
 
 package version
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+)
 
 func TestParseVersion(t *testing.T) {
 	assertVersionParsedTo(t, "v2.3", SMCP_2_3)
 	assertVersionParsedTo(t, "2.3", SMCP_2_3)
+	assertVersionParsedTo(t, "2.6.2", Version{Major: 2, Minor: 6, Patch: 2})
+	assertVersionParsedTo(t, "4.18.0-rc.5", Version{Major: 4, Minor: 18, PreRelease: "rc.5"})
+	assertVersionParsedTo(t, "v1.23.17+16bcd69", Version{Major: 1, Minor: 23, Patch: 17, Build: "16bcd69"})
+}
+
+func TestParseReturnsErrorForInvalidVersion(t *testing.T) {
+	for _, str := range []string{"", "2", "2.x", "2.6.2.1", "4.18.0-", "-1.0"} {
+		if _, err := Parse(str); err == nil {
+			t.Errorf("expected error for %q", str)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	for str, expected := range map[string]string{
+		"2.6":           "v2.6",
+		"2.6.0":         "v2.6",
+		"2.6.2":         "v2.6.2",
+		"4.18.0-rc.5":   "v4.18.0-rc.5",
+		"4.18.0+abcdef": "v4.18.0+abcdef",
+	} {
+		if actual := ParseVersion(str).String(); actual != expected {
+			t.Errorf("expected %q to be printed as %q, but was %q", str, expected, actual)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(map[string]Version{"ocp": ParseVersion("4.18.0-rc.5"), "smcp": SMCP_2_6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"ocp":"v4.18.0-rc.5","smcp":"v2.6"}` {
+		t.Fatalf("unexpected JSON: %s", data)
+	}
+	var decoded map[string]Version
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["ocp"] != ParseVersion("4.18.0-rc.5") || decoded["smcp"] != SMCP_2_6 {
+		t.Fatalf("unexpected versions: %v", decoded)
+	}
+}
+
+func TestComparePatchAndPreRelease(t *testing.T) {
+	assertTrue(t, OPERATOR_2_6_0.LessThan(OPERATOR_2_6_2))
+	assertTrue(t, OPERATOR_2_5_2.LessThan(OPERATOR_2_6_0))
+	assertTrue(t, ParseVersion("4.18.0-rc.5").LessThan(OCP_4_18))
+	assertTrue(t, ParseVersion("4.18.0-rc.5").LessThan(ParseVersion("4.18.0-rc.10")))
+	assertTrue(t, ParseVersion("4.18.0-1").LessThan(ParseVersion("4.18.0-rc")))
+	assertTrue(t, ParseVersion("4.18.0-rc").LessThan(ParseVersion("4.18.0-rc.1")))
+	assertTrue(t, ParseVersion("4.18.0-rc.5").Core().Equals(OCP_4_18))
+	assertTrue(t, ParseVersion("4.18.0+a").Equals(ParseVersion("4.18.0+b")))
+	assertTrue(t, OPERATOR_2_6_2.MajorMinor().Equals(SMCP_2_6))
+}
+
+func TestRange(t *testing.T) {
+	cases := []struct {
+		expr     string
+		included []string
+		excluded []string
+	}{
+		{
+			expr:     ">=2.4 <2.7",
+			included: []string{"2.4", "2.6.2"},
+			excluded: []string{"2.3.9", "2.7.0"},
+		},
+		{
+			expr:     "=2.6",
+			included: []string{"2.6.0", "2.6.2"},
+			excluded: []string{"2.5.2", "2.7.0"},
+		},
+		{
+			expr:     "<=4.18",
+			included: []string{"4.17.3", "4.18.9"},
+			excluded: []string{"4.19.0-rc.1"},
+		},
+		{
+			expr:     ">2.6.0",
+			included: []string{"2.6.1-rc.1", "2.6.2"},
+			excluded: []string{"2.6.0", "2.6.0+build", "2.5.9"},
+		},
+		{
+			expr:     "<2.3 || 2.5.2",
+			included: []string{"2.2", "2.5.2"},
+			excluded: []string{"2.3", "2.5.3"},
+		},
+	}
+	for _, c := range cases {
+		r := MustParseRange(c.expr)
+		for _, v := range c.included {
+			if !r.Contains(ParseVersion(v)) {
+				t.Errorf("expected range %q to contain %s", c.expr, v)
+			}
+		}
+		for _, v := range c.excluded {
+			if r.Contains(ParseVersion(v)) {
+				t.Errorf("expected range %q not to contain %s", c.expr, v)
+			}
+		}
+	}
+}
+
+func TestParseRangeReturnsErrorForInvalidExpression(t *testing.T) {
+	for _, expr := range []string{"", ">=2.4 ||", "~2.4", ">=x"} {
+		if _, err := ParseRange(expr); err == nil {
+			t.Errorf("expected error for %q", expr)
+		}
+	}
 }
 
 func assertVersionParsedTo(t *testing.T, str string, expectedVersion Version) {
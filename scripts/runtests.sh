@@ -24,6 +24,12 @@ OPERATOR_VERSION="${OPERATOR_VERSION#v}" # remove "v" prefix if necessary
 
 echo "OSSM Operator version is $OPERATOR_VERSION"
 
+# number of test packages executed concurrently; only increase this once all tests that modify
+# cluster-scoped resources acquire an exclusive lease (see pkg/util/lease)
+TEST_PACKAGE_PARALLELISM=${TEST_PACKAGE_PARALLELISM:-"1"}
+# number of tests that call t.Parallel() executed concurrently within each package
+TEST_PARALLELISM=${TEST_PARALLELISM:-"4"}
+
 case "$OPERATOR_VERSION" in
     2.3.*) SUPPORTED_VERSIONS=("v2.1" "v2.2" "v2.3") ;;
     2.4.*) SUPPORTED_VERSIONS=("v2.2" "v2.3" "v2.4") ;;
@@ -77,7 +83,7 @@ runTestsAgainstVersion() {
         --rerun-fails=2 --rerun-fails-max-failures 10 --rerun-fails-run-root-test --rerun-fails-report "$RERUNS_FILE" \
         --junitfile "$REPORT_FILE" --junitfile-project-name "maistra-test-tool-$SMCP_VERSION" --junitfile-hide-empty-pkg \
         --junitfile-testsuite-name relative --junitfile-testcase-classname relative \
-        -- -timeout 1h -count 1 -p 1 2>&1 \
+        -- -timeout 1h -count 1 -p "$TEST_PACKAGE_PARALLELISM" -parallel "$TEST_PARALLELISM" 2>&1 \
         | tee -a "$LOG_FILE"
     else
         logHeader "Executing $TEST_CASE against SMCP $SMCP_VERSION"
@@ -108,6 +114,9 @@ resetCluster() {
     else
         oc delete namespace istio-system bookinfo foo bar legacy mesh-external cert-manager --ignore-not-found
     fi
+    # namespaces created by ns.Unique() and leases left behind by killed test processes
+    oc delete namespace -l maistra.io/maistra-test-tool=test-bound-ns --ignore-not-found
+    oc delete lease -n default -l maistra.io/maistra-test-tool=lease --ignore-not-found
     echo
 }
 
@@ -240,7 +249,9 @@ timedMain() {
 }
 
 
-export OUTPUT_DIR_BASE="$PWD/tests/result-$(date +%Y%m%d%H%M%S)"
+# all objects created by the tests are labeled with the run ID (see env.GetRunId())
+export RUN_ID=${RUN_ID:-"$(date +%Y%m%d%H%M%S)"}
+export OUTPUT_DIR_BASE="$PWD/tests/result-$RUN_ID"
 echo "Output dir: $OUTPUT_DIR_BASE"
 mkdir -p "$OUTPUT_DIR_BASE"
 ln -sfn "$OUTPUT_DIR_BASE" "$PWD/tests/result-latest"